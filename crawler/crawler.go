@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"regexp"
 	"strings"
 	"time"
@@ -16,38 +17,104 @@ import (
 	"golang.org/x/net/html/atom"
 )
 
+// ErrPageGone is returned by AnalyzePage if the page doesn't exist anymore
+var ErrPageGone = errors.New("page gone")
+
 type Crawler interface {
-	AnalyzePage(url string) (title string, tokens, edgeURLs []string, err error)
+	AnalyzePage(ctx context.Context, url string) (*Page, error)
 	Crawl([]string)
 	SetDoogleClient(cl doogle.DoogleClient)
 }
 
+// Page is the information extracted from a web page
+type Page struct {
+	Title    string
+	Tokens   []*Token
+	EdgeURLs []string
+
+	// primary language subtag of the page, e.g. "en". empty if unknown
+	Lang string
+
+	// SimHash fingerprint of the terms on the page
+	SimHash uint64
+
+	// anchor texts describing the pages on EdgeURLs
+	Anchors []*Anchor
+}
+
+// Token is a term on a page with the fields it occurred in
+type Token struct {
+	Term   string
+	Fields []doogle.Field
+}
+
+// Anchor is the terms in anchor texts of links to URL
+type Anchor struct {
+	URL   string
+	Terms []string
+}
+
+func (a *Anchor) addTerm(term string) {
+	for _, t := range a.Terms {
+		if t == term {
+			return
+		}
+	}
+	a.Terms = append(a.Terms, term)
+}
+
+func (t *Token) addField(f doogle.Field) {
+	for _, e := range t.Fields {
+		if e == f {
+			return
+		}
+	}
+	t.Fields = append(t.Fields, f)
+}
+
+const (
+	// timeout on a request to a web server
+	fetchTimeout = 30 * time.Second
+
+	// timeout on fetching a page popped from the frontier, which includes its robots.txt
+	workerFetchTimeout = 2 * fetchTimeout
+
+	// timeout on posting a found url to the node, which crawls and indexes it
+	postTimeout = 2 * time.Minute
+)
+
 type doogleCrawler struct {
 	tokenRegex *regexp.Regexp
-	urlRegex   *regexp.Regexp
 	dClient    doogle.DoogleClient
-	queue      chan string
+	frontier   *frontier
 	logger     *logrus.Logger
+
+	userAgent string
+	client    *http.Client
+	robots    *robotsCache
 }
 
 var _ Crawler = &doogleCrawler{}
 
-func NewCrawler(queueCap, numWorker int, logger *logrus.Logger) (Crawler, error) {
-	tRegex, err := regexp.Compile("^[A-Za-z0-9]+$")
-	if err != nil {
-		return nil, errors.Errorf("failed to compile tokenRegexp: %v", err)
+// NewCrawler creates a crawler which obeys the rules of robots.txt for userAgent and limits requests to each host
+func NewCrawler(queueCap, numWorker int, userAgent string, p Politeness, logger *logrus.Logger) (Crawler, error) {
+	if p.MaxConnsPerHost < 1 || p.MinDelay < 0 || p.MaxDelay < p.MinDelay {
+		return nil, errors.Errorf("invalid politeness: %+v", p)
 	}
 
-	urlRegex, err := regexp.Compile(`^(http:\/\/www\.|https:\/\/www\.|http:\/\/|https:\/\/)?[a-z0-9]+([\-\.]{1}[a-z0-9]+)*\.[a-z]{2,5}(:[0-9]{1,5})?(\/.*)?$`)
+	tRegex, err := regexp.Compile("^[A-Za-z0-9]+$")
 	if err != nil {
 		return nil, errors.Errorf("failed to compile tokenRegexp: %v", err)
 	}
 
+	client := &http.Client{Timeout: fetchTimeout}
 	crawler := &doogleCrawler{
 		tokenRegex: tRegex,
-		urlRegex:   urlRegex,
 		logger:     logger,
-		queue:      make(chan string, queueCap),
+		frontier:   newFrontier(queueCap, p),
+		userAgent:  userAgent,
+		client:     client,
+		robots:     newRobotsCache(userAgent, client),
 	}
 
 	for i := 0; i < numWorker; i++ {
@@ -62,28 +129,72 @@ func (c *doogleCrawler) SetDoogleClient(cl doogle.DoogleClient) {
 }
 
 func (c *doogleCrawler) Crawl(urls []string) {
-	if cap(c.queue) < 1 {
-		return
+	for _, u := range urls {
+		if nu, err := NormalizeURL(u); err == nil {
+			// if the frontier is full, ignore it
+			c.frontier.push(nu)
+		}
 	}
+}
 
-	for _, url := range urls {
-		if c.urlRegex.MatchString(url) {
-			select {
-			case c.queue <- url:
-			default: // if the queue is full, ignore it
-			}
-		}
+// AnalyzePage fetches the page as soon as its host can be requested, and analyzes it.
+// it gives up waiting for the host or the response when ctx is done
+func (c *doogleCrawler) AnalyzePage(ctx context.Context, rawURL string) (*Page, error) {
+	host := hostKey(rawURL)
+	if err := c.frontier.acquire(ctx, host); err != nil {
+		return nil, errors.Errorf("failed to wait for host: %v", err)
 	}
+	return c.fetch(ctx, rawURL, host)
 }
 
-func (c *doogleCrawler) AnalyzePage(url string) (string, []string, []string, error) {
-	res, err := http.Get(url)
+// fetch analyzes the page on the host acquired from the frontier, and releases the host
+func (c *doogleCrawler) fetch(ctx context.Context, rawURL, host string) (*Page, error) {
+	var s *fetchStats
+	defer func() {
+		// the canceled request tells nothing about the host
+		if ctx.Err() != nil {
+			s = nil
+		}
+		c.frontier.release(host, s, time.Now())
+	}()
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, errors.Errorf("failed to parse url: %v", err)
+	}
+
+	crawlDelay, err := c.robots.check(ctx, u)
 	if err != nil {
-		return "", nil, nil, errors.Errorf("failed to https.Get: %v", err)
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, errors.Errorf("failed to create request: %v", err)
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("User-Agent", c.userAgent)
+
+	s = &fetchStats{crawlDelay: crawlDelay}
+	start := time.Now()
+	res, err := c.client.Do(req)
+	s.elapsed = time.Since(start)
+	if err != nil {
+		s.failed = true
+		return nil, errors.Errorf("failed to get page: %v", err)
 	}
 
 	defer res.Body.Close()
-	return c.analyze(res.Body, url)
+
+	s.status = res.StatusCode
+	s.retryAfter = retryAfter(res.Header.Get("Retry-After"), time.Now())
+
+	if res.StatusCode == http.StatusNotFound || res.StatusCode == http.StatusGone {
+		return nil, ErrPageGone
+	} else if s.overloaded() {
+		return nil, errors.Errorf("host overloaded: %s", res.Status)
+	}
+	return c.analyze(res.Body, rawURL)
 }
 
 func (c *doogleCrawler) worker(id int) {
@@ -91,18 +202,20 @@ func (c *doogleCrawler) worker(id int) {
 	c.logger.Info(workerFmt, " started")
 
 	for {
-		time.Sleep(1 * time.Second)
-
-		url, _ := <-c.queue
-		_, _, urls, err := c.AnalyzePage(url)
+		url, host := c.frontier.pop()
+		ctx, cancel := context.WithTimeout(context.Background(), workerFetchTimeout)
+		page, err := c.fetch(ctx, url, host)
+		cancel()
 		if err != nil {
 			continue
 		}
 
-		for _, url := range urls {
-			_, err := c.dClient.PostUrl(context.Background(), &doogle.StringMessage{
+		for _, url := range page.EdgeURLs {
+			ctx, cancel := context.WithTimeout(context.Background(), postTimeout)
+			_, err := c.dClient.PostUrl(ctx, &doogle.StringMessage{
 				Message: url,
 			})
+			cancel()
 			if err != nil {
 				c.logger.Errorf("%s PostUrl failed : %v", workerFmt, err)
 			}
@@ -110,60 +223,187 @@ func (c *doogleCrawler) worker(id int) {
 	}
 }
 
-func (c *doogleCrawler) analyze(body io.Reader, target string) (string, []string, []string, error) {
+func (c *doogleCrawler) analyze(body io.Reader, target string) (*Page, error) {
 	doc := html.NewTokenizer(body)
-	var title string
-	var tokens []string
-	var edgeURLs []string
+	page := &Page{}
+	termToToken := map[string]*Token{}
+	urlToAnchor := map[string]*Anchor{}
+
+	// term frequencies for SimHash
+	tf := map[string]int{}
 
 	selected := map[string]struct{}{}
 	selected[target] = struct{}{}
 
-	for tokenType := doc.Next(); tokenType != html.ErrorToken; {
+	// relative links are resolved against the url of the page, or <base href> if any
+	base, err := url.Parse(target)
+	if err != nil {
+		base = &url.URL{}
+	}
+	if self, err := NormalizeURL(target); err == nil {
+		selected[self] = struct{}{}
+	}
+	var hasBase bool
+	setBase := func(token html.Token) {
+		for _, attr := range token.Attr {
+			if attr.Key != "href" || hasBase {
+				continue
+			}
+
+			if b, err := base.Parse(strings.TrimSpace(attr.Val)); err == nil {
+				base, hasBase = b, true
+			}
+		}
+	}
+
+	terms := func(text string) []string {
+		var ret []string
+		for _, w := range strings.Split(text, " ") {
+			w := strings.ToLower(w)
+			if _, ok := selected[w]; ok || !c.tokenRegex.MatchString(w) {
+				continue
+			}
+			ret = append(ret, w)
+		}
+		return ret
+	}
+
+	addTokens := func(text string, field doogle.Field) {
+		for _, w := range terms(text) {
+			tf[w]++
+
+			tk, ok := termToToken[w]
+			if !ok {
+				tk = &Token{Term: w}
+				termToToken[w] = tk
+				page.Tokens = append(page.Tokens, tk)
+			}
+			tk.addField(field)
+		}
+	}
+
+	// depth of the heading tags surrounding the current token
+	var inHeading int
+
+	// anchor of the link surrounding the current token
+	var anchor *Anchor
+	for tokenType := doc.Next(); tokenType != html.ErrorToken; tokenType = doc.Next() {
 		token := doc.Token()
 
-		if tokenType == html.TextToken {
-			for _, w := range strings.Split(token.Data, " ") {
-				w := strings.ToLower(w)
-				_, ok := selected[w]
-				if c.tokenRegex.MatchString(w) && !ok {
-					tokens = append(tokens, w)
+		switch tokenType {
+		case html.TextToken:
+			// anchor texts describe the linked page, and are body texts of this page
+			if anchor != nil {
+				for _, w := range terms(token.Data) {
+					anchor.addTerm(w)
 				}
 			}
-		}
 
-		if tokenType == html.StartTagToken {
-			if token.Data == "title" {
+			if inHeading > 0 {
+				addTokens(token.Data, doogle.Field_HEADING)
+			} else {
+				addTokens(token.Data, doogle.Field_BODY)
+			}
+		case html.StartTagToken:
+			switch token.DataAtom {
+			case atom.Html:
+				for _, attr := range token.Attr {
+					if attr.Key == "lang" {
+						page.Lang = normalizeLang(attr.Val)
+					}
+				}
+			case atom.Meta:
+				// lang attribute on <html> has priority
+				if page.Lang == "" {
+					page.Lang = metaLang(token)
+				}
+			case atom.Title:
 				doc.Next()
-				title = doc.Token().String()
+				page.Title = doc.Token().String()
+				addTokens(page.Title, doogle.Field_TITLE)
+			case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6:
+				inHeading++
+			case atom.Base:
+				setBase(token)
+			case atom.A:
+				anchor = nil
+				for _, attr := range token.Attr {
+					if attr.Key != "href" {
+						continue
+					}
+
+					u, err := resolveURL(base, attr.Val)
+					if _, ok := selected[u]; err != nil || ok {
+						continue
+					}
+					page.EdgeURLs = append(page.EdgeURLs, u)
 
-				for _, w := range strings.Split(title, " ") {
-					w := strings.ToLower(w)
-					_, ok := selected[w]
-					if c.tokenRegex.MatchString(w) && !ok {
-						tokens = append(tokens, w)
+					if _, ok := urlToAnchor[u]; !ok {
+						urlToAnchor[u] = &Anchor{URL: u}
 					}
+					anchor = urlToAnchor[u]
 				}
 			}
-
-			if token.DataAtom != atom.A {
-				tokenType = doc.Next()
-				continue
+		case html.SelfClosingTagToken:
+			switch token.DataAtom {
+			case atom.Meta:
+				if page.Lang == "" {
+					page.Lang = metaLang(token)
+				}
+			case atom.Base:
+				setBase(token)
 			}
-			for _, attr := range token.Attr {
-				if attr.Key == "href" {
-					_, ok := selected[attr.Val]
-					if c.urlRegex.MatchString(attr.Val) && !ok {
-						edgeURLs = append(edgeURLs, attr.Val)
-					}
+		case html.EndTagToken:
+			switch token.DataAtom {
+			case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6:
+				if inHeading > 0 {
+					inHeading--
 				}
+			case atom.A:
+				anchor = nil
 			}
 		}
-		tokenType = doc.Next()
 	}
 
-	if title == "" || len(tokens) == 0 || len(edgeURLs) == 0 {
-		return "", nil, nil, errors.Errorf("failed to get sufficient information")
+	if page.Title == "" || len(page.Tokens) == 0 || len(page.EdgeURLs) == 0 {
+		return nil, errors.Errorf("failed to get sufficient information")
+	}
+
+	for _, url := range page.EdgeURLs {
+		if a, ok := urlToAnchor[url]; ok && len(a.Terms) > 0 {
+			page.Anchors = append(page.Anchors, a)
+			delete(urlToAnchor, url)
+		}
+	}
+
+	page.SimHash = simHash(tf)
+	return page, nil
+}
+
+// metaLang returns the language declared by <meta http-equiv="content-language">
+func metaLang(token html.Token) string {
+	var contentLang bool
+	var content string
+	for _, attr := range token.Attr {
+		switch attr.Key {
+		case "http-equiv":
+			contentLang = strings.EqualFold(attr.Val, "content-language")
+		case "content":
+			content = attr.Val
+		}
+	}
+
+	if !contentLang {
+		return ""
+	}
+	return normalizeLang(content)
+}
+
+// normalizeLang returns the primary subtag of the given language tag
+func normalizeLang(tag string) string {
+	tag = strings.TrimSpace(strings.Split(tag, ",")[0])
+	if i := strings.IndexAny(tag, "-_"); i >= 0 {
+		tag = tag[:i]
 	}
-	return title, tokens, edgeURLs, nil
+	return strings.ToLower(tag)
 }
@@ -3,6 +3,8 @@ package crawler
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 	"time"
@@ -25,6 +27,62 @@ func (mockDoogleClient) FindIndex(ctx context.Context, in *doogle.FindIndexReque
 	return nil, nil
 }
 
+func (mockDoogleClient) CacheIndex(ctx context.Context, in *doogle.CacheIndexRequest, opts ...grpc.CallOption) (*doogle.Empty, error) {
+	return nil, nil
+}
+
+func (mockDoogleClient) DeleteItem(ctx context.Context, in *doogle.DeleteItemRequest, opts ...grpc.CallOption) (*doogle.Empty, error) {
+	return nil, nil
+}
+
+func (mockDoogleClient) StoreDocument(ctx context.Context, in *doogle.StoreDocumentRequest, opts ...grpc.CallOption) (*doogle.Empty, error) {
+	return nil, nil
+}
+
+func (mockDoogleClient) FindDocument(ctx context.Context, in *doogle.FindDocumentRequest, opts ...grpc.CallOption) (*doogle.Document, error) {
+	return nil, nil
+}
+
+func (mockDoogleClient) StoreBacklink(ctx context.Context, in *doogle.StoreBacklinkRequest, opts ...grpc.CallOption) (*doogle.Empty, error) {
+	return nil, nil
+}
+
+func (mockDoogleClient) FindBacklinks(ctx context.Context, in *doogle.FindBacklinksRequest, opts ...grpc.CallOption) (*doogle.Backlinks, error) {
+	return nil, nil
+}
+
+func (mockDoogleClient) FindCoLinks(ctx context.Context, in *doogle.FindCoLinksRequest, opts ...grpc.CallOption) (*doogle.CoLinks, error) {
+	return nil, nil
+}
+
+func (mockDoogleClient) Related(ctx context.Context, in *doogle.StringMessage, opts ...grpc.CallOption) (*doogle.RelatedPages, error) {
+	return nil, nil
+}
+
+func (mockDoogleClient) Meet(ctx context.Context, in *doogle.MeetRequest, opts ...grpc.CallOption) (*doogle.MeetReply, error) {
+	return nil, nil
+}
+
+func (mockDoogleClient) GetRankStats(ctx context.Context, in *doogle.StringMessage, opts ...grpc.CallOption) (*doogle.RankStats, error) {
+	return nil, nil
+}
+
+func (mockDoogleClient) GetRankHistory(ctx context.Context, in *doogle.StringMessage, opts ...grpc.CallOption) (*doogle.RankHistory, error) {
+	return nil, nil
+}
+
+func (mockDoogleClient) FindRankNeighbours(ctx context.Context, in *doogle.FindRankNeighboursRequest, opts ...grpc.CallOption) (*doogle.RankNeighbours, error) {
+	return nil, nil
+}
+
+func (mockDoogleClient) Explain(ctx context.Context, in *doogle.ExplainRequest, opts ...grpc.CallOption) (*doogle.Explanation, error) {
+	return nil, nil
+}
+
+func (mockDoogleClient) ExportGraph(ctx context.Context, in *doogle.ExportGraphRequest, opts ...grpc.CallOption) (doogle.Doogle_ExportGraphClient, error) {
+	return nil, nil
+}
+
 func (mockDoogleClient) FindNode(ctx context.Context, in *doogle.FindNodeRequest, opts ...grpc.CallOption) (*doogle.NodeInfos, error) {
 	return nil, nil
 }
@@ -33,6 +91,22 @@ func (mockDoogleClient) PingWithCertificate(ctx context.Context, in *doogle.Node
 	return nil, nil
 }
 
+func (mockDoogleClient) StoreSpelling(ctx context.Context, in *doogle.StoreSpellingRequest, opts ...grpc.CallOption) (*doogle.Empty, error) {
+	return nil, nil
+}
+
+func (mockDoogleClient) FindSpelling(ctx context.Context, in *doogle.FindSpellingRequest, opts ...grpc.CallOption) (*doogle.Spellings, error) {
+	return nil, nil
+}
+
+func (mockDoogleClient) StoreCompletion(ctx context.Context, in *doogle.StoreCompletionRequest, opts ...grpc.CallOption) (*doogle.Empty, error) {
+	return nil, nil
+}
+
+func (mockDoogleClient) FindCompletion(ctx context.Context, in *doogle.FindCompletionRequest, opts ...grpc.CallOption) (*doogle.Completions, error) {
+	return nil, nil
+}
+
 func (mockDoogleClient) Ping(ctx context.Context, in *doogle.StringMessage, opts ...grpc.CallOption) (*doogle.StringMessage, error) {
 	return nil, nil
 }
@@ -49,9 +123,25 @@ func (mockDoogleClient) PostUrl(ctx context.Context, in *doogle.StringMessage, o
 	return nil, nil
 }
 
+func (mockDoogleClient) SearchStream(ctx context.Context, in *doogle.StringMessage, opts ...grpc.CallOption) (doogle.Doogle_SearchStreamClient, error) {
+	return nil, nil
+}
+
+func (mockDoogleClient) GetCacheStats(ctx context.Context, in *doogle.Empty, opts ...grpc.CallOption) (*doogle.CacheStats, error) {
+	return nil, nil
+}
+
+func (mockDoogleClient) GetBacklinks(ctx context.Context, in *doogle.StringMessage, opts ...grpc.CallOption) (*doogle.Backlinks, error) {
+	return nil, nil
+}
+
+func (mockDoogleClient) Suggest(ctx context.Context, in *doogle.StringMessage, opts ...grpc.CallOption) (*doogle.Completions, error) {
+	return nil, nil
+}
+
 func TestDoogleCrawler_worker(t *testing.T) {
 	logger := logrus.New()
-	crawler, _ := NewCrawler(1, 4, logger)
+	crawler, _ := NewCrawler(1, 4, DefaultUserAgent, DefaultPoliteness(), logger)
 	cr := crawler.(*doogleCrawler)
 	cr.SetDoogleClient(mClient)
 
@@ -62,7 +152,7 @@ func TestDoogleCrawler_worker(t *testing.T) {
 		"https://en.wikipedia.org/wiki/japan",
 		"https://en.wikipedia.org/wiki/golang",
 	} {
-		cr.queue <- url
+		cr.frontier.push(url)
 	}
 
 	time.Sleep(1 * time.Second)
@@ -70,7 +160,7 @@ func TestDoogleCrawler_worker(t *testing.T) {
 
 func TestDoogleCrawler_Crawl(t *testing.T) {
 	logger := logrus.New()
-	crawler, _ := NewCrawler(4, 4, logger)
+	crawler, _ := NewCrawler(4, 4, DefaultUserAgent, DefaultPoliteness(), logger)
 	cr := crawler.(*doogleCrawler)
 	cr.SetDoogleClient(mClient)
 
@@ -85,14 +175,16 @@ func TestDoogleCrawler_Crawl(t *testing.T) {
 }
 
 func TestDoogleCrawler_analyze(t *testing.T) {
-	crawler, _ := NewCrawler(0, 0, nil)
+	crawler, _ := NewCrawler(0, 0, DefaultUserAgent, DefaultPoliteness(), nil)
 	cr := crawler.(*doogleCrawler)
 
 	for i, cc := range []struct {
-		target    string
-		expTitle  string
-		expEdges  []string
-		expTokens []string
+		target     string
+		expTitle   string
+		expEdges   []string
+		expTokens  []*Token
+		expAnchors []*Anchor
+		expLang    string
 	}{
 		{
 			target: `
@@ -104,9 +196,11 @@ func TestDoogleCrawler_analyze(t *testing.T) {
 		<a href="https://www.google.com">
 	</body>
 </html>`,
-			expTitle:  "title1",
-			expEdges:  []string{"https://www.google.com"},
-			expTokens: []string{"title1"},
+			expTitle: "title1",
+			expEdges: []string{"https://www.google.com/"},
+			expTokens: []*Token{
+				{Term: "title1", Fields: []doogle.Field{doogle.Field_TITLE}},
+			},
 		},
 		{
 			target: `
@@ -119,9 +213,19 @@ func TestDoogleCrawler_analyze(t *testing.T) {
 		<a href="https://www.doogle.com"> 123456 </a>
 	</body>
 </html>`,
-			expTitle:  "This is a pen",
-			expEdges:  []string{"https://www.google.com", "https://www.doogle.com"},
-			expTokens: []string{"this", "is", "a", "pen", "123456", "123456"},
+			expTitle: "This is a pen",
+			expEdges: []string{"https://www.google.com/", "https://www.doogle.com/"},
+			expTokens: []*Token{
+				{Term: "this", Fields: []doogle.Field{doogle.Field_TITLE}},
+				{Term: "is", Fields: []doogle.Field{doogle.Field_TITLE}},
+				{Term: "a", Fields: []doogle.Field{doogle.Field_TITLE}},
+				{Term: "pen", Fields: []doogle.Field{doogle.Field_TITLE}},
+				{Term: "123456", Fields: []doogle.Field{doogle.Field_BODY}},
+			},
+			expAnchors: []*Anchor{
+				{URL: "https://www.google.com/", Terms: []string{"123456"}},
+				{URL: "https://www.doogle.com/", Terms: []string{"123456"}},
+			},
 		},
 		{
 			target: `
@@ -135,14 +239,29 @@ func TestDoogleCrawler_analyze(t *testing.T) {
 		<p> this is first text field</p>
 	</body>
 </html>`,
-			expTitle:  "This is a pen 100yen",
-			expEdges:  []string{"https://www.google.com", "https://www.doogle.com"},
-			expTokens: []string{"this", "is", "a", "pen", "100yen", "123456", "123456", "this", "is", "first", "text", "field"},
+			expTitle: "This is a pen 100yen",
+			expEdges: []string{"https://www.google.com/", "https://www.doogle.com/"},
+			expTokens: []*Token{
+				{Term: "this", Fields: []doogle.Field{doogle.Field_TITLE, doogle.Field_BODY}},
+				{Term: "is", Fields: []doogle.Field{doogle.Field_TITLE, doogle.Field_BODY}},
+				{Term: "a", Fields: []doogle.Field{doogle.Field_TITLE}},
+				{Term: "pen", Fields: []doogle.Field{doogle.Field_TITLE}},
+				{Term: "100yen", Fields: []doogle.Field{doogle.Field_TITLE}},
+				{Term: "123456", Fields: []doogle.Field{doogle.Field_BODY}},
+				{Term: "first", Fields: []doogle.Field{doogle.Field_BODY}},
+				{Term: "text", Fields: []doogle.Field{doogle.Field_BODY}},
+				{Term: "field", Fields: []doogle.Field{doogle.Field_BODY}},
+			},
+			expAnchors: []*Anchor{
+				{URL: "https://www.google.com/", Terms: []string{"123456"}},
+				{URL: "https://www.doogle.com/", Terms: []string{"123456"}},
+			},
 		},
 		{
 			target: `
 <!DOCTYPE html><html>
 	<header>
+		<meta http-equiv="Content-Language" content="ja"/>
 		<title>This is a pen 100yen</title>
 	</header>
 	<body>
@@ -152,29 +271,128 @@ func TestDoogleCrawler_analyze(t *testing.T) {
 		<p> this is first text field</p>
 	</body>
 </html>`,
-			expTitle:  "This is a pen 100yen",
-			expEdges:  []string{"https://www.google.com"},
-			expTokens: []string{"this", "is", "a", "pen", "100yen", "123456", "123456", "this", "is", "first", "text", "field"},
+			expTitle: "This is a pen 100yen",
+			expEdges: []string{"https://www.google.com/"},
+			expTokens: []*Token{
+				{Term: "this", Fields: []doogle.Field{doogle.Field_TITLE, doogle.Field_BODY}},
+				{Term: "is", Fields: []doogle.Field{doogle.Field_TITLE, doogle.Field_BODY}},
+				{Term: "a", Fields: []doogle.Field{doogle.Field_TITLE}},
+				{Term: "pen", Fields: []doogle.Field{doogle.Field_TITLE}},
+				{Term: "100yen", Fields: []doogle.Field{doogle.Field_TITLE}},
+				{Term: "123456", Fields: []doogle.Field{doogle.Field_BODY}},
+				{Term: "first", Fields: []doogle.Field{doogle.Field_BODY}},
+				{Term: "text", Fields: []doogle.Field{doogle.Field_BODY}},
+				{Term: "field", Fields: []doogle.Field{doogle.Field_BODY}},
+			},
+			expAnchors: []*Anchor{
+				{URL: "https://www.google.com/", Terms: []string{"123456"}},
+			},
+			expLang: "ja",
+		},
+		{
+			target: `
+<!DOCTYPE html><html lang="en-US">
+	<header>
+		<meta http-equiv="content-language" content="ja">
+		<title>doogle</title>
+	</header>
+	<body>
+		<h1>decentralized search</h1>
+		<a href="https://www.google.com">search</a>
+		<p>doogle is a search engine</p>
+	</body>
+</html>`,
+			expTitle: "doogle",
+			expEdges: []string{"https://www.google.com/"},
+			expTokens: []*Token{
+				{Term: "doogle", Fields: []doogle.Field{doogle.Field_TITLE, doogle.Field_BODY}},
+				{Term: "decentralized", Fields: []doogle.Field{doogle.Field_HEADING}},
+				{Term: "search", Fields: []doogle.Field{doogle.Field_HEADING, doogle.Field_BODY}},
+				{Term: "is", Fields: []doogle.Field{doogle.Field_BODY}},
+				{Term: "a", Fields: []doogle.Field{doogle.Field_BODY}},
+				{Term: "engine", Fields: []doogle.Field{doogle.Field_BODY}},
+			},
+			expAnchors: []*Anchor{
+				{URL: "https://www.google.com/", Terms: []string{"search"}},
+			},
+			expLang: "en",
 		},
 	} {
 		c := cc
 		t.Run(fmt.Sprintf("%d-th case", i), func(t *testing.T) {
 			body := strings.NewReader(c.target)
-			aTitle, aTokens, aEdgeURLs, err := cr.analyze(body, "")
+			page, err := cr.analyze(body, "")
 			if err != nil {
 				panic(err)
 			}
-			assert.Equal(t, c.expTitle, aTitle)
-			assert.Equal(t, len(c.expEdges), len(aEdgeURLs))
+			assert.Equal(t, c.expTitle, page.Title)
+			assert.Equal(t, len(c.expEdges), len(page.EdgeURLs))
 
 			for i := range c.expEdges {
-				assert.Equal(t, c.expEdges[i], aEdgeURLs[i])
+				assert.Equal(t, c.expEdges[i], page.EdgeURLs[i])
 			}
 
-			assert.Equal(t, len(c.expTokens), len(aTokens))
+			assert.Equal(t, len(c.expTokens), len(page.Tokens))
 
 			for i := range c.expTokens {
-				assert.Equal(t, c.expTokens[i], aTokens[i])
+				assert.Equal(t, c.expTokens[i], page.Tokens[i])
+			}
+
+			assert.Equal(t, c.expAnchors, page.Anchors)
+			assert.Equal(t, c.expLang, page.Lang)
+			assert.NotEqual(t, uint64(0), page.SimHash)
+		})
+	}
+}
+
+func TestNormalizeLang(t *testing.T) {
+	for i, cc := range []struct {
+		tag, exp string
+	}{
+		{"en", "en"},
+		{"en-US", "en"},
+		{"ja_JP", "ja"},
+		{" DE, en", "de"},
+		{"", ""},
+	} {
+		c := cc
+		t.Run(fmt.Sprintf("%d-th case", i), func(t *testing.T) {
+			assert.Equal(t, c.exp, normalizeLang(c.tag))
+		})
+	}
+}
+
+func TestDoogleCrawler_AnalyzePage(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/gone":
+			w.WriteHeader(http.StatusGone)
+		case "/page":
+			fmt.Fprint(w, `<html><head><title>doogle</title></head><body><a href="https://example.com">kademlia</a></body></html>`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+
+	cr, err := NewCrawler(0, 0, DefaultUserAgent, Politeness{MaxConnsPerHost: 1, MaxDelay: time.Second}, logrus.New())
+	assert.Nil(t, err)
+
+	for i, cc := range []struct {
+		path     string
+		expErr   error
+		expTitle string
+	}{
+		{path: "/page", expTitle: "doogle"},
+		{path: "/missing", expErr: ErrPageGone},
+		{path: "/gone", expErr: ErrPageGone},
+	} {
+		c := cc
+		t.Run(fmt.Sprintf("%d-th case", i), func(t *testing.T) {
+			page, err := cr.AnalyzePage(context.Background(), ts.URL+c.path)
+			assert.Equal(t, c.expErr, err)
+			if c.expErr == nil {
+				assert.Equal(t, c.expTitle, page.Title)
 			}
 		})
 	}
@@ -0,0 +1,309 @@
+package crawler
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// the delay for a host is at least `delayFactor` times its last response time
+	delayFactor = 5
+
+	// delay for an overloaded host when MinDelay is zero
+	minBackoff = time.Second
+)
+
+// Politeness limits the requests of the crawler to each host
+type Politeness struct {
+	// max number of concurrent requests to a host
+	MaxConnsPerHost int
+
+	// min and max intervals between requests to a host. the delay backs off up to MaxDelay
+	// while the host is overloaded, and Crawl-delay is capped by MaxDelay
+	MinDelay time.Duration
+	MaxDelay time.Duration
+}
+
+func DefaultPoliteness() Politeness {
+	return Politeness{MaxConnsPerHost: 1, MinDelay: time.Second, MaxDelay: 5 * time.Minute}
+}
+
+// fetchStats is how a host responded to a request
+type fetchStats struct {
+	status  int
+	elapsed time.Duration
+
+	// delay requested by Retry-After header
+	retryAfter time.Duration
+
+	// Crawl-delay in robots.txt of the host
+	crawlDelay time.Duration
+
+	// true if the request failed before the response
+	failed bool
+}
+
+func (s *fetchStats) overloaded() bool {
+	return s.failed || s.status == http.StatusTooManyRequests || s.status == http.StatusServiceUnavailable
+}
+
+// retryAfter parses Retry-After header given in seconds or HTTP date
+func retryAfter(h string, now time.Time) time.Duration {
+	if h == "" {
+		return 0
+	}
+
+	if sec, err := strconv.Atoi(h); err == nil && sec > 0 {
+		return time.Duration(sec) * time.Second
+	}
+
+	if t, err := http.ParseTime(h); err == nil && t.After(now) {
+		return t.Sub(now)
+	}
+	return 0
+}
+
+type hostQueue struct {
+	urls   []string
+	active int
+	delay  time.Duration
+
+	// time from which the next request to the host can start
+	nextFetchAt time.Time
+}
+
+// frontier holds the urls to be crawled partitioned by hosts, and hands them out to workers
+// as long as the hosts are not being fetched too frequently
+type frontier struct {
+	capacity int
+	size     int
+	p        Politeness
+
+	// type: map{host -> *hostQueue}
+	hosts map[string]*hostQueue
+
+	// closed and replaced on changes to wake up the waiters
+	changed chan struct{}
+
+	mux sync.Mutex
+}
+
+func newFrontier(capacity int, p Politeness) *frontier {
+	return &frontier{
+		capacity: capacity,
+		p:        p,
+		hosts:    map[string]*hostQueue{},
+		changed:  make(chan struct{}),
+	}
+}
+
+func hostKey(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return rawURL
+	}
+	return strings.ToLower(u.Host)
+}
+
+// broadcast wakes up all the waiters. the caller must hold the lock
+func (f *frontier) broadcast() {
+	close(f.changed)
+	f.changed = make(chan struct{})
+}
+
+// await calls try with the lock held until it succeeds. while it fails, await waits for changes of the frontier
+// or the duration returned by try if it is positive. it gives up when ctx is done
+func (f *frontier) await(ctx context.Context, try func(now time.Time) (time.Duration, bool)) error {
+	for {
+		f.mux.Lock()
+		wait, ok := try(time.Now())
+		changed := f.changed
+		f.mux.Unlock()
+
+		if ok {
+			return nil
+		}
+
+		// a nil channel never receives
+		var timeout <-chan time.Time
+		var t *time.Timer
+		if wait > 0 {
+			t = time.NewTimer(wait)
+			timeout = t.C
+		}
+
+		var err error
+		select {
+		case <-changed:
+		case <-timeout:
+		case <-ctx.Done():
+			err = ctx.Err()
+		}
+
+		if t != nil {
+			t.Stop()
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (f *frontier) hostQueue(host string) *hostQueue {
+	hq, ok := f.hosts[host]
+	if !ok {
+		hq = &hostQueue{delay: f.p.MinDelay}
+		f.hosts[host] = hq
+	}
+	return hq
+}
+
+// readyIn returns the time until a request to the host can start, and false if all of its connections are in use
+func (f *frontier) readyIn(hq *hostQueue, now time.Time) (time.Duration, bool) {
+	if hq.active >= f.p.MaxConnsPerHost {
+		return 0, false
+	}
+
+	if d := hq.nextFetchAt.Sub(now); d > 0 {
+		return d, true
+	}
+	return 0, true
+}
+
+func (f *frontier) start(hq *hostQueue, now time.Time) {
+	hq.active++
+	hq.nextFetchAt = now.Add(hq.delay)
+}
+
+// push adds the url to the frontier, and returns false if it is full
+func (f *frontier) push(rawURL string) bool {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+
+	if f.size >= f.capacity {
+		return false
+	}
+
+	hq := f.hostQueue(hostKey(rawURL))
+	hq.urls = append(hq.urls, rawURL)
+	f.size++
+	f.broadcast()
+	return true
+}
+
+// tryPop takes a url of the host which has waited longest among the ready ones.
+// if there's no ready url, it returns the time until one gets ready, or zero if it's unknown.
+// the caller must hold the lock
+func (f *frontier) tryPop(now time.Time) (rawURL, host string, wait time.Duration, ok bool) {
+	var next *hostQueue
+	for h, hq := range f.hosts {
+		if len(hq.urls) == 0 {
+			// forget idle hosts which have recovered
+			if hq.active == 0 && !hq.nextFetchAt.After(now) && hq.delay <= f.p.MinDelay {
+				delete(f.hosts, h)
+			}
+			continue
+		}
+
+		d, free := f.readyIn(hq, now)
+		if !free {
+			continue
+		} else if d > 0 {
+			if wait == 0 || d < wait {
+				wait = d
+			}
+			continue
+		}
+
+		if next == nil || hq.nextFetchAt.Before(next.nextFetchAt) {
+			next, host = hq, h
+		}
+	}
+
+	if next == nil {
+		return "", "", wait, false
+	}
+
+	rawURL, next.urls = next.urls[0], next.urls[1:]
+	f.size--
+	f.start(next, now)
+	return rawURL, host, 0, true
+}
+
+// pop blocks until a url gets ready, and returns it with its host acquired.
+// the host must be released after the request
+func (f *frontier) pop() (rawURL, host string) {
+	f.await(context.Background(), func(now time.Time) (time.Duration, bool) {
+		var wait time.Duration
+		var ok bool
+		rawURL, host, wait, ok = f.tryPop(now)
+		return wait, ok
+	})
+	return
+}
+
+// acquire blocks until a request to the host can start or ctx is done.
+// unless it returns an error, the host must be released after the request
+func (f *frontier) acquire(ctx context.Context, host string) error {
+	return f.await(ctx, func(now time.Time) (time.Duration, bool) {
+		hq := f.hostQueue(host)
+		if d, free := f.readyIn(hq, now); !free || d > 0 {
+			return d, false
+		}
+
+		f.start(hq, now)
+		return 0, true
+	})
+}
+
+// release frees the connection to the host, and adapts the delay to the response unless s is nil.
+// the delay doubles while the host is overloaded, and otherwise halves down to the one proportional to the response time
+func (f *frontier) release(host string, s *fetchStats, now time.Time) {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+	defer f.broadcast()
+
+	hq := f.hostQueue(host)
+	if hq.active > 0 {
+		hq.active--
+	}
+
+	if s == nil {
+		return
+	}
+
+	if s.overloaded() {
+		hq.delay *= 2
+		if hq.delay < minBackoff {
+			hq.delay = minBackoff
+		}
+		if s.retryAfter > hq.delay {
+			hq.delay = s.retryAfter
+		}
+	} else {
+		hq.delay /= 2
+		if d := s.elapsed * delayFactor; d > hq.delay {
+			hq.delay = d
+		}
+	}
+
+	// Crawl-delay is also capped so that a host can't stall the requests waiting for it
+	if hq.delay < s.crawlDelay {
+		hq.delay = s.crawlDelay
+	}
+	if hq.delay > f.p.MaxDelay {
+		hq.delay = f.p.MaxDelay
+	}
+	if hq.delay < f.p.MinDelay {
+		hq.delay = f.p.MinDelay
+	}
+
+	if at := now.Add(hq.delay); at.After(hq.nextFetchAt) {
+		hq.nextFetchAt = at
+	}
+}
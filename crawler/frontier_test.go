@@ -0,0 +1,171 @@
+package crawler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFrontier_push(t *testing.T) {
+	f := newFrontier(3, DefaultPoliteness())
+	for i, cc := range []struct {
+		url string
+		exp bool
+	}{
+		{url: "https://a.com/1", exp: true},
+		{url: "https://A.com/2", exp: true},
+		{url: "https://b.com/1", exp: true},
+		{url: "https://c.com/1", exp: false},
+	} {
+		c := cc
+		t.Run(fmt.Sprintf("%d-th case", i), func(t *testing.T) {
+			assert.Equal(t, c.exp, f.push(c.url))
+		})
+	}
+
+	assert.Equal(t, 2, len(f.hosts))
+	assert.Equal(t, []string{"https://a.com/1", "https://A.com/2"}, f.hosts["a.com"].urls)
+}
+
+func TestFrontier_tryPop(t *testing.T) {
+	f := newFrontier(10, Politeness{MaxConnsPerHost: 1, MinDelay: time.Second, MaxDelay: time.Minute})
+	for _, url := range []string{"https://a.com/1", "https://a.com/2", "https://b.com/1"} {
+		f.push(url)
+	}
+
+	now := time.Now()
+	var hosts []string
+	for i := 0; i < 2; i++ {
+		_, host, _, ok := f.tryPop(now)
+		assert.True(t, ok)
+		hosts = append(hosts, host)
+	}
+	assert.ElementsMatch(t, []string{"a.com", "b.com"}, hosts)
+
+	// a.com is in use
+	_, _, wait, ok := f.tryPop(now)
+	assert.False(t, ok)
+	assert.Equal(t, time.Duration(0), wait)
+
+	// a.com is released but must wait for the delay
+	f.release("a.com", nil, now)
+	_, _, wait, ok = f.tryPop(now)
+	assert.False(t, ok)
+	assert.Equal(t, time.Second, wait)
+
+	url, host, _, ok := f.tryPop(now.Add(time.Second))
+	assert.True(t, ok)
+	assert.Equal(t, "https://a.com/2", url)
+	assert.Equal(t, "a.com", host)
+	assert.Equal(t, 0, f.size)
+}
+
+func TestFrontier_release(t *testing.T) {
+	f := newFrontier(10, Politeness{MaxConnsPerHost: 1, MinDelay: time.Second, MaxDelay: time.Minute})
+	now := time.Now()
+
+	for i, cc := range []struct {
+		stats *fetchStats
+		exp   time.Duration
+	}{
+		{stats: &fetchStats{status: http.StatusOK, elapsed: 10 * time.Millisecond}, exp: time.Second},
+		// backs off while overloaded
+		{stats: &fetchStats{status: http.StatusServiceUnavailable}, exp: 2 * time.Second},
+		{stats: &fetchStats{status: http.StatusTooManyRequests}, exp: 4 * time.Second},
+		{stats: &fetchStats{failed: true}, exp: 8 * time.Second},
+		{stats: &fetchStats{status: http.StatusTooManyRequests, retryAfter: 30 * time.Second}, exp: 30 * time.Second},
+		{stats: &fetchStats{status: http.StatusTooManyRequests}, exp: time.Minute},
+		// and recovers
+		{stats: &fetchStats{status: http.StatusOK}, exp: 30 * time.Second},
+		{stats: &fetchStats{status: http.StatusOK, elapsed: 10 * time.Second}, exp: 50 * time.Second},
+		{stats: &fetchStats{status: http.StatusOK, elapsed: time.Second}, exp: 25 * time.Second},
+		{stats: &fetchStats{status: http.StatusOK, crawlDelay: 20 * time.Second}, exp: 20 * time.Second},
+		{stats: nil, exp: 20 * time.Second},
+		// Crawl-delay is capped
+		{stats: &fetchStats{status: http.StatusOK, crawlDelay: time.Hour}, exp: time.Minute},
+	} {
+		c := cc
+		t.Run(fmt.Sprintf("%d-th case", i), func(t *testing.T) {
+			f.release("a.com", c.stats, now)
+			assert.Equal(t, c.exp, f.hosts["a.com"].delay)
+		})
+	}
+}
+
+func TestFrontier_acquire(t *testing.T) {
+	f := newFrontier(10, Politeness{MaxConnsPerHost: 1, MaxDelay: time.Minute})
+	f.acquire(context.Background(), "a.com")
+
+	acquired := make(chan struct{})
+	go func() {
+		f.acquire(context.Background(), "a.com")
+		close(acquired)
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatal("acquired the host in use")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	f.release("a.com", nil, time.Now())
+	select {
+	case <-acquired:
+	case <-time.After(time.Second):
+		t.Fatal("failed to acquire the released host")
+	}
+
+	// gives up waiting for the host in use
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, context.DeadlineExceeded, f.acquire(ctx, "a.com"))
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Now()
+	for i, cc := range []struct {
+		h   string
+		exp time.Duration
+	}{
+		{h: "", exp: 0},
+		{h: "120", exp: 2 * time.Minute},
+		{h: "-1", exp: 0},
+		{h: now.Add(time.Hour).UTC().Format(http.TimeFormat), exp: time.Hour},
+		{h: "foo", exp: 0},
+	} {
+		c := cc
+		t.Run(fmt.Sprintf("%d-th case", i), func(t *testing.T) {
+			assert.InDelta(t, float64(c.exp), float64(retryAfter(c.h, now)), float64(time.Second))
+		})
+	}
+}
+
+func TestDoogleCrawler_AnalyzePage_overloaded(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/robots.txt" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Retry-After", "3")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+
+	cr, err := NewCrawler(0, 0, DefaultUserAgent, Politeness{MaxConnsPerHost: 1, MaxDelay: time.Minute}, logrus.New())
+	assert.Nil(t, err)
+
+	_, err = cr.AnalyzePage(context.Background(), ts.URL+"/page")
+	assert.NotNil(t, err)
+
+	f := cr.(*doogleCrawler).frontier
+	assert.Equal(t, 3*time.Second, f.hosts[hostKey(ts.URL)].delay)
+
+	_, err = NewCrawler(0, 0, DefaultUserAgent, Politeness{}, logrus.New())
+	assert.NotNil(t, err)
+}
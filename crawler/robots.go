@@ -0,0 +1,285 @@
+package crawler
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ErrDisallowed is returned by AnalyzePage if robots.txt disallows the crawler to fetch the page
+var ErrDisallowed = errors.New("disallowed by robots.txt")
+
+const (
+	// DefaultUserAgent is the token by which the crawler identifies itself to web servers
+	DefaultUserAgent = "doogle"
+
+	// lifetime of the cached robots.txt
+	robotsTTL = 24 * time.Hour
+
+	// lifetime of the cached result of robots.txt which could not be fetched
+	robotsErrorTTL = 10 * time.Minute
+
+	// max size of robots.txt to be parsed
+	maxRobotsSize = 500 * 1024
+)
+
+type robotsRule struct {
+	allow   bool
+	pattern *regexp.Regexp
+
+	// length of the pattern. the longest matching rule is applied
+	length int
+}
+
+// robotsRules is the rules of robots.txt applied to the crawler
+type robotsRules struct {
+	rules      []*robotsRule
+	crawlDelay time.Duration
+}
+
+var (
+	allowAll    = &robotsRules{}
+	disallowAll = &robotsRules{rules: []*robotsRule{{pattern: regexp.MustCompile("^/"), length: 1}}}
+)
+
+// allowed returns true if the path with the query is allowed
+func (r *robotsRules) allowed(path string) bool {
+	var matched *robotsRule
+	for _, rule := range r.rules {
+		if !rule.pattern.MatchString(path) {
+			continue
+		}
+
+		// Allow wins on ties
+		if matched == nil || rule.length > matched.length || (rule.length == matched.length && rule.allow) {
+			matched = rule
+		}
+	}
+	return matched == nil || matched.allow
+}
+
+// compileRobotsPattern converts the path pattern with the wildcards `*` and `$` into a regexp
+func compileRobotsPattern(p string) (*regexp.Regexp, error) {
+	var anchored bool
+	if strings.HasSuffix(p, "$") {
+		p, anchored = p[:len(p)-1], true
+	}
+
+	expr := "^" + strings.Replace(regexp.QuoteMeta(p), `\*`, ".*", -1)
+	if anchored {
+		expr += "$"
+	}
+	return regexp.Compile(expr)
+}
+
+// parseRobots returns the rules of the groups for userAgent in robots.txt,
+// or the ones for `*` if there's no such group
+func parseRobots(r io.Reader, userAgent string) *robotsRules {
+	userAgent = strings.ToLower(userAgent)
+	specific, wildcard := &robotsRules{}, &robotsRules{}
+	var hasSpecific bool
+
+	// the groups to which the current lines belong
+	var agents []string
+	var inRules bool
+
+	s := bufio.NewScanner(io.LimitReader(r, maxRobotsSize))
+	for s.Scan() {
+		line := s.Text()
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+
+		kv := strings.SplitN(line, ":", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		key, value := strings.ToLower(strings.TrimSpace(kv[0])), strings.TrimSpace(kv[1])
+
+		if key == "user-agent" {
+			// a user-agent line after rules starts a new group
+			if inRules {
+				agents, inRules = nil, false
+			}
+
+			agent := strings.ToLower(strings.SplitN(value, "/", 2)[0])
+			agents = append(agents, agent)
+			if agent == userAgent {
+				hasSpecific = true
+			}
+			continue
+		}
+
+		var targets []*robotsRules
+		for _, a := range agents {
+			if a == userAgent {
+				targets = append(targets, specific)
+			} else if a == "*" {
+				targets = append(targets, wildcard)
+			}
+		}
+
+		switch key {
+		case "allow", "disallow":
+			inRules = true
+
+			// empty Disallow allows everything
+			if value == "" {
+				continue
+			}
+
+			pattern, err := compileRobotsPattern(value)
+			if err != nil {
+				continue
+			}
+
+			for _, t := range targets {
+				t.rules = append(t.rules, &robotsRule{allow: key == "allow", pattern: pattern, length: len(value)})
+			}
+		case "crawl-delay":
+			inRules = true
+
+			sec, err := strconv.ParseFloat(value, 64)
+			if err != nil || sec < 0 {
+				continue
+			}
+
+			for _, t := range targets {
+				t.crawlDelay = time.Duration(sec * float64(time.Second))
+			}
+		}
+	}
+
+	if hasSpecific {
+		return specific
+	}
+	return wildcard
+}
+
+type hostRobots struct {
+	rules     *robotsRules
+	expiresAt time.Time
+
+	// closed when the fetch in progress finishes. nil if robots.txt is not being fetched
+	fetching chan struct{}
+
+	mux sync.Mutex
+}
+
+// robotsCache fetches and caches robots.txt of each host
+type robotsCache struct {
+	userAgent string
+	client    *http.Client
+
+	// type: map{scheme://host -> *hostRobots}
+	hosts sync.Map
+}
+
+func newRobotsCache(userAgent string, client *http.Client) *robotsCache {
+	return &robotsCache{userAgent: userAgent, client: client}
+}
+
+// fetch gets robots.txt of the host. when it is not available, everything is allowed, and
+// when the server fails, everything is disallowed as it may be temporarily hidden
+func (c *robotsCache) fetch(ctx context.Context, u *url.URL) (*robotsRules, time.Duration) {
+	req, err := http.NewRequest(http.MethodGet, u.Scheme+"://"+u.Host+"/robots.txt", nil)
+	if err != nil {
+		return disallowAll, robotsErrorTTL
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("User-Agent", c.userAgent)
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return disallowAll, robotsErrorTTL
+	}
+	defer res.Body.Close()
+
+	switch {
+	case res.StatusCode >= 500:
+		return disallowAll, robotsErrorTTL
+	case res.StatusCode >= 400:
+		return allowAll, robotsTTL
+	case res.StatusCode >= 300:
+		// the client follows redirects, so the ones left have no location to follow
+		return allowAll, robotsErrorTTL
+	}
+	return parseRobots(res.Body, c.userAgent), robotsTTL
+}
+
+// rules returns the unexpired rules for the host of the url. robots.txt is fetched by one request at a time
+// without holding the lock, and the others wait for it until ctx is done
+func (c *robotsCache) rules(ctx context.Context, u *url.URL) (*robotsRules, error) {
+	raw, _ := c.hosts.LoadOrStore(u.Scheme+"://"+u.Host, &hostRobots{})
+	hr := raw.(*hostRobots)
+
+	for {
+		hr.mux.Lock()
+		if hr.rules != nil && !time.Now().After(hr.expiresAt) {
+			rules := hr.rules
+			hr.mux.Unlock()
+			return rules, nil
+		}
+
+		if fetching := hr.fetching; fetching != nil {
+			hr.mux.Unlock()
+			select {
+			case <-fetching:
+				continue
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+
+		fetching := make(chan struct{})
+		hr.fetching = fetching
+		hr.mux.Unlock()
+
+		rules, ttl := c.fetch(ctx, u)
+
+		hr.mux.Lock()
+		// the result of the canceled request is not cached as it's not of the host
+		err := ctx.Err()
+		if err == nil {
+			hr.rules, hr.expiresAt = rules, time.Now().Add(ttl)
+		}
+		hr.fetching = nil
+		close(fetching)
+		hr.mux.Unlock()
+
+		if err != nil {
+			return nil, err
+		}
+		return rules, nil
+	}
+}
+
+// check returns Crawl-delay of the host if robots.txt allows the url
+func (c *robotsCache) check(ctx context.Context, u *url.URL) (time.Duration, error) {
+	rules, err := c.rules(ctx, u)
+	if err != nil {
+		return 0, err
+	}
+
+	path := u.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	if u.RawQuery != "" {
+		path += "?" + u.RawQuery
+	}
+
+	if !rules.allowed(path) {
+		return 0, ErrDisallowed
+	}
+	return rules.crawlDelay, nil
+}
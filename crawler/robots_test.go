@@ -0,0 +1,173 @@
+package crawler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRobots(t *testing.T) {
+	const robots = `
+# comment
+User-agent: *
+Disallow: /private
+Crawl-delay: 2
+
+User-agent: Doogle/1.0
+User-agent: other
+Disallow: /
+Allow: /public
+Allow: /*.html$
+Disallow: /public/secret # inline comment
+Crawl-delay: 0.5
+
+User-agent: doogle
+Disallow: /tmp/
+`
+
+	for i, cc := range []struct {
+		userAgent string
+		path      string
+		exp       bool
+	}{
+		{userAgent: "doogle", path: "/", exp: false},
+		{userAgent: "doogle", path: "/public/index", exp: true},
+		{userAgent: "doogle", path: "/public/secret/a", exp: false},
+		{userAgent: "doogle", path: "/docs/a.html", exp: true},
+		{userAgent: "doogle", path: "/docs/a.html?q=1", exp: false},
+		{userAgent: "doogle", path: "/tmp/a", exp: false},
+		// the longest match wins
+		{userAgent: "doogle", path: "/tmp/a.html", exp: true},
+		{userAgent: "DOOGLE", path: "/public", exp: true},
+		{userAgent: "bot", path: "/", exp: true},
+		{userAgent: "bot", path: "/private/a", exp: false},
+	} {
+		c := cc
+		t.Run(fmt.Sprintf("%d-th case", i), func(t *testing.T) {
+			assert.Equal(t, c.exp, parseRobots(strings.NewReader(robots), c.userAgent).allowed(c.path))
+		})
+	}
+
+	assert.Equal(t, 500*time.Millisecond, parseRobots(strings.NewReader(robots), "doogle").crawlDelay)
+	assert.Equal(t, 2*time.Second, parseRobots(strings.NewReader(robots), "bot").crawlDelay)
+
+	// allow wins on ties, and empty disallow allows everything
+	rs := parseRobots(strings.NewReader("User-agent: *\nDisallow: /a\nAllow: /a\nDisallow:\n"), "doogle")
+	assert.Equal(t, true, rs.allowed("/a"))
+	assert.Equal(t, true, rs.allowed("/b"))
+}
+
+func TestRobotsCache_check(t *testing.T) {
+	var numFetches int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "doogle", r.Header.Get("User-Agent"))
+		atomic.AddInt32(&numFetches, 1)
+		fmt.Fprint(w, "User-agent: doogle\nDisallow: /private\nCrawl-delay: 1\n")
+	}))
+	defer ts.Close()
+
+	c := newRobotsCache("doogle", ts.Client())
+	u, _ := url.Parse(ts.URL + "/page")
+
+	delay, err := c.check(context.Background(), u)
+	assert.Nil(t, err)
+	assert.Equal(t, time.Second, delay)
+
+	u, _ = url.Parse(ts.URL + "/private/a")
+	_, err = c.check(context.Background(), u)
+	assert.Equal(t, ErrDisallowed, err)
+
+	// robots.txt is cached
+	assert.Equal(t, int32(1), atomic.LoadInt32(&numFetches))
+}
+
+func TestRobotsCache_check_canceled(t *testing.T) {
+	unblock := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-unblock
+		fmt.Fprint(w, "User-agent: *\nDisallow: /private\n")
+	}))
+	defer ts.Close()
+
+	c := newRobotsCache("doogle", ts.Client())
+	u, _ := url.Parse(ts.URL + "/page")
+
+	// both the fetching request and the one waiting for it give up
+	errs := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			defer cancel()
+			_, err := c.check(ctx, u)
+			errs <- err
+		}()
+	}
+	for i := 0; i < 2; i++ {
+		select {
+		case err := <-errs:
+			assert.NotNil(t, err)
+		case <-time.After(time.Second):
+			t.Fatal("blocked by the fetch of robots.txt")
+		}
+	}
+	close(unblock)
+
+	// the failure of the canceled request is not cached
+	_, err := c.check(context.Background(), u)
+	assert.Nil(t, err)
+}
+
+func TestRobotsCache_fetch(t *testing.T) {
+	for i, cc := range []struct {
+		status int
+		exp    bool
+	}{
+		{status: http.StatusOK, exp: false},
+		{status: http.StatusNotFound, exp: true},
+		{status: http.StatusServiceUnavailable, exp: false},
+	} {
+		c := cc
+		t.Run(fmt.Sprintf("%d-th case", i), func(t *testing.T) {
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(c.status)
+				fmt.Fprint(w, "User-agent: *\nDisallow: /\n")
+			}))
+			defer ts.Close()
+
+			u, _ := url.Parse(ts.URL)
+			rules, _ := newRobotsCache("doogle", ts.Client()).fetch(context.Background(), u)
+			assert.Equal(t, c.exp, rules.allowed("/"))
+		})
+	}
+}
+
+func TestDoogleCrawler_AnalyzePage_robots(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/robots.txt":
+			fmt.Fprint(w, "User-agent: *\nDisallow: /private\n")
+		default:
+			fmt.Fprint(w, `<html><head><title>doogle</title></head><body><a href="https://example.com">kademlia</a></body></html>`)
+		}
+	}))
+	defer ts.Close()
+
+	cr, err := NewCrawler(0, 0, DefaultUserAgent, Politeness{MaxConnsPerHost: 1, MaxDelay: time.Second}, logrus.New())
+	assert.Nil(t, err)
+
+	_, err = cr.AnalyzePage(context.Background(), ts.URL+"/private/page")
+	assert.Equal(t, ErrDisallowed, err)
+
+	page, err := cr.AnalyzePage(context.Background(), ts.URL+"/page")
+	assert.Nil(t, err)
+	assert.Equal(t, "doogle", page.Title)
+}
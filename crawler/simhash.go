@@ -0,0 +1,33 @@
+package crawler
+
+import (
+	"hash/fnv"
+)
+
+const simHashBits = 64
+
+// simHash computes the SimHash fingerprint of a document with the given term frequencies
+func simHash(tf map[string]int) uint64 {
+	var v [simHashBits]int
+	for term, w := range tf {
+		h := fnv.New64a()
+		h.Write([]byte(term))
+		sum := h.Sum64()
+
+		for i := 0; i < simHashBits; i++ {
+			if sum&(1<<uint(i)) != 0 {
+				v[i] += w
+			} else {
+				v[i] -= w
+			}
+		}
+	}
+
+	var ret uint64
+	for i := 0; i < simHashBits; i++ {
+		if v[i] > 0 {
+			ret |= 1 << uint(i)
+		}
+	}
+	return ret
+}
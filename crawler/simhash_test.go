@@ -0,0 +1,29 @@
+package crawler
+
+import (
+	"math/bits"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSimHash(t *testing.T) {
+	base := map[string]int{
+		"doogle": 3, "is": 2, "a": 2, "decentralized": 1, "search": 2, "engine": 1,
+		"based": 1, "on": 1, "grpc": 1, "written": 1, "in": 1, "go": 1,
+	}
+
+	similar := map[string]int{"utm": 1}
+	for k, v := range base {
+		similar[k] = v
+	}
+
+	different := map[string]int{
+		"kademlia": 2, "distributed": 1, "hash": 3, "table": 3, "for": 1,
+		"peer": 2, "to": 1, "networks": 1, "xor": 1, "metric": 1,
+	}
+
+	assert.Equal(t, simHash(base), simHash(base))
+	assert.Equal(t, uint64(0), simHash(map[string]int{}))
+	assert.True(t, bits.OnesCount64(simHash(base)^simHash(similar)) < bits.OnesCount64(simHash(base)^simHash(different)))
+}
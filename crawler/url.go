@@ -0,0 +1,159 @@
+package crawler
+
+import (
+	"net"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/net/idna"
+)
+
+// ports omitted from normalized urls
+var defaultPorts = map[string]string{"http": "80", "https": "443"}
+
+// query parameters which only track visitors
+var trackingParams = map[string]struct{}{
+	"gclid":   {},
+	"fbclid":  {},
+	"msclkid": {},
+	"yclid":   {},
+	"mc_cid":  {},
+	"mc_eid":  {},
+	"_ga":     {},
+}
+
+func isTrackingParam(key string) bool {
+	if strings.HasPrefix(strings.ToLower(key), "utm_") {
+		return true
+	}
+	_, ok := trackingParams[strings.ToLower(key)]
+	return ok
+}
+
+// NormalizeURL returns the canonical form of the absolute url so that the same page has the same address.
+// urls without scheme are taken as http
+func NormalizeURL(rawURL string) (string, error) {
+	rawURL = strings.TrimSpace(rawURL)
+	if !strings.Contains(rawURL, "://") {
+		// a colon before the path is either of a port like "a.com:8080" or of another scheme like "mailto:"
+		if i := strings.IndexAny(rawURL, ":/"); i >= 0 && rawURL[i] == ':' && !isPort(rawURL[i+1:]) {
+			return "", errors.Errorf("unsupported scheme: %s", rawURL[:i])
+		}
+		rawURL = "http://" + strings.TrimPrefix(rawURL, "//")
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", errors.Errorf("failed to parse url: %v", err)
+	}
+	return normalize(u)
+}
+
+// isPort returns true if s begins with digits followed by the path
+func isPort(s string) bool {
+	if i := strings.Index(s, "/"); i >= 0 {
+		s = s[:i]
+	}
+
+	if s == "" {
+		return false
+	}
+
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// resolveURL resolves the reference found on the page at base, and normalizes it
+func resolveURL(base *url.URL, ref string) (string, error) {
+	r, err := url.Parse(strings.TrimSpace(ref))
+	if err != nil {
+		return "", errors.Errorf("failed to parse url: %v", err)
+	}
+	return normalize(base.ResolveReference(r))
+}
+
+// normalize lowercases scheme and host, converts IDN into punycode, omits the default port,
+// resolves dot segments, and drops the fragment and tracking parameters
+func normalize(u *url.URL) (string, error) {
+	scheme := strings.ToLower(u.Scheme)
+	if _, ok := defaultPorts[scheme]; !ok {
+		return "", errors.Errorf("unsupported scheme: %s", u.Scheme)
+	}
+
+	host := strings.TrimSuffix(strings.ToLower(u.Hostname()), ".")
+	if host == "" {
+		return "", errors.New("empty host")
+	}
+
+	if net.ParseIP(host) == nil {
+		var err error
+		if host, err = idna.Lookup.ToASCII(host); err != nil {
+			return "", errors.Errorf("invalid host: %v", err)
+		}
+	}
+
+	if port := u.Port(); port != "" && port != defaultPorts[scheme] {
+		host = net.JoinHostPort(host, port)
+	} else if strings.Contains(host, ":") {
+		// IPv6
+		host = "[" + host + "]"
+	}
+
+	// resolving the empty reference removes dot segments
+	ret := u.ResolveReference(&url.URL{})
+	ret.Scheme, ret.Host, ret.User, ret.Fragment = scheme, host, nil, ""
+	if ret.Path == "" {
+		ret.Path, ret.RawPath = "/", ""
+	}
+
+	ret.RawQuery, ret.ForceQuery = encodeQuery(ret.RawQuery), false
+	return ret.String(), nil
+}
+
+// encodeQuery drops the tracking parameters and sorts the others by key as url.Values.Encode does,
+// but keeps the keys without values bare since servers may tell `?a` from `?a=`
+func encodeQuery(rawQuery string) string {
+	type param struct{ key, encoded string }
+
+	var ps []param
+	for _, s := range strings.Split(rawQuery, "&") {
+		if s == "" || strings.Contains(s, ";") {
+			continue
+		}
+
+		rawKey, rawValue := s, ""
+		i := strings.Index(s, "=")
+		if i >= 0 {
+			rawKey, rawValue = s[:i], s[i+1:]
+		}
+
+		key, err := url.QueryUnescape(rawKey)
+		if err != nil || isTrackingParam(key) {
+			continue
+		}
+
+		encoded := url.QueryEscape(key)
+		if i >= 0 {
+			value, err := url.QueryUnescape(rawValue)
+			if err != nil {
+				continue
+			}
+			encoded += "=" + url.QueryEscape(value)
+		}
+		ps = append(ps, param{key: key, encoded: encoded})
+	}
+
+	sort.SliceStable(ps, func(i, j int) bool { return ps[i].key < ps[j].key })
+
+	encoded := make([]string, len(ps))
+	for i, p := range ps {
+		encoded[i] = p.encoded
+	}
+	return strings.Join(encoded, "&")
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"io"
+	"os"
+
+	"github.com/mathetake/doogle/grpc"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+)
+
+var graphFormats = map[string]doogle.GraphFormat{
+	"edgelist": doogle.GraphFormat_EDGE_LIST,
+	"dot":      doogle.GraphFormat_DOT,
+	"graphml":  doogle.GraphFormat_GRAPHML,
+}
+
+// runExport writes the link graph exported by the node to stdout
+func runExport(args []string) error {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	addr := fs.String("addr", "localhost:8080", "address of the node")
+	format := fs.String("format", "edgelist", "format of the graph: edgelist, dot or graphml")
+	withAttrs := fs.Bool("attrs", false, "include urls and ranks of the items as attributes")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	f, ok := graphFormats[*format]
+	if !ok {
+		return errors.Errorf("unknown format: %s", *format)
+	}
+
+	conn, err := grpc.Dial(*addr, grpc.WithInsecure())
+	if err != nil {
+		return errors.Wrap(err, "failed to dial")
+	}
+	defer conn.Close()
+
+	stream, err := doogle.NewDoogleClient(conn).ExportGraph(context.Background(),
+		&doogle.ExportGraphRequest{Format: f, WithAttributes: *withAttrs})
+	if err != nil {
+		return errors.Wrap(err, "failed to call ExportGraph")
+	}
+
+	for {
+		c, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return errors.Wrap(err, "failed to receive graph")
+		}
+
+		if _, err := os.Stdout.Write(c.Data); err != nil {
+			return err
+		}
+	}
+}
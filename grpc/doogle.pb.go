@@ -22,6 +22,66 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
+// part of a page in which an index occurred
+type Field int32
+
+const (
+	Field_BODY    Field = 0
+	Field_TITLE   Field = 1
+	Field_HEADING Field = 2
+	Field_ANCHOR  Field = 3
+)
+
+var Field_name = map[int32]string{
+	0: "BODY",
+	1: "TITLE",
+	2: "HEADING",
+	3: "ANCHOR",
+}
+
+var Field_value = map[string]int32{
+	"BODY":    0,
+	"TITLE":   1,
+	"HEADING": 2,
+	"ANCHOR":  3,
+}
+
+func (x Field) String() string {
+	return proto.EnumName(Field_name, int32(x))
+}
+
+func (Field) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_947ca98c6f36e503, []int{0}
+}
+
+type GraphFormat int32
+
+const (
+	GraphFormat_EDGE_LIST GraphFormat = 0
+	GraphFormat_DOT       GraphFormat = 1
+	GraphFormat_GRAPHML   GraphFormat = 2
+)
+
+var GraphFormat_name = map[int32]string{
+	0: "EDGE_LIST",
+	1: "DOT",
+	2: "GRAPHML",
+}
+
+var GraphFormat_value = map[string]int32{
+	"EDGE_LIST": 0,
+	"DOT":       1,
+	"GRAPHML":   2,
+}
+
+func (x GraphFormat) String() string {
+	return proto.EnumName(GraphFormat_name, int32(x))
+}
+
+func (GraphFormat) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_947ca98c6f36e503, []int{1}
+}
+
 type Empty struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -249,12 +309,105 @@ func (m *NodeCertificate) GetDifficulty() int32 {
 	return 0
 }
 
+type ExportGraphRequest struct {
+	Format               GraphFormat `protobuf:"varint,1,opt,name=format,proto3,enum=doogle.GraphFormat" json:"format,omitempty"`
+	WithAttributes       bool        `protobuf:"varint,2,opt,name=withAttributes,proto3" json:"withAttributes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *ExportGraphRequest) Reset()         { *m = ExportGraphRequest{} }
+func (m *ExportGraphRequest) String() string { return proto.CompactTextString(m) }
+func (*ExportGraphRequest) ProtoMessage()    {}
+func (*ExportGraphRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_947ca98c6f36e503, []int{5}
+}
+
+func (m *ExportGraphRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExportGraphRequest.Unmarshal(m, b)
+}
+func (m *ExportGraphRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExportGraphRequest.Marshal(b, m, deterministic)
+}
+func (m *ExportGraphRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportGraphRequest.Merge(m, src)
+}
+func (m *ExportGraphRequest) XXX_Size() int {
+	return xxx_messageInfo_ExportGraphRequest.Size(m)
+}
+func (m *ExportGraphRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportGraphRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExportGraphRequest proto.InternalMessageInfo
+
+func (m *ExportGraphRequest) GetFormat() GraphFormat {
+	if m != nil {
+		return m.Format
+	}
+	return GraphFormat_EDGE_LIST
+}
+
+func (m *ExportGraphRequest) GetWithAttributes() bool {
+	if m != nil {
+		return m.WithAttributes
+	}
+	return false
+}
+
+// part of the exported graph. the concatenation of data is the whole
+type GraphChunk struct {
+	Data                 []byte   `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GraphChunk) Reset()         { *m = GraphChunk{} }
+func (m *GraphChunk) String() string { return proto.CompactTextString(m) }
+func (*GraphChunk) ProtoMessage()    {}
+func (*GraphChunk) Descriptor() ([]byte, []int) {
+	return fileDescriptor_947ca98c6f36e503, []int{6}
+}
+
+func (m *GraphChunk) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GraphChunk.Unmarshal(m, b)
+}
+func (m *GraphChunk) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GraphChunk.Marshal(b, m, deterministic)
+}
+func (m *GraphChunk) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GraphChunk.Merge(m, src)
+}
+func (m *GraphChunk) XXX_Size() int {
+	return xxx_messageInfo_GraphChunk.Size(m)
+}
+func (m *GraphChunk) XXX_DiscardUnknown() {
+	xxx_messageInfo_GraphChunk.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GraphChunk proto.InternalMessageInfo
+
+func (m *GraphChunk) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
 type StoreItemRequest struct {
 	Certificate          *NodeCertificate `protobuf:"bytes,1,opt,name=certificate,proto3" json:"certificate,omitempty"`
 	Url                  string           `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
 	Title                string           `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
 	EdgeURLs             []string         `protobuf:"bytes,5,rep,name=edgeURLs,proto3" json:"edgeURLs,omitempty"`
 	Index                string           `protobuf:"bytes,6,opt,name=index,proto3" json:"index,omitempty"`
+	Fields               []Field          `protobuf:"varint,7,rep,packed,name=fields,proto3,enum=doogle.Field" json:"fields,omitempty"`
+	AnchorOnly           bool             `protobuf:"varint,8,opt,name=anchorOnly,proto3" json:"anchorOnly,omitempty"`
+	Host                 string           `protobuf:"bytes,9,opt,name=host,proto3" json:"host,omitempty"`
+	Lang                 string           `protobuf:"bytes,10,opt,name=lang,proto3" json:"lang,omitempty"`
+	SimHash              uint64           `protobuf:"varint,11,opt,name=simHash,proto3" json:"simHash,omitempty"`
+	Timestamp            int64            `protobuf:"varint,12,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
 	XXX_unrecognized     []byte           `json:"-"`
 	XXX_sizecache        int32            `json:"-"`
@@ -264,7 +417,7 @@ func (m *StoreItemRequest) Reset()         { *m = StoreItemRequest{} }
 func (m *StoreItemRequest) String() string { return proto.CompactTextString(m) }
 func (*StoreItemRequest) ProtoMessage()    {}
 func (*StoreItemRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_947ca98c6f36e503, []int{5}
+	return fileDescriptor_947ca98c6f36e503, []int{7}
 }
 
 func (m *StoreItemRequest) XXX_Unmarshal(b []byte) error {
@@ -320,294 +473,406 @@ func (m *StoreItemRequest) GetIndex() string {
 	return ""
 }
 
-type Item struct {
-	Url                  string   `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
-	Title                string   `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	LocalRank            float64  `protobuf:"fixed64,4,opt,name=localRank,proto3" json:"localRank,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *StoreItemRequest) GetFields() []Field {
+	if m != nil {
+		return m.Fields
+	}
+	return nil
 }
 
-func (m *Item) Reset()         { *m = Item{} }
-func (m *Item) String() string { return proto.CompactTextString(m) }
-func (*Item) ProtoMessage()    {}
-func (*Item) Descriptor() ([]byte, []int) {
-	return fileDescriptor_947ca98c6f36e503, []int{6}
+func (m *StoreItemRequest) GetAnchorOnly() bool {
+	if m != nil {
+		return m.AnchorOnly
+	}
+	return false
 }
 
-func (m *Item) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Item.Unmarshal(m, b)
-}
-func (m *Item) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Item.Marshal(b, m, deterministic)
-}
-func (m *Item) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Item.Merge(m, src)
-}
-func (m *Item) XXX_Size() int {
-	return xxx_messageInfo_Item.Size(m)
-}
-func (m *Item) XXX_DiscardUnknown() {
-	xxx_messageInfo_Item.DiscardUnknown(m)
+func (m *StoreItemRequest) GetHost() string {
+	if m != nil {
+		return m.Host
+	}
+	return ""
 }
 
-var xxx_messageInfo_Item proto.InternalMessageInfo
-
-func (m *Item) GetUrl() string {
+func (m *StoreItemRequest) GetLang() string {
 	if m != nil {
-		return m.Url
+		return m.Lang
 	}
 	return ""
 }
 
-func (m *Item) GetTitle() string {
+func (m *StoreItemRequest) GetSimHash() uint64 {
 	if m != nil {
-		return m.Title
+		return m.SimHash
 	}
-	return ""
+	return 0
 }
 
-func (m *Item) GetLocalRank() float64 {
+func (m *StoreItemRequest) GetTimestamp() int64 {
 	if m != nil {
-		return m.LocalRank
+		return m.Timestamp
 	}
 	return 0
 }
 
-type Items struct {
-	Items                []*Item  `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+// deletion of the url from the posting list of the index
+type Tombstone struct {
+	Url                  string   `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Index                string   `protobuf:"bytes,2,opt,name=index,proto3" json:"index,omitempty"`
+	Timestamp            int64    `protobuf:"varint,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Gone                 bool     `protobuf:"varint,4,opt,name=gone,proto3" json:"gone,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Items) Reset()         { *m = Items{} }
-func (m *Items) String() string { return proto.CompactTextString(m) }
-func (*Items) ProtoMessage()    {}
-func (*Items) Descriptor() ([]byte, []int) {
-	return fileDescriptor_947ca98c6f36e503, []int{7}
+func (m *Tombstone) Reset()         { *m = Tombstone{} }
+func (m *Tombstone) String() string { return proto.CompactTextString(m) }
+func (*Tombstone) ProtoMessage()    {}
+func (*Tombstone) Descriptor() ([]byte, []int) {
+	return fileDescriptor_947ca98c6f36e503, []int{8}
 }
 
-func (m *Items) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Items.Unmarshal(m, b)
+func (m *Tombstone) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Tombstone.Unmarshal(m, b)
 }
-func (m *Items) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Items.Marshal(b, m, deterministic)
+func (m *Tombstone) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Tombstone.Marshal(b, m, deterministic)
 }
-func (m *Items) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Items.Merge(m, src)
+func (m *Tombstone) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Tombstone.Merge(m, src)
 }
-func (m *Items) XXX_Size() int {
-	return xxx_messageInfo_Items.Size(m)
+func (m *Tombstone) XXX_Size() int {
+	return xxx_messageInfo_Tombstone.Size(m)
 }
-func (m *Items) XXX_DiscardUnknown() {
-	xxx_messageInfo_Items.DiscardUnknown(m)
+func (m *Tombstone) XXX_DiscardUnknown() {
+	xxx_messageInfo_Tombstone.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Items proto.InternalMessageInfo
+var xxx_messageInfo_Tombstone proto.InternalMessageInfo
 
-func (m *Items) GetItems() []*Item {
+func (m *Tombstone) GetUrl() string {
 	if m != nil {
-		return m.Items
+		return m.Url
 	}
-	return nil
+	return ""
 }
 
-type FindIndexRequest struct {
+func (m *Tombstone) GetIndex() string {
+	if m != nil {
+		return m.Index
+	}
+	return ""
+}
+
+func (m *Tombstone) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *Tombstone) GetGone() bool {
+	if m != nil {
+		return m.Gone
+	}
+	return false
+}
+
+type DeleteItemRequest struct {
 	Certificate          *NodeCertificate `protobuf:"bytes,1,opt,name=certificate,proto3" json:"certificate,omitempty"`
-	DoogleAddress        []byte           `protobuf:"bytes,2,opt,name=doogleAddress,proto3" json:"doogleAddress,omitempty"`
+	Tombstone            *Tombstone       `protobuf:"bytes,2,opt,name=tombstone,proto3" json:"tombstone,omitempty"`
+	Signature            []byte           `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
 	XXX_unrecognized     []byte           `json:"-"`
 	XXX_sizecache        int32            `json:"-"`
 }
 
-func (m *FindIndexRequest) Reset()         { *m = FindIndexRequest{} }
-func (m *FindIndexRequest) String() string { return proto.CompactTextString(m) }
-func (*FindIndexRequest) ProtoMessage()    {}
-func (*FindIndexRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_947ca98c6f36e503, []int{8}
+func (m *DeleteItemRequest) Reset()         { *m = DeleteItemRequest{} }
+func (m *DeleteItemRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteItemRequest) ProtoMessage()    {}
+func (*DeleteItemRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_947ca98c6f36e503, []int{9}
 }
 
-func (m *FindIndexRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_FindIndexRequest.Unmarshal(m, b)
+func (m *DeleteItemRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteItemRequest.Unmarshal(m, b)
 }
-func (m *FindIndexRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_FindIndexRequest.Marshal(b, m, deterministic)
+func (m *DeleteItemRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteItemRequest.Marshal(b, m, deterministic)
 }
-func (m *FindIndexRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_FindIndexRequest.Merge(m, src)
+func (m *DeleteItemRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteItemRequest.Merge(m, src)
 }
-func (m *FindIndexRequest) XXX_Size() int {
-	return xxx_messageInfo_FindIndexRequest.Size(m)
+func (m *DeleteItemRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteItemRequest.Size(m)
 }
-func (m *FindIndexRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_FindIndexRequest.DiscardUnknown(m)
+func (m *DeleteItemRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteItemRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_FindIndexRequest proto.InternalMessageInfo
+var xxx_messageInfo_DeleteItemRequest proto.InternalMessageInfo
 
-func (m *FindIndexRequest) GetCertificate() *NodeCertificate {
+func (m *DeleteItemRequest) GetCertificate() *NodeCertificate {
 	if m != nil {
 		return m.Certificate
 	}
 	return nil
 }
 
-func (m *FindIndexRequest) GetDoogleAddress() []byte {
+func (m *DeleteItemRequest) GetTombstone() *Tombstone {
 	if m != nil {
-		return m.DoogleAddress
+		return m.Tombstone
 	}
 	return nil
 }
 
-type FindIndexReply struct {
-	// Types that are valid to be assigned to Result:
-	//	*FindIndexReply_NodeInfos
-	//	*FindIndexReply_Items
-	Result               isFindIndexReply_Result `protobuf_oneof:"result"`
-	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
-	XXX_unrecognized     []byte                  `json:"-"`
-	XXX_sizecache        int32                   `json:"-"`
+func (m *DeleteItemRequest) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
 }
 
-func (m *FindIndexReply) Reset()         { *m = FindIndexReply{} }
-func (m *FindIndexReply) String() string { return proto.CompactTextString(m) }
-func (*FindIndexReply) ProtoMessage()    {}
-func (*FindIndexReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_947ca98c6f36e503, []int{9}
+// indices of the page stored on the nodes closest to its url
+type Document struct {
+	Url                  string   `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Terms                []string `protobuf:"bytes,2,rep,name=terms,proto3" json:"terms,omitempty"`
+	Timestamp            int64    `protobuf:"varint,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Deleted              bool     `protobuf:"varint,4,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	EdgeURLs             []string `protobuf:"bytes,5,rep,name=edgeURLs,proto3" json:"edgeURLs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *FindIndexReply) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_FindIndexReply.Unmarshal(m, b)
+func (m *Document) Reset()         { *m = Document{} }
+func (m *Document) String() string { return proto.CompactTextString(m) }
+func (*Document) ProtoMessage()    {}
+func (*Document) Descriptor() ([]byte, []int) {
+	return fileDescriptor_947ca98c6f36e503, []int{10}
 }
-func (m *FindIndexReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_FindIndexReply.Marshal(b, m, deterministic)
+
+func (m *Document) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Document.Unmarshal(m, b)
 }
-func (m *FindIndexReply) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_FindIndexReply.Merge(m, src)
+func (m *Document) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Document.Marshal(b, m, deterministic)
 }
-func (m *FindIndexReply) XXX_Size() int {
-	return xxx_messageInfo_FindIndexReply.Size(m)
+func (m *Document) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Document.Merge(m, src)
 }
-func (m *FindIndexReply) XXX_DiscardUnknown() {
-	xxx_messageInfo_FindIndexReply.DiscardUnknown(m)
+func (m *Document) XXX_Size() int {
+	return xxx_messageInfo_Document.Size(m)
+}
+func (m *Document) XXX_DiscardUnknown() {
+	xxx_messageInfo_Document.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_FindIndexReply proto.InternalMessageInfo
+var xxx_messageInfo_Document proto.InternalMessageInfo
 
-type isFindIndexReply_Result interface {
-	isFindIndexReply_Result()
+func (m *Document) GetUrl() string {
+	if m != nil {
+		return m.Url
+	}
+	return ""
 }
 
-type FindIndexReply_NodeInfos struct {
-	NodeInfos *NodeInfos `protobuf:"bytes,1,opt,name=nodeInfos,proto3,oneof"`
+func (m *Document) GetTerms() []string {
+	if m != nil {
+		return m.Terms
+	}
+	return nil
 }
 
-type FindIndexReply_Items struct {
-	Items *Items `protobuf:"bytes,2,opt,name=items,proto3,oneof"`
+func (m *Document) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
 }
 
-func (*FindIndexReply_NodeInfos) isFindIndexReply_Result() {}
-
-func (*FindIndexReply_Items) isFindIndexReply_Result() {}
-
-func (m *FindIndexReply) GetResult() isFindIndexReply_Result {
+func (m *Document) GetDeleted() bool {
 	if m != nil {
-		return m.Result
+		return m.Deleted
 	}
-	return nil
+	return false
 }
 
-func (m *FindIndexReply) GetNodeInfos() *NodeInfos {
-	if x, ok := m.GetResult().(*FindIndexReply_NodeInfos); ok {
-		return x.NodeInfos
+func (m *Document) GetEdgeURLs() []string {
+	if m != nil {
+		return m.EdgeURLs
 	}
 	return nil
 }
 
-func (m *FindIndexReply) GetItems() *Items {
-	if x, ok := m.GetResult().(*FindIndexReply_Items); ok {
-		return x.Items
-	}
-	return nil
+// link from the page on url
+type Backlink struct {
+	Url                  string   `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Title                string   `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Timestamp            int64    `protobuf:"varint,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Deleted              bool     `protobuf:"varint,4,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	AnchorTerms          []string `protobuf:"bytes,5,rep,name=anchorTerms,proto3" json:"anchorTerms,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-// XXX_OneofFuncs is for the internal use of the proto package.
-func (*FindIndexReply) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
-	return _FindIndexReply_OneofMarshaler, _FindIndexReply_OneofUnmarshaler, _FindIndexReply_OneofSizer, []interface{}{
-		(*FindIndexReply_NodeInfos)(nil),
-		(*FindIndexReply_Items)(nil),
-	}
+func (m *Backlink) Reset()         { *m = Backlink{} }
+func (m *Backlink) String() string { return proto.CompactTextString(m) }
+func (*Backlink) ProtoMessage()    {}
+func (*Backlink) Descriptor() ([]byte, []int) {
+	return fileDescriptor_947ca98c6f36e503, []int{11}
 }
 
-func _FindIndexReply_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
-	m := msg.(*FindIndexReply)
-	// result
-	switch x := m.Result.(type) {
-	case *FindIndexReply_NodeInfos:
-		b.EncodeVarint(1<<3 | proto.WireBytes)
-		if err := b.EncodeMessage(x.NodeInfos); err != nil {
-			return err
-		}
-	case *FindIndexReply_Items:
-		b.EncodeVarint(2<<3 | proto.WireBytes)
-		if err := b.EncodeMessage(x.Items); err != nil {
-			return err
-		}
-	case nil:
-	default:
-		return fmt.Errorf("FindIndexReply.Result has unexpected type %T", x)
+func (m *Backlink) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Backlink.Unmarshal(m, b)
+}
+func (m *Backlink) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Backlink.Marshal(b, m, deterministic)
+}
+func (m *Backlink) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Backlink.Merge(m, src)
+}
+func (m *Backlink) XXX_Size() int {
+	return xxx_messageInfo_Backlink.Size(m)
+}
+func (m *Backlink) XXX_DiscardUnknown() {
+	xxx_messageInfo_Backlink.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Backlink proto.InternalMessageInfo
+
+func (m *Backlink) GetUrl() string {
+	if m != nil {
+		return m.Url
+	}
+	return ""
+}
+
+func (m *Backlink) GetTitle() string {
+	if m != nil {
+		return m.Title
+	}
+	return ""
+}
+
+func (m *Backlink) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *Backlink) GetDeleted() bool {
+	if m != nil {
+		return m.Deleted
+	}
+	return false
+}
+
+func (m *Backlink) GetAnchorTerms() []string {
+	if m != nil {
+		return m.AnchorTerms
 	}
 	return nil
 }
 
-func _FindIndexReply_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error) {
-	m := msg.(*FindIndexReply)
-	switch tag {
-	case 1: // result.nodeInfos
-		if wire != proto.WireBytes {
-			return true, proto.ErrInternalBadWireType
-		}
-		msg := new(NodeInfos)
-		err := b.DecodeMessage(msg)
-		m.Result = &FindIndexReply_NodeInfos{msg}
-		return true, err
-	case 2: // result.items
-		if wire != proto.WireBytes {
-			return true, proto.ErrInternalBadWireType
-		}
-		msg := new(Items)
-		err := b.DecodeMessage(msg)
-		m.Result = &FindIndexReply_Items{msg}
-		return true, err
-	default:
-		return false, nil
+type Backlinks struct {
+	Backlinks            []*Backlink `protobuf:"bytes,1,rep,name=backlinks,proto3" json:"backlinks,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *Backlinks) Reset()         { *m = Backlinks{} }
+func (m *Backlinks) String() string { return proto.CompactTextString(m) }
+func (*Backlinks) ProtoMessage()    {}
+func (*Backlinks) Descriptor() ([]byte, []int) {
+	return fileDescriptor_947ca98c6f36e503, []int{12}
+}
+
+func (m *Backlinks) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Backlinks.Unmarshal(m, b)
+}
+func (m *Backlinks) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Backlinks.Marshal(b, m, deterministic)
+}
+func (m *Backlinks) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Backlinks.Merge(m, src)
+}
+func (m *Backlinks) XXX_Size() int {
+	return xxx_messageInfo_Backlinks.Size(m)
+}
+func (m *Backlinks) XXX_DiscardUnknown() {
+	xxx_messageInfo_Backlinks.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Backlinks proto.InternalMessageInfo
+
+func (m *Backlinks) GetBacklinks() []*Backlink {
+	if m != nil {
+		return m.Backlinks
 	}
+	return nil
 }
 
-func _FindIndexReply_OneofSizer(msg proto.Message) (n int) {
-	m := msg.(*FindIndexReply)
-	// result
-	switch x := m.Result.(type) {
-	case *FindIndexReply_NodeInfos:
-		s := proto.Size(x.NodeInfos)
-		n += 1 // tag and wire
-		n += proto.SizeVarint(uint64(s))
-		n += s
-	case *FindIndexReply_Items:
-		s := proto.Size(x.Items)
-		n += 1 // tag and wire
-		n += proto.SizeVarint(uint64(s))
-		n += s
-	case nil:
-	default:
-		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+type StoreBacklinkRequest struct {
+	Certificate          *NodeCertificate `protobuf:"bytes,1,opt,name=certificate,proto3" json:"certificate,omitempty"`
+	Target               string           `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
+	Backlink             *Backlink        `protobuf:"bytes,3,opt,name=backlink,proto3" json:"backlink,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *StoreBacklinkRequest) Reset()         { *m = StoreBacklinkRequest{} }
+func (m *StoreBacklinkRequest) String() string { return proto.CompactTextString(m) }
+func (*StoreBacklinkRequest) ProtoMessage()    {}
+func (*StoreBacklinkRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_947ca98c6f36e503, []int{13}
+}
+
+func (m *StoreBacklinkRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StoreBacklinkRequest.Unmarshal(m, b)
+}
+func (m *StoreBacklinkRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StoreBacklinkRequest.Marshal(b, m, deterministic)
+}
+func (m *StoreBacklinkRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StoreBacklinkRequest.Merge(m, src)
+}
+func (m *StoreBacklinkRequest) XXX_Size() int {
+	return xxx_messageInfo_StoreBacklinkRequest.Size(m)
+}
+func (m *StoreBacklinkRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StoreBacklinkRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StoreBacklinkRequest proto.InternalMessageInfo
+
+func (m *StoreBacklinkRequest) GetCertificate() *NodeCertificate {
+	if m != nil {
+		return m.Certificate
 	}
-	return n
+	return nil
 }
 
-type FindNodeRequest struct {
+func (m *StoreBacklinkRequest) GetTarget() string {
+	if m != nil {
+		return m.Target
+	}
+	return ""
+}
+
+func (m *StoreBacklinkRequest) GetBacklink() *Backlink {
+	if m != nil {
+		return m.Backlink
+	}
+	return nil
+}
+
+type FindBacklinksRequest struct {
 	Certificate          *NodeCertificate `protobuf:"bytes,1,opt,name=certificate,proto3" json:"certificate,omitempty"`
 	DoogleAddress        []byte           `protobuf:"bytes,2,opt,name=doogleAddress,proto3" json:"doogleAddress,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
@@ -615,410 +880,3471 @@ type FindNodeRequest struct {
 	XXX_sizecache        int32            `json:"-"`
 }
 
-func (m *FindNodeRequest) Reset()         { *m = FindNodeRequest{} }
-func (m *FindNodeRequest) String() string { return proto.CompactTextString(m) }
-func (*FindNodeRequest) ProtoMessage()    {}
-func (*FindNodeRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_947ca98c6f36e503, []int{10}
+func (m *FindBacklinksRequest) Reset()         { *m = FindBacklinksRequest{} }
+func (m *FindBacklinksRequest) String() string { return proto.CompactTextString(m) }
+func (*FindBacklinksRequest) ProtoMessage()    {}
+func (*FindBacklinksRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_947ca98c6f36e503, []int{14}
 }
 
-func (m *FindNodeRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_FindNodeRequest.Unmarshal(m, b)
+func (m *FindBacklinksRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FindBacklinksRequest.Unmarshal(m, b)
 }
-func (m *FindNodeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_FindNodeRequest.Marshal(b, m, deterministic)
+func (m *FindBacklinksRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FindBacklinksRequest.Marshal(b, m, deterministic)
 }
-func (m *FindNodeRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_FindNodeRequest.Merge(m, src)
+func (m *FindBacklinksRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FindBacklinksRequest.Merge(m, src)
 }
-func (m *FindNodeRequest) XXX_Size() int {
-	return xxx_messageInfo_FindNodeRequest.Size(m)
+func (m *FindBacklinksRequest) XXX_Size() int {
+	return xxx_messageInfo_FindBacklinksRequest.Size(m)
 }
-func (m *FindNodeRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_FindNodeRequest.DiscardUnknown(m)
+func (m *FindBacklinksRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FindBacklinksRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_FindNodeRequest proto.InternalMessageInfo
+var xxx_messageInfo_FindBacklinksRequest proto.InternalMessageInfo
 
-func (m *FindNodeRequest) GetCertificate() *NodeCertificate {
+func (m *FindBacklinksRequest) GetCertificate() *NodeCertificate {
 	if m != nil {
 		return m.Certificate
 	}
 	return nil
 }
 
-func (m *FindNodeRequest) GetDoogleAddress() []byte {
+func (m *FindBacklinksRequest) GetDoogleAddress() []byte {
 	if m != nil {
 		return m.DoogleAddress
 	}
 	return nil
 }
 
-type GetIndexReply struct {
-	Items                []*Item  `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+type FindCoLinksRequest struct {
+	Certificate          *NodeCertificate `protobuf:"bytes,1,opt,name=certificate,proto3" json:"certificate,omitempty"`
+	DoogleAddress        []byte           `protobuf:"bytes,2,opt,name=doogleAddress,proto3" json:"doogleAddress,omitempty"`
+	Edges                [][]byte         `protobuf:"bytes,3,rep,name=edges,proto3" json:"edges,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *FindCoLinksRequest) Reset()         { *m = FindCoLinksRequest{} }
+func (m *FindCoLinksRequest) String() string { return proto.CompactTextString(m) }
+func (*FindCoLinksRequest) ProtoMessage()    {}
+func (*FindCoLinksRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_947ca98c6f36e503, []int{15}
+}
+
+func (m *FindCoLinksRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FindCoLinksRequest.Unmarshal(m, b)
+}
+func (m *FindCoLinksRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FindCoLinksRequest.Marshal(b, m, deterministic)
+}
+func (m *FindCoLinksRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FindCoLinksRequest.Merge(m, src)
+}
+func (m *FindCoLinksRequest) XXX_Size() int {
+	return xxx_messageInfo_FindCoLinksRequest.Size(m)
+}
+func (m *FindCoLinksRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FindCoLinksRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FindCoLinksRequest proto.InternalMessageInfo
+
+func (m *FindCoLinksRequest) GetCertificate() *NodeCertificate {
+	if m != nil {
+		return m.Certificate
+	}
+	return nil
+}
+
+func (m *FindCoLinksRequest) GetDoogleAddress() []byte {
+	if m != nil {
+		return m.DoogleAddress
+	}
+	return nil
+}
+
+func (m *FindCoLinksRequest) GetEdges() [][]byte {
+	if m != nil {
+		return m.Edges
+	}
+	return nil
+}
+
+// page linked alongside the requested one
+type CoLink struct {
+	DoogleAddress        []byte   `protobuf:"bytes,1,opt,name=doogleAddress,proto3" json:"doogleAddress,omitempty"`
+	Url                  string   `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	Title                string   `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	CitedBy              [][]byte `protobuf:"bytes,4,rep,name=citedBy,proto3" json:"citedBy,omitempty"`
+	CoupledBy            [][]byte `protobuf:"bytes,5,rep,name=coupledBy,proto3" json:"coupledBy,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GetIndexReply) Reset()         { *m = GetIndexReply{} }
-func (m *GetIndexReply) String() string { return proto.CompactTextString(m) }
-func (*GetIndexReply) ProtoMessage()    {}
-func (*GetIndexReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_947ca98c6f36e503, []int{11}
+func (m *CoLink) Reset()         { *m = CoLink{} }
+func (m *CoLink) String() string { return proto.CompactTextString(m) }
+func (*CoLink) ProtoMessage()    {}
+func (*CoLink) Descriptor() ([]byte, []int) {
+	return fileDescriptor_947ca98c6f36e503, []int{16}
 }
 
-func (m *GetIndexReply) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetIndexReply.Unmarshal(m, b)
+func (m *CoLink) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CoLink.Unmarshal(m, b)
 }
-func (m *GetIndexReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetIndexReply.Marshal(b, m, deterministic)
+func (m *CoLink) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CoLink.Marshal(b, m, deterministic)
 }
-func (m *GetIndexReply) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetIndexReply.Merge(m, src)
+func (m *CoLink) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CoLink.Merge(m, src)
 }
-func (m *GetIndexReply) XXX_Size() int {
-	return xxx_messageInfo_GetIndexReply.Size(m)
+func (m *CoLink) XXX_Size() int {
+	return xxx_messageInfo_CoLink.Size(m)
 }
-func (m *GetIndexReply) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetIndexReply.DiscardUnknown(m)
+func (m *CoLink) XXX_DiscardUnknown() {
+	xxx_messageInfo_CoLink.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetIndexReply proto.InternalMessageInfo
+var xxx_messageInfo_CoLink proto.InternalMessageInfo
 
-func (m *GetIndexReply) GetItems() []*Item {
+func (m *CoLink) GetDoogleAddress() []byte {
 	if m != nil {
-		return m.Items
+		return m.DoogleAddress
 	}
 	return nil
 }
 
-func init() {
-	proto.RegisterType((*Empty)(nil), "doogle.Empty")
-	proto.RegisterType((*StringMessage)(nil), "doogle.StringMessage")
-	proto.RegisterType((*NodeInfo)(nil), "doogle.NodeInfo")
-	proto.RegisterType((*NodeInfos)(nil), "doogle.NodeInfos")
-	proto.RegisterType((*NodeCertificate)(nil), "doogle.NodeCertificate")
-	proto.RegisterType((*StoreItemRequest)(nil), "doogle.StoreItemRequest")
-	proto.RegisterType((*Item)(nil), "doogle.Item")
-	proto.RegisterType((*Items)(nil), "doogle.Items")
-	proto.RegisterType((*FindIndexRequest)(nil), "doogle.FindIndexRequest")
-	proto.RegisterType((*FindIndexReply)(nil), "doogle.FindIndexReply")
-	proto.RegisterType((*FindNodeRequest)(nil), "doogle.FindNodeRequest")
-	proto.RegisterType((*GetIndexReply)(nil), "doogle.GetIndexReply")
+func (m *CoLink) GetUrl() string {
+	if m != nil {
+		return m.Url
+	}
+	return ""
 }
 
-func init() { proto.RegisterFile("doogle.proto", fileDescriptor_947ca98c6f36e503) }
+func (m *CoLink) GetTitle() string {
+	if m != nil {
+		return m.Title
+	}
+	return ""
+}
 
-var fileDescriptor_947ca98c6f36e503 = []byte{
-	// 581 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x55, 0xdb, 0x6e, 0xd3, 0x40,
-	0x10, 0xc5, 0x49, 0xed, 0xc6, 0xd3, 0xa4, 0x0d, 0xc3, 0xa5, 0x96, 0x55, 0xa1, 0x68, 0x05, 0x28,
-	0x08, 0xa9, 0x52, 0x93, 0x8a, 0xcb, 0x03, 0x0f, 0x5c, 0x4b, 0x44, 0x41, 0xd5, 0x96, 0x0a, 0x5e,
-	0x53, 0x7b, 0x13, 0x56, 0x75, 0xbc, 0xc1, 0xde, 0x08, 0xf2, 0x45, 0x7c, 0x00, 0x7f, 0xc3, 0xd7,
-	0xa0, 0x5d, 0x67, 0xe3, 0x6d, 0x2e, 0x02, 0xa4, 0x8a, 0xb7, 0x9d, 0x99, 0x33, 0x97, 0x33, 0x33,
-	0x9a, 0x85, 0x7a, 0x2c, 0xc4, 0x30, 0x61, 0xfb, 0xe3, 0x4c, 0x48, 0x81, 0x5e, 0x21, 0x91, 0x4d,
-	0x70, 0x5f, 0x8f, 0xc6, 0x72, 0x4a, 0x1e, 0x40, 0xe3, 0x54, 0x66, 0x3c, 0x1d, 0xbe, 0x67, 0x79,
-	0xde, 0x1f, 0x32, 0x0c, 0x60, 0x73, 0x54, 0x3c, 0x03, 0xa7, 0xe5, 0xb4, 0x7d, 0x6a, 0x44, 0xf2,
-	0x19, 0x6a, 0x1f, 0x44, 0xcc, 0x7a, 0xe9, 0x40, 0xe0, 0x5d, 0x68, 0x14, 0x91, 0x9e, 0xc7, 0x71,
-	0xc6, 0xf2, 0x5c, 0x63, 0xeb, 0xf4, 0xb2, 0x12, 0xef, 0xc3, 0x76, 0xca, 0xe4, 0x37, 0x91, 0x5d,
-	0x18, 0x58, 0x45, 0x87, 0x5c, 0xd0, 0x92, 0x2e, 0xf8, 0x26, 0xb2, 0x72, 0x72, 0xb9, 0x7a, 0x04,
-	0x4e, 0xab, 0xda, 0xde, 0xea, 0x34, 0xf7, 0x67, 0x04, 0x0c, 0x82, 0x16, 0x66, 0xf2, 0xd3, 0x81,
-	0x1d, 0xa5, 0x7b, 0xc9, 0x32, 0xc9, 0x07, 0x3c, 0xea, 0x4b, 0x76, 0xb5, 0x65, 0xe1, 0x1e, 0xf8,
-	0xe3, 0xc9, 0x79, 0xc2, 0xa3, 0x77, 0x6c, 0x1a, 0x54, 0x75, 0xa4, 0x52, 0x81, 0x37, 0xc1, 0x4d,
-	0x45, 0x1a, 0xb1, 0x60, 0x43, 0x5b, 0x0a, 0x01, 0xef, 0x00, 0xc4, 0x7c, 0x30, 0xe0, 0xd1, 0x24,
-	0x91, 0xd3, 0xc0, 0x6d, 0x39, 0x6d, 0x97, 0x5a, 0x1a, 0xf2, 0xc3, 0x81, 0xe6, 0xa9, 0x14, 0x19,
-	0xeb, 0x49, 0x36, 0xa2, 0xec, 0xeb, 0x84, 0xe5, 0x12, 0x9f, 0xc2, 0x56, 0x54, 0xb2, 0xd0, 0x45,
-	0x6f, 0x75, 0x76, 0x6d, 0xe2, 0x16, 0x49, 0x6a, 0x63, 0xb1, 0x09, 0xd5, 0x49, 0x96, 0xcc, 0x08,
-	0xa8, 0xa7, 0xaa, 0x4b, 0x72, 0x99, 0x30, 0x5d, 0xb1, 0x4f, 0x0b, 0x01, 0x43, 0xa8, 0xb1, 0x78,
-	0xc8, 0xce, 0xe8, 0x71, 0x1e, 0xb8, 0xad, 0x6a, 0xdb, 0xa7, 0x73, 0x59, 0x79, 0xf0, 0x34, 0x66,
-	0xdf, 0x03, 0xaf, 0xf0, 0xd0, 0x02, 0x39, 0x86, 0x0d, 0x55, 0xa3, 0xc9, 0xe0, 0xac, 0xc8, 0x50,
-	0xb1, 0x33, 0xec, 0x81, 0x9f, 0x88, 0xa8, 0x9f, 0xd0, 0x7e, 0x7a, 0xa1, 0x7b, 0xe2, 0xd0, 0x52,
-	0x41, 0x1e, 0x82, 0xab, 0xa2, 0xe5, 0x48, 0xc0, 0xe5, 0xea, 0x31, 0x1b, 0x6f, 0xdd, 0xb0, 0xd4,
-	0xfd, 0x28, 0x4c, 0x24, 0x87, 0xe6, 0x1b, 0x9e, 0xc6, 0x3d, 0x55, 0xc7, 0x15, 0xf4, 0x68, 0x69,
-	0x2b, 0x2a, 0x2b, 0xb6, 0x82, 0x48, 0xd8, 0xb6, 0x92, 0x8e, 0x93, 0x29, 0x1e, 0x80, 0x9f, 0x9a,
-	0xb5, 0x9c, 0x25, 0xbc, 0xbe, 0xb8, 0x8d, 0xf9, 0xdb, 0x6b, 0xb4, 0x44, 0xe1, 0x3d, 0xc3, 0xae,
-	0xa2, 0xe1, 0x0d, 0x9b, 0x9d, 0x82, 0x16, 0xd6, 0x17, 0x35, 0xf0, 0x32, 0x96, 0x4f, 0x12, 0x49,
-	0x32, 0xd8, 0x51, 0x59, 0x55, 0xb8, 0xff, 0xc6, 0xb4, 0x0b, 0x8d, 0x23, 0x26, 0x2d, 0xa2, 0x7f,
-	0x31, 0x93, 0xce, 0xaf, 0x2a, 0x78, 0xaf, 0xb4, 0x1a, 0x0f, 0xc1, 0x9f, 0xaf, 0x30, 0x06, 0x06,
-	0xbc, 0xb8, 0xd5, 0xe1, 0x9c, 0xbc, 0xbe, 0x34, 0xf8, 0x0c, 0xfc, 0x79, 0x7f, 0x4b, 0xaf, 0xc5,
-	0x39, 0x87, 0xb7, 0x57, 0x58, 0x54, 0x8d, 0x8f, 0xa0, 0x66, 0x1a, 0x85, 0xbb, 0x36, 0xc6, 0x6a,
-	0x5d, 0xb8, 0x3c, 0x1e, 0x3c, 0x82, 0x1b, 0x27, 0x3c, 0x1d, 0x7e, 0xe2, 0xf2, 0x8b, 0x7d, 0x29,
-	0xd6, 0xf5, 0x33, 0x5c, 0x67, 0xc0, 0x43, 0xd8, 0x50, 0x81, 0xf0, 0x56, 0x49, 0xd8, 0xba, 0x9b,
-	0xe1, 0x6a, 0x35, 0x1e, 0x80, 0xa7, 0xbc, 0x3e, 0x0a, 0x5c, 0x3a, 0x64, 0xeb, 0x5c, 0x9e, 0x40,
-	0xcd, 0x8c, 0xe7, 0x8f, 0xc9, 0x2e, 0xcf, 0xf1, 0x31, 0x6c, 0x9e, 0x88, 0x5c, 0x9e, 0x65, 0xc9,
-	0xbf, 0x55, 0x79, 0xee, 0xe9, 0xdf, 0xa1, 0xfb, 0x3b, 0x00, 0x00, 0xff, 0xff, 0xc6, 0x80, 0xfa,
-	0x5e, 0x2d, 0x06, 0x00, 0x00,
+func (m *CoLink) GetCitedBy() [][]byte {
+	if m != nil {
+		return m.CitedBy
+	}
+	return nil
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
+func (m *CoLink) GetCoupledBy() [][]byte {
+	if m != nil {
+		return m.CoupledBy
+	}
+	return nil
+}
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+type CoLinks struct {
+	CoLinks              []*CoLink `protobuf:"bytes,1,rep,name=coLinks,proto3" json:"coLinks,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
 
-// DoogleClient is the client API for Doogle service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type DoogleClient interface {
-	// Store give index
-	StoreItem(ctx context.Context, in *StoreItemRequest, opts ...grpc.CallOption) (*Empty, error)
-	// find index of given key
-	FindIndex(ctx context.Context, in *FindIndexRequest, opts ...grpc.CallOption) (*FindIndexReply, error)
-	// return k closed nodes to given address
-	FindNode(ctx context.Context, in *FindNodeRequest, opts ...grpc.CallOption) (*NodeInfos, error)
-	// health check
-	PingWithCertificate(ctx context.Context, in *NodeCertificate, opts ...grpc.CallOption) (*NodeCertificate, error)
-	// the following endpoints can be accessed from outside of the network.
-	Ping(ctx context.Context, in *StringMessage, opts ...grpc.CallOption) (*StringMessage, error)
-	PingTo(ctx context.Context, in *NodeInfo, opts ...grpc.CallOption) (*StringMessage, error)
-	GetIndex(ctx context.Context, in *StringMessage, opts ...grpc.CallOption) (*GetIndexReply, error)
-	PostUrl(ctx context.Context, in *StringMessage, opts ...grpc.CallOption) (*StringMessage, error)
+func (m *CoLinks) Reset()         { *m = CoLinks{} }
+func (m *CoLinks) String() string { return proto.CompactTextString(m) }
+func (*CoLinks) ProtoMessage()    {}
+func (*CoLinks) Descriptor() ([]byte, []int) {
+	return fileDescriptor_947ca98c6f36e503, []int{17}
+}
+
+func (m *CoLinks) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CoLinks.Unmarshal(m, b)
+}
+func (m *CoLinks) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CoLinks.Marshal(b, m, deterministic)
+}
+func (m *CoLinks) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CoLinks.Merge(m, src)
+}
+func (m *CoLinks) XXX_Size() int {
+	return xxx_messageInfo_CoLinks.Size(m)
+}
+func (m *CoLinks) XXX_DiscardUnknown() {
+	xxx_messageInfo_CoLinks.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CoLinks proto.InternalMessageInfo
+
+func (m *CoLinks) GetCoLinks() []*CoLink {
+	if m != nil {
+		return m.CoLinks
+	}
+	return nil
+}
+
+type RelatedPage struct {
+	Url                  string   `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Title                string   `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	CoCitation           int32    `protobuf:"varint,3,opt,name=coCitation,proto3" json:"coCitation,omitempty"`
+	Coupling             int32    `protobuf:"varint,4,opt,name=coupling,proto3" json:"coupling,omitempty"`
+	Score                float64  `protobuf:"fixed64,5,opt,name=score,proto3" json:"score,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RelatedPage) Reset()         { *m = RelatedPage{} }
+func (m *RelatedPage) String() string { return proto.CompactTextString(m) }
+func (*RelatedPage) ProtoMessage()    {}
+func (*RelatedPage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_947ca98c6f36e503, []int{18}
+}
+
+func (m *RelatedPage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RelatedPage.Unmarshal(m, b)
+}
+func (m *RelatedPage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RelatedPage.Marshal(b, m, deterministic)
+}
+func (m *RelatedPage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RelatedPage.Merge(m, src)
+}
+func (m *RelatedPage) XXX_Size() int {
+	return xxx_messageInfo_RelatedPage.Size(m)
+}
+func (m *RelatedPage) XXX_DiscardUnknown() {
+	xxx_messageInfo_RelatedPage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RelatedPage proto.InternalMessageInfo
+
+func (m *RelatedPage) GetUrl() string {
+	if m != nil {
+		return m.Url
+	}
+	return ""
+}
+
+func (m *RelatedPage) GetTitle() string {
+	if m != nil {
+		return m.Title
+	}
+	return ""
+}
+
+func (m *RelatedPage) GetCoCitation() int32 {
+	if m != nil {
+		return m.CoCitation
+	}
+	return 0
+}
+
+func (m *RelatedPage) GetCoupling() int32 {
+	if m != nil {
+		return m.Coupling
+	}
+	return 0
+}
+
+func (m *RelatedPage) GetScore() float64 {
+	if m != nil {
+		return m.Score
+	}
+	return 0
+}
+
+type RelatedPages struct {
+	Pages                []*RelatedPage `protobuf:"bytes,1,rep,name=pages,proto3" json:"pages,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *RelatedPages) Reset()         { *m = RelatedPages{} }
+func (m *RelatedPages) String() string { return proto.CompactTextString(m) }
+func (*RelatedPages) ProtoMessage()    {}
+func (*RelatedPages) Descriptor() ([]byte, []int) {
+	return fileDescriptor_947ca98c6f36e503, []int{19}
+}
+
+func (m *RelatedPages) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RelatedPages.Unmarshal(m, b)
+}
+func (m *RelatedPages) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RelatedPages.Marshal(b, m, deterministic)
+}
+func (m *RelatedPages) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RelatedPages.Merge(m, src)
+}
+func (m *RelatedPages) XXX_Size() int {
+	return xxx_messageInfo_RelatedPages.Size(m)
+}
+func (m *RelatedPages) XXX_DiscardUnknown() {
+	xxx_messageInfo_RelatedPages.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RelatedPages proto.InternalMessageInfo
+
+func (m *RelatedPages) GetPages() []*RelatedPage {
+	if m != nil {
+		return m.Pages
+	}
+	return nil
+}
+
+// score of a page estimated by a node
+type PageScore struct {
+	DoogleAddress        []byte   `protobuf:"bytes,1,opt,name=doogleAddress,proto3" json:"doogleAddress,omitempty"`
+	Score                float64  `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"`
+	Edges                [][]byte `protobuf:"bytes,3,rep,name=edges,proto3" json:"edges,omitempty"`
+	Owned                bool     `protobuf:"varint,4,opt,name=owned,proto3" json:"owned,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PageScore) Reset()         { *m = PageScore{} }
+func (m *PageScore) String() string { return proto.CompactTextString(m) }
+func (*PageScore) ProtoMessage()    {}
+func (*PageScore) Descriptor() ([]byte, []int) {
+	return fileDescriptor_947ca98c6f36e503, []int{20}
+}
+
+func (m *PageScore) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PageScore.Unmarshal(m, b)
+}
+func (m *PageScore) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PageScore.Marshal(b, m, deterministic)
+}
+func (m *PageScore) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PageScore.Merge(m, src)
+}
+func (m *PageScore) XXX_Size() int {
+	return xxx_messageInfo_PageScore.Size(m)
+}
+func (m *PageScore) XXX_DiscardUnknown() {
+	xxx_messageInfo_PageScore.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PageScore proto.InternalMessageInfo
+
+func (m *PageScore) GetDoogleAddress() []byte {
+	if m != nil {
+		return m.DoogleAddress
+	}
+	return nil
+}
+
+func (m *PageScore) GetScore() float64 {
+	if m != nil {
+		return m.Score
+	}
+	return 0
+}
+
+func (m *PageScore) GetEdges() [][]byte {
+	if m != nil {
+		return m.Edges
+	}
+	return nil
+}
+
+func (m *PageScore) GetOwned() bool {
+	if m != nil {
+		return m.Owned
+	}
+	return false
+}
+
+type MeetRequest struct {
+	Certificate          *NodeCertificate `protobuf:"bytes,1,opt,name=certificate,proto3" json:"certificate,omitempty"`
+	Pages                []*PageScore     `protobuf:"bytes,2,rep,name=pages,proto3" json:"pages,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *MeetRequest) Reset()         { *m = MeetRequest{} }
+func (m *MeetRequest) String() string { return proto.CompactTextString(m) }
+func (*MeetRequest) ProtoMessage()    {}
+func (*MeetRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_947ca98c6f36e503, []int{21}
+}
+
+func (m *MeetRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MeetRequest.Unmarshal(m, b)
+}
+func (m *MeetRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MeetRequest.Marshal(b, m, deterministic)
+}
+func (m *MeetRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MeetRequest.Merge(m, src)
+}
+func (m *MeetRequest) XXX_Size() int {
+	return xxx_messageInfo_MeetRequest.Size(m)
+}
+func (m *MeetRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_MeetRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MeetRequest proto.InternalMessageInfo
+
+func (m *MeetRequest) GetCertificate() *NodeCertificate {
+	if m != nil {
+		return m.Certificate
+	}
+	return nil
+}
+
+func (m *MeetRequest) GetPages() []*PageScore {
+	if m != nil {
+		return m.Pages
+	}
+	return nil
+}
+
+type MeetReply struct {
+	Pages                []*PageScore `protobuf:"bytes,1,rep,name=pages,proto3" json:"pages,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *MeetReply) Reset()         { *m = MeetReply{} }
+func (m *MeetReply) String() string { return proto.CompactTextString(m) }
+func (*MeetReply) ProtoMessage()    {}
+func (*MeetReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_947ca98c6f36e503, []int{22}
+}
+
+func (m *MeetReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MeetReply.Unmarshal(m, b)
+}
+func (m *MeetReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MeetReply.Marshal(b, m, deterministic)
+}
+func (m *MeetReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MeetReply.Merge(m, src)
+}
+func (m *MeetReply) XXX_Size() int {
+	return xxx_messageInfo_MeetReply.Size(m)
+}
+func (m *MeetReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_MeetReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MeetReply proto.InternalMessageInfo
+
+func (m *MeetReply) GetPages() []*PageScore {
+	if m != nil {
+		return m.Pages
+	}
+	return nil
+}
+
+type StoreDocumentRequest struct {
+	Certificate          *NodeCertificate `protobuf:"bytes,1,opt,name=certificate,proto3" json:"certificate,omitempty"`
+	Document             *Document        `protobuf:"bytes,2,opt,name=document,proto3" json:"document,omitempty"`
+	Signature            []byte           `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *StoreDocumentRequest) Reset()         { *m = StoreDocumentRequest{} }
+func (m *StoreDocumentRequest) String() string { return proto.CompactTextString(m) }
+func (*StoreDocumentRequest) ProtoMessage()    {}
+func (*StoreDocumentRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_947ca98c6f36e503, []int{23}
+}
+
+func (m *StoreDocumentRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StoreDocumentRequest.Unmarshal(m, b)
+}
+func (m *StoreDocumentRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StoreDocumentRequest.Marshal(b, m, deterministic)
+}
+func (m *StoreDocumentRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StoreDocumentRequest.Merge(m, src)
+}
+func (m *StoreDocumentRequest) XXX_Size() int {
+	return xxx_messageInfo_StoreDocumentRequest.Size(m)
+}
+func (m *StoreDocumentRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StoreDocumentRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StoreDocumentRequest proto.InternalMessageInfo
+
+func (m *StoreDocumentRequest) GetCertificate() *NodeCertificate {
+	if m != nil {
+		return m.Certificate
+	}
+	return nil
+}
+
+func (m *StoreDocumentRequest) GetDocument() *Document {
+	if m != nil {
+		return m.Document
+	}
+	return nil
+}
+
+func (m *StoreDocumentRequest) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+type FindDocumentRequest struct {
+	Certificate          *NodeCertificate `protobuf:"bytes,1,opt,name=certificate,proto3" json:"certificate,omitempty"`
+	DoogleAddress        []byte           `protobuf:"bytes,2,opt,name=doogleAddress,proto3" json:"doogleAddress,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *FindDocumentRequest) Reset()         { *m = FindDocumentRequest{} }
+func (m *FindDocumentRequest) String() string { return proto.CompactTextString(m) }
+func (*FindDocumentRequest) ProtoMessage()    {}
+func (*FindDocumentRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_947ca98c6f36e503, []int{24}
+}
+
+func (m *FindDocumentRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FindDocumentRequest.Unmarshal(m, b)
+}
+func (m *FindDocumentRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FindDocumentRequest.Marshal(b, m, deterministic)
+}
+func (m *FindDocumentRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FindDocumentRequest.Merge(m, src)
+}
+func (m *FindDocumentRequest) XXX_Size() int {
+	return xxx_messageInfo_FindDocumentRequest.Size(m)
+}
+func (m *FindDocumentRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FindDocumentRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FindDocumentRequest proto.InternalMessageInfo
+
+func (m *FindDocumentRequest) GetCertificate() *NodeCertificate {
+	if m != nil {
+		return m.Certificate
+	}
+	return nil
+}
+
+func (m *FindDocumentRequest) GetDoogleAddress() []byte {
+	if m != nil {
+		return m.DoogleAddress
+	}
+	return nil
+}
+
+type Item struct {
+	Url                  string   `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Title                string   `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	LocalRank            float64  `protobuf:"fixed64,4,opt,name=localRank,proto3" json:"localRank,omitempty"`
+	Fields               []Field  `protobuf:"varint,5,rep,packed,name=fields,proto3,enum=doogle.Field" json:"fields,omitempty"`
+	Host                 string   `protobuf:"bytes,6,opt,name=host,proto3" json:"host,omitempty"`
+	Lang                 string   `protobuf:"bytes,7,opt,name=lang,proto3" json:"lang,omitempty"`
+	SimHash              uint64   `protobuf:"varint,8,opt,name=simHash,proto3" json:"simHash,omitempty"`
+	RankComputedAt       int64    `protobuf:"varint,9,opt,name=rankComputedAt,proto3" json:"rankComputedAt,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Item) Reset()         { *m = Item{} }
+func (m *Item) String() string { return proto.CompactTextString(m) }
+func (*Item) ProtoMessage()    {}
+func (*Item) Descriptor() ([]byte, []int) {
+	return fileDescriptor_947ca98c6f36e503, []int{25}
+}
+
+func (m *Item) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Item.Unmarshal(m, b)
+}
+func (m *Item) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Item.Marshal(b, m, deterministic)
+}
+func (m *Item) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Item.Merge(m, src)
+}
+func (m *Item) XXX_Size() int {
+	return xxx_messageInfo_Item.Size(m)
+}
+func (m *Item) XXX_DiscardUnknown() {
+	xxx_messageInfo_Item.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Item proto.InternalMessageInfo
+
+func (m *Item) GetUrl() string {
+	if m != nil {
+		return m.Url
+	}
+	return ""
+}
+
+func (m *Item) GetTitle() string {
+	if m != nil {
+		return m.Title
+	}
+	return ""
+}
+
+func (m *Item) GetLocalRank() float64 {
+	if m != nil {
+		return m.LocalRank
+	}
+	return 0
+}
+
+func (m *Item) GetFields() []Field {
+	if m != nil {
+		return m.Fields
+	}
+	return nil
+}
+
+func (m *Item) GetHost() string {
+	if m != nil {
+		return m.Host
+	}
+	return ""
+}
+
+func (m *Item) GetLang() string {
+	if m != nil {
+		return m.Lang
+	}
+	return ""
+}
+
+func (m *Item) GetSimHash() uint64 {
+	if m != nil {
+		return m.SimHash
+	}
+	return 0
+}
+
+func (m *Item) GetRankComputedAt() int64 {
+	if m != nil {
+		return m.RankComputedAt
+	}
+	return 0
+}
+
+type Items struct {
+	Items                []*Item  `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Items) Reset()         { *m = Items{} }
+func (m *Items) String() string { return proto.CompactTextString(m) }
+func (*Items) ProtoMessage()    {}
+func (*Items) Descriptor() ([]byte, []int) {
+	return fileDescriptor_947ca98c6f36e503, []int{26}
+}
+
+func (m *Items) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Items.Unmarshal(m, b)
+}
+func (m *Items) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Items.Marshal(b, m, deterministic)
+}
+func (m *Items) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Items.Merge(m, src)
+}
+func (m *Items) XXX_Size() int {
+	return xxx_messageInfo_Items.Size(m)
+}
+func (m *Items) XXX_DiscardUnknown() {
+	xxx_messageInfo_Items.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Items proto.InternalMessageInfo
+
+func (m *Items) GetItems() []*Item {
+	if m != nil {
+		return m.Items
+	}
+	return nil
+}
+
+// restriction on items returned by FindIndex
+type Filter struct {
+	// empty values mean no restriction
+	Fields               []Field  `protobuf:"varint,1,rep,packed,name=fields,proto3,enum=doogle.Field" json:"fields,omitempty"`
+	Site                 string   `protobuf:"bytes,2,opt,name=site,proto3" json:"site,omitempty"`
+	Inurl                string   `protobuf:"bytes,3,opt,name=inurl,proto3" json:"inurl,omitempty"`
+	Lang                 string   `protobuf:"bytes,4,opt,name=lang,proto3" json:"lang,omitempty"`
+	Link                 string   `protobuf:"bytes,5,opt,name=link,proto3" json:"link,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Filter) Reset()         { *m = Filter{} }
+func (m *Filter) String() string { return proto.CompactTextString(m) }
+func (*Filter) ProtoMessage()    {}
+func (*Filter) Descriptor() ([]byte, []int) {
+	return fileDescriptor_947ca98c6f36e503, []int{27}
+}
+
+func (m *Filter) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Filter.Unmarshal(m, b)
+}
+func (m *Filter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Filter.Marshal(b, m, deterministic)
+}
+func (m *Filter) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Filter.Merge(m, src)
+}
+func (m *Filter) XXX_Size() int {
+	return xxx_messageInfo_Filter.Size(m)
+}
+func (m *Filter) XXX_DiscardUnknown() {
+	xxx_messageInfo_Filter.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Filter proto.InternalMessageInfo
+
+func (m *Filter) GetFields() []Field {
+	if m != nil {
+		return m.Fields
+	}
+	return nil
+}
+
+func (m *Filter) GetSite() string {
+	if m != nil {
+		return m.Site
+	}
+	return ""
+}
+
+func (m *Filter) GetInurl() string {
+	if m != nil {
+		return m.Inurl
+	}
+	return ""
+}
+
+func (m *Filter) GetLang() string {
+	if m != nil {
+		return m.Lang
+	}
+	return ""
+}
+
+func (m *Filter) GetLink() string {
+	if m != nil {
+		return m.Link
+	}
+	return ""
+}
+
+type FindIndexRequest struct {
+	Certificate          *NodeCertificate `protobuf:"bytes,1,opt,name=certificate,proto3" json:"certificate,omitempty"`
+	DoogleAddress        []byte           `protobuf:"bytes,2,opt,name=doogleAddress,proto3" json:"doogleAddress,omitempty"`
+	Filter               *Filter          `protobuf:"bytes,3,opt,name=filter,proto3" json:"filter,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *FindIndexRequest) Reset()         { *m = FindIndexRequest{} }
+func (m *FindIndexRequest) String() string { return proto.CompactTextString(m) }
+func (*FindIndexRequest) ProtoMessage()    {}
+func (*FindIndexRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_947ca98c6f36e503, []int{28}
+}
+
+func (m *FindIndexRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FindIndexRequest.Unmarshal(m, b)
+}
+func (m *FindIndexRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FindIndexRequest.Marshal(b, m, deterministic)
+}
+func (m *FindIndexRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FindIndexRequest.Merge(m, src)
+}
+func (m *FindIndexRequest) XXX_Size() int {
+	return xxx_messageInfo_FindIndexRequest.Size(m)
+}
+func (m *FindIndexRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FindIndexRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FindIndexRequest proto.InternalMessageInfo
+
+func (m *FindIndexRequest) GetCertificate() *NodeCertificate {
+	if m != nil {
+		return m.Certificate
+	}
+	return nil
+}
+
+func (m *FindIndexRequest) GetDoogleAddress() []byte {
+	if m != nil {
+		return m.DoogleAddress
+	}
+	return nil
+}
+
+func (m *FindIndexRequest) GetFilter() *Filter {
+	if m != nil {
+		return m.Filter
+	}
+	return nil
+}
+
+type FindIndexReply struct {
+	// Types that are valid to be assigned to Result:
+	//	*FindIndexReply_NodeInfos
+	//	*FindIndexReply_Items
+	Result               isFindIndexReply_Result `protobuf_oneof:"result"`
+	Cached               bool                    `protobuf:"varint,3,opt,name=cached,proto3" json:"cached,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *FindIndexReply) Reset()         { *m = FindIndexReply{} }
+func (m *FindIndexReply) String() string { return proto.CompactTextString(m) }
+func (*FindIndexReply) ProtoMessage()    {}
+func (*FindIndexReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_947ca98c6f36e503, []int{29}
+}
+
+func (m *FindIndexReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FindIndexReply.Unmarshal(m, b)
+}
+func (m *FindIndexReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FindIndexReply.Marshal(b, m, deterministic)
+}
+func (m *FindIndexReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FindIndexReply.Merge(m, src)
+}
+func (m *FindIndexReply) XXX_Size() int {
+	return xxx_messageInfo_FindIndexReply.Size(m)
+}
+func (m *FindIndexReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_FindIndexReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FindIndexReply proto.InternalMessageInfo
+
+type isFindIndexReply_Result interface {
+	isFindIndexReply_Result()
+}
+
+type FindIndexReply_NodeInfos struct {
+	NodeInfos *NodeInfos `protobuf:"bytes,1,opt,name=nodeInfos,proto3,oneof"`
+}
+
+type FindIndexReply_Items struct {
+	Items *Items `protobuf:"bytes,2,opt,name=items,proto3,oneof"`
+}
+
+func (*FindIndexReply_NodeInfos) isFindIndexReply_Result() {}
+
+func (*FindIndexReply_Items) isFindIndexReply_Result() {}
+
+func (m *FindIndexReply) GetResult() isFindIndexReply_Result {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+func (m *FindIndexReply) GetNodeInfos() *NodeInfos {
+	if x, ok := m.GetResult().(*FindIndexReply_NodeInfos); ok {
+		return x.NodeInfos
+	}
+	return nil
+}
+
+func (m *FindIndexReply) GetItems() *Items {
+	if x, ok := m.GetResult().(*FindIndexReply_Items); ok {
+		return x.Items
+	}
+	return nil
+}
+
+func (m *FindIndexReply) GetCached() bool {
+	if m != nil {
+		return m.Cached
+	}
+	return false
+}
+
+// XXX_OneofFuncs is for the internal use of the proto package.
+func (*FindIndexReply) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
+	return _FindIndexReply_OneofMarshaler, _FindIndexReply_OneofUnmarshaler, _FindIndexReply_OneofSizer, []interface{}{
+		(*FindIndexReply_NodeInfos)(nil),
+		(*FindIndexReply_Items)(nil),
+	}
+}
+
+func _FindIndexReply_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
+	m := msg.(*FindIndexReply)
+	// result
+	switch x := m.Result.(type) {
+	case *FindIndexReply_NodeInfos:
+		b.EncodeVarint(1<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.NodeInfos); err != nil {
+			return err
+		}
+	case *FindIndexReply_Items:
+		b.EncodeVarint(2<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Items); err != nil {
+			return err
+		}
+	case nil:
+	default:
+		return fmt.Errorf("FindIndexReply.Result has unexpected type %T", x)
+	}
+	return nil
+}
+
+func _FindIndexReply_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error) {
+	m := msg.(*FindIndexReply)
+	switch tag {
+	case 1: // result.nodeInfos
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(NodeInfos)
+		err := b.DecodeMessage(msg)
+		m.Result = &FindIndexReply_NodeInfos{msg}
+		return true, err
+	case 2: // result.items
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(Items)
+		err := b.DecodeMessage(msg)
+		m.Result = &FindIndexReply_Items{msg}
+		return true, err
+	default:
+		return false, nil
+	}
+}
+
+func _FindIndexReply_OneofSizer(msg proto.Message) (n int) {
+	m := msg.(*FindIndexReply)
+	// result
+	switch x := m.Result.(type) {
+	case *FindIndexReply_NodeInfos:
+		s := proto.Size(x.NodeInfos)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *FindIndexReply_Items:
+		s := proto.Size(x.Items)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case nil:
+	default:
+		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+	}
+	return n
+}
+
+type CacheIndexRequest struct {
+	Certificate          *NodeCertificate `protobuf:"bytes,1,opt,name=certificate,proto3" json:"certificate,omitempty"`
+	DoogleAddress        []byte           `protobuf:"bytes,2,opt,name=doogleAddress,proto3" json:"doogleAddress,omitempty"`
+	Items                []*Item          `protobuf:"bytes,3,rep,name=items,proto3" json:"items,omitempty"`
+	TtlMillis            int64            `protobuf:"varint,4,opt,name=ttlMillis,proto3" json:"ttlMillis,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *CacheIndexRequest) Reset()         { *m = CacheIndexRequest{} }
+func (m *CacheIndexRequest) String() string { return proto.CompactTextString(m) }
+func (*CacheIndexRequest) ProtoMessage()    {}
+func (*CacheIndexRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_947ca98c6f36e503, []int{30}
+}
+
+func (m *CacheIndexRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CacheIndexRequest.Unmarshal(m, b)
+}
+func (m *CacheIndexRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CacheIndexRequest.Marshal(b, m, deterministic)
+}
+func (m *CacheIndexRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CacheIndexRequest.Merge(m, src)
+}
+func (m *CacheIndexRequest) XXX_Size() int {
+	return xxx_messageInfo_CacheIndexRequest.Size(m)
+}
+func (m *CacheIndexRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CacheIndexRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CacheIndexRequest proto.InternalMessageInfo
+
+func (m *CacheIndexRequest) GetCertificate() *NodeCertificate {
+	if m != nil {
+		return m.Certificate
+	}
+	return nil
+}
+
+func (m *CacheIndexRequest) GetDoogleAddress() []byte {
+	if m != nil {
+		return m.DoogleAddress
+	}
+	return nil
+}
+
+func (m *CacheIndexRequest) GetItems() []*Item {
+	if m != nil {
+		return m.Items
+	}
+	return nil
+}
+
+func (m *CacheIndexRequest) GetTtlMillis() int64 {
+	if m != nil {
+		return m.TtlMillis
+	}
+	return 0
+}
+
+type FindNodeRequest struct {
+	Certificate          *NodeCertificate `protobuf:"bytes,1,opt,name=certificate,proto3" json:"certificate,omitempty"`
+	DoogleAddress        []byte           `protobuf:"bytes,2,opt,name=doogleAddress,proto3" json:"doogleAddress,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *FindNodeRequest) Reset()         { *m = FindNodeRequest{} }
+func (m *FindNodeRequest) String() string { return proto.CompactTextString(m) }
+func (*FindNodeRequest) ProtoMessage()    {}
+func (*FindNodeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_947ca98c6f36e503, []int{31}
+}
+
+func (m *FindNodeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FindNodeRequest.Unmarshal(m, b)
+}
+func (m *FindNodeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FindNodeRequest.Marshal(b, m, deterministic)
+}
+func (m *FindNodeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FindNodeRequest.Merge(m, src)
+}
+func (m *FindNodeRequest) XXX_Size() int {
+	return xxx_messageInfo_FindNodeRequest.Size(m)
+}
+func (m *FindNodeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FindNodeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FindNodeRequest proto.InternalMessageInfo
+
+func (m *FindNodeRequest) GetCertificate() *NodeCertificate {
+	if m != nil {
+		return m.Certificate
+	}
+	return nil
+}
+
+func (m *FindNodeRequest) GetDoogleAddress() []byte {
+	if m != nil {
+		return m.DoogleAddress
+	}
+	return nil
+}
+
+type GetIndexReply struct {
+	Items                []*Item      `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	NumSimilarHidden     int32        `protobuf:"varint,2,opt,name=numSimilarHidden,proto3" json:"numSimilarHidden,omitempty"`
+	Suggestions          []string     `protobuf:"bytes,3,rep,name=suggestions,proto3" json:"suggestions,omitempty"`
+	Stats                *SearchStats `protobuf:"bytes,4,opt,name=stats,proto3" json:"stats,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *GetIndexReply) Reset()         { *m = GetIndexReply{} }
+func (m *GetIndexReply) String() string { return proto.CompactTextString(m) }
+func (*GetIndexReply) ProtoMessage()    {}
+func (*GetIndexReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_947ca98c6f36e503, []int{32}
+}
+
+func (m *GetIndexReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetIndexReply.Unmarshal(m, b)
+}
+func (m *GetIndexReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetIndexReply.Marshal(b, m, deterministic)
+}
+func (m *GetIndexReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetIndexReply.Merge(m, src)
+}
+func (m *GetIndexReply) XXX_Size() int {
+	return xxx_messageInfo_GetIndexReply.Size(m)
+}
+func (m *GetIndexReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetIndexReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetIndexReply proto.InternalMessageInfo
+
+func (m *GetIndexReply) GetItems() []*Item {
+	if m != nil {
+		return m.Items
+	}
+	return nil
+}
+
+func (m *GetIndexReply) GetNumSimilarHidden() int32 {
+	if m != nil {
+		return m.NumSimilarHidden
+	}
+	return 0
+}
+
+func (m *GetIndexReply) GetSuggestions() []string {
+	if m != nil {
+		return m.Suggestions
+	}
+	return nil
+}
+
+func (m *GetIndexReply) GetStats() *SearchStats {
+	if m != nil {
+		return m.Stats
+	}
+	return nil
+}
+
+// numbers of remote nodes asked for the query, by outcome
+type SearchStats struct {
+	NumAnswered          int32    `protobuf:"varint,1,opt,name=numAnswered,proto3" json:"numAnswered,omitempty"`
+	NumFailed            int32    `protobuf:"varint,2,opt,name=numFailed,proto3" json:"numFailed,omitempty"`
+	NumTimedOut          int32    `protobuf:"varint,3,opt,name=numTimedOut,proto3" json:"numTimedOut,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SearchStats) Reset()         { *m = SearchStats{} }
+func (m *SearchStats) String() string { return proto.CompactTextString(m) }
+func (*SearchStats) ProtoMessage()    {}
+func (*SearchStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_947ca98c6f36e503, []int{33}
+}
+
+func (m *SearchStats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SearchStats.Unmarshal(m, b)
+}
+func (m *SearchStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SearchStats.Marshal(b, m, deterministic)
+}
+func (m *SearchStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SearchStats.Merge(m, src)
+}
+func (m *SearchStats) XXX_Size() int {
+	return xxx_messageInfo_SearchStats.Size(m)
+}
+func (m *SearchStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_SearchStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SearchStats proto.InternalMessageInfo
+
+func (m *SearchStats) GetNumAnswered() int32 {
+	if m != nil {
+		return m.NumAnswered
+	}
+	return 0
+}
+
+func (m *SearchStats) GetNumFailed() int32 {
+	if m != nil {
+		return m.NumFailed
+	}
+	return 0
+}
+
+func (m *SearchStats) GetNumTimedOut() int32 {
+	if m != nil {
+		return m.NumTimedOut
+	}
+	return 0
+}
+
+type SearchStreamReply struct {
+	Items                []*Item        `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	Summary              *GetIndexReply `protobuf:"bytes,2,opt,name=summary,proto3" json:"summary,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *SearchStreamReply) Reset()         { *m = SearchStreamReply{} }
+func (m *SearchStreamReply) String() string { return proto.CompactTextString(m) }
+func (*SearchStreamReply) ProtoMessage()    {}
+func (*SearchStreamReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_947ca98c6f36e503, []int{34}
+}
+
+func (m *SearchStreamReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SearchStreamReply.Unmarshal(m, b)
+}
+func (m *SearchStreamReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SearchStreamReply.Marshal(b, m, deterministic)
+}
+func (m *SearchStreamReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SearchStreamReply.Merge(m, src)
+}
+func (m *SearchStreamReply) XXX_Size() int {
+	return xxx_messageInfo_SearchStreamReply.Size(m)
+}
+func (m *SearchStreamReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_SearchStreamReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SearchStreamReply proto.InternalMessageInfo
+
+func (m *SearchStreamReply) GetItems() []*Item {
+	if m != nil {
+		return m.Items
+	}
+	return nil
+}
+
+func (m *SearchStreamReply) GetSummary() *GetIndexReply {
+	if m != nil {
+		return m.Summary
+	}
+	return nil
+}
+
+type CacheStats struct {
+	Hits                 int64    `protobuf:"varint,1,opt,name=hits,proto3" json:"hits,omitempty"`
+	Misses               int64    `protobuf:"varint,2,opt,name=misses,proto3" json:"misses,omitempty"`
+	Size                 int32    `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CacheStats) Reset()         { *m = CacheStats{} }
+func (m *CacheStats) String() string { return proto.CompactTextString(m) }
+func (*CacheStats) ProtoMessage()    {}
+func (*CacheStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_947ca98c6f36e503, []int{35}
+}
+
+func (m *CacheStats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CacheStats.Unmarshal(m, b)
+}
+func (m *CacheStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CacheStats.Marshal(b, m, deterministic)
+}
+func (m *CacheStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CacheStats.Merge(m, src)
+}
+func (m *CacheStats) XXX_Size() int {
+	return xxx_messageInfo_CacheStats.Size(m)
+}
+func (m *CacheStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_CacheStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CacheStats proto.InternalMessageInfo
+
+func (m *CacheStats) GetHits() int64 {
+	if m != nil {
+		return m.Hits
+	}
+	return 0
+}
+
+func (m *CacheStats) GetMisses() int64 {
+	if m != nil {
+		return m.Misses
+	}
+	return 0
+}
+
+func (m *CacheStats) GetSize() int32 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+type RankStats struct {
+	NumDirtyItems        int32    `protobuf:"varint,1,opt,name=numDirtyItems,proto3" json:"numDirtyItems,omitempty"`
+	NumDirtyTerms        int32    `protobuf:"varint,2,opt,name=numDirtyTerms,proto3" json:"numDirtyTerms,omitempty"`
+	LastRunAt            int64    `protobuf:"varint,3,opt,name=lastRunAt,proto3" json:"lastRunAt,omitempty"`
+	LastRunNumItems      int32    `protobuf:"varint,4,opt,name=lastRunNumItems,proto3" json:"lastRunNumItems,omitempty"`
+	TermComputedAt       int64    `protobuf:"varint,5,opt,name=termComputedAt,proto3" json:"termComputedAt,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RankStats) Reset()         { *m = RankStats{} }
+func (m *RankStats) String() string { return proto.CompactTextString(m) }
+func (*RankStats) ProtoMessage()    {}
+func (*RankStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_947ca98c6f36e503, []int{36}
+}
+
+func (m *RankStats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RankStats.Unmarshal(m, b)
+}
+func (m *RankStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RankStats.Marshal(b, m, deterministic)
+}
+func (m *RankStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RankStats.Merge(m, src)
+}
+func (m *RankStats) XXX_Size() int {
+	return xxx_messageInfo_RankStats.Size(m)
+}
+func (m *RankStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_RankStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RankStats proto.InternalMessageInfo
+
+func (m *RankStats) GetNumDirtyItems() int32 {
+	if m != nil {
+		return m.NumDirtyItems
+	}
+	return 0
+}
+
+func (m *RankStats) GetNumDirtyTerms() int32 {
+	if m != nil {
+		return m.NumDirtyTerms
+	}
+	return 0
+}
+
+func (m *RankStats) GetLastRunAt() int64 {
+	if m != nil {
+		return m.LastRunAt
+	}
+	return 0
+}
+
+func (m *RankStats) GetLastRunNumItems() int32 {
+	if m != nil {
+		return m.LastRunNumItems
+	}
+	return 0
+}
+
+func (m *RankStats) GetTermComputedAt() int64 {
+	if m != nil {
+		return m.TermComputedAt
+	}
+	return 0
+}
+
+type RankRecord struct {
+	ComputedAt           int64    `protobuf:"varint,1,opt,name=computedAt,proto3" json:"computedAt,omitempty"`
+	Rank                 float64  `protobuf:"fixed64,2,opt,name=rank,proto3" json:"rank,omitempty"`
+	LocalRank            float64  `protobuf:"fixed64,3,opt,name=localRank,proto3" json:"localRank,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RankRecord) Reset()         { *m = RankRecord{} }
+func (m *RankRecord) String() string { return proto.CompactTextString(m) }
+func (*RankRecord) ProtoMessage()    {}
+func (*RankRecord) Descriptor() ([]byte, []int) {
+	return fileDescriptor_947ca98c6f36e503, []int{37}
+}
+
+func (m *RankRecord) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RankRecord.Unmarshal(m, b)
+}
+func (m *RankRecord) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RankRecord.Marshal(b, m, deterministic)
+}
+func (m *RankRecord) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RankRecord.Merge(m, src)
+}
+func (m *RankRecord) XXX_Size() int {
+	return xxx_messageInfo_RankRecord.Size(m)
+}
+func (m *RankRecord) XXX_DiscardUnknown() {
+	xxx_messageInfo_RankRecord.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RankRecord proto.InternalMessageInfo
+
+func (m *RankRecord) GetComputedAt() int64 {
+	if m != nil {
+		return m.ComputedAt
+	}
+	return 0
+}
+
+func (m *RankRecord) GetRank() float64 {
+	if m != nil {
+		return m.Rank
+	}
+	return 0
+}
+
+func (m *RankRecord) GetLocalRank() float64 {
+	if m != nil {
+		return m.LocalRank
+	}
+	return 0
+}
+
+type RankHistory struct {
+	LocalRank            float64       `protobuf:"fixed64,1,opt,name=localRank,proto3" json:"localRank,omitempty"`
+	Prior                float64       `protobuf:"fixed64,2,opt,name=prior,proto3" json:"prior,omitempty"`
+	Records              []*RankRecord `protobuf:"bytes,3,rep,name=records,proto3" json:"records,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *RankHistory) Reset()         { *m = RankHistory{} }
+func (m *RankHistory) String() string { return proto.CompactTextString(m) }
+func (*RankHistory) ProtoMessage()    {}
+func (*RankHistory) Descriptor() ([]byte, []int) {
+	return fileDescriptor_947ca98c6f36e503, []int{38}
+}
+
+func (m *RankHistory) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RankHistory.Unmarshal(m, b)
+}
+func (m *RankHistory) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RankHistory.Marshal(b, m, deterministic)
+}
+func (m *RankHistory) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RankHistory.Merge(m, src)
+}
+func (m *RankHistory) XXX_Size() int {
+	return xxx_messageInfo_RankHistory.Size(m)
+}
+func (m *RankHistory) XXX_DiscardUnknown() {
+	xxx_messageInfo_RankHistory.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RankHistory proto.InternalMessageInfo
+
+func (m *RankHistory) GetLocalRank() float64 {
+	if m != nil {
+		return m.LocalRank
+	}
+	return 0
+}
+
+func (m *RankHistory) GetPrior() float64 {
+	if m != nil {
+		return m.Prior
+	}
+	return 0
+}
+
+func (m *RankHistory) GetRecords() []*RankRecord {
+	if m != nil {
+		return m.Records
+	}
+	return nil
+}
+
+type FindRankNeighboursRequest struct {
+	Certificate          *NodeCertificate `protobuf:"bytes,1,opt,name=certificate,proto3" json:"certificate,omitempty"`
+	DoogleAddress        []byte           `protobuf:"bytes,2,opt,name=doogleAddress,proto3" json:"doogleAddress,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *FindRankNeighboursRequest) Reset()         { *m = FindRankNeighboursRequest{} }
+func (m *FindRankNeighboursRequest) String() string { return proto.CompactTextString(m) }
+func (*FindRankNeighboursRequest) ProtoMessage()    {}
+func (*FindRankNeighboursRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_947ca98c6f36e503, []int{39}
+}
+
+func (m *FindRankNeighboursRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FindRankNeighboursRequest.Unmarshal(m, b)
+}
+func (m *FindRankNeighboursRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FindRankNeighboursRequest.Marshal(b, m, deterministic)
+}
+func (m *FindRankNeighboursRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FindRankNeighboursRequest.Merge(m, src)
+}
+func (m *FindRankNeighboursRequest) XXX_Size() int {
+	return xxx_messageInfo_FindRankNeighboursRequest.Size(m)
+}
+func (m *FindRankNeighboursRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FindRankNeighboursRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FindRankNeighboursRequest proto.InternalMessageInfo
+
+func (m *FindRankNeighboursRequest) GetCertificate() *NodeCertificate {
+	if m != nil {
+		return m.Certificate
+	}
+	return nil
+}
+
+func (m *FindRankNeighboursRequest) GetDoogleAddress() []byte {
+	if m != nil {
+		return m.DoogleAddress
+	}
+	return nil
+}
+
+// page linking to the explained one
+type RankNeighbour struct {
+	Url                  string   `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	DoogleAddress        []byte   `protobuf:"bytes,2,opt,name=doogleAddress,proto3" json:"doogleAddress,omitempty"`
+	Score                float64  `protobuf:"fixed64,3,opt,name=score,proto3" json:"score,omitempty"`
+	OutDegree            int32    `protobuf:"varint,4,opt,name=outDegree,proto3" json:"outDegree,omitempty"`
+	Contribution         float64  `protobuf:"fixed64,5,opt,name=contribution,proto3" json:"contribution,omitempty"`
+	World                bool     `protobuf:"varint,6,opt,name=world,proto3" json:"world,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RankNeighbour) Reset()         { *m = RankNeighbour{} }
+func (m *RankNeighbour) String() string { return proto.CompactTextString(m) }
+func (*RankNeighbour) ProtoMessage()    {}
+func (*RankNeighbour) Descriptor() ([]byte, []int) {
+	return fileDescriptor_947ca98c6f36e503, []int{40}
+}
+
+func (m *RankNeighbour) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RankNeighbour.Unmarshal(m, b)
+}
+func (m *RankNeighbour) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RankNeighbour.Marshal(b, m, deterministic)
+}
+func (m *RankNeighbour) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RankNeighbour.Merge(m, src)
+}
+func (m *RankNeighbour) XXX_Size() int {
+	return xxx_messageInfo_RankNeighbour.Size(m)
+}
+func (m *RankNeighbour) XXX_DiscardUnknown() {
+	xxx_messageInfo_RankNeighbour.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RankNeighbour proto.InternalMessageInfo
+
+func (m *RankNeighbour) GetUrl() string {
+	if m != nil {
+		return m.Url
+	}
+	return ""
+}
+
+func (m *RankNeighbour) GetDoogleAddress() []byte {
+	if m != nil {
+		return m.DoogleAddress
+	}
+	return nil
+}
+
+func (m *RankNeighbour) GetScore() float64 {
+	if m != nil {
+		return m.Score
+	}
+	return 0
+}
+
+func (m *RankNeighbour) GetOutDegree() int32 {
+	if m != nil {
+		return m.OutDegree
+	}
+	return 0
+}
+
+func (m *RankNeighbour) GetContribution() float64 {
+	if m != nil {
+		return m.Contribution
+	}
+	return 0
+}
+
+func (m *RankNeighbour) GetWorld() bool {
+	if m != nil {
+		return m.World
+	}
+	return false
+}
+
+type RankNeighbours struct {
+	Neighbours           []*RankNeighbour `protobuf:"bytes,1,rep,name=neighbours,proto3" json:"neighbours,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *RankNeighbours) Reset()         { *m = RankNeighbours{} }
+func (m *RankNeighbours) String() string { return proto.CompactTextString(m) }
+func (*RankNeighbours) ProtoMessage()    {}
+func (*RankNeighbours) Descriptor() ([]byte, []int) {
+	return fileDescriptor_947ca98c6f36e503, []int{41}
+}
+
+func (m *RankNeighbours) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RankNeighbours.Unmarshal(m, b)
+}
+func (m *RankNeighbours) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RankNeighbours.Marshal(b, m, deterministic)
+}
+func (m *RankNeighbours) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RankNeighbours.Merge(m, src)
+}
+func (m *RankNeighbours) XXX_Size() int {
+	return xxx_messageInfo_RankNeighbours.Size(m)
+}
+func (m *RankNeighbours) XXX_DiscardUnknown() {
+	xxx_messageInfo_RankNeighbours.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RankNeighbours proto.InternalMessageInfo
+
+func (m *RankNeighbours) GetNeighbours() []*RankNeighbour {
+	if m != nil {
+		return m.Neighbours
+	}
+	return nil
+}
+
+// the item reported by a node
+type NodeReport struct {
+	NetworkAddress       string           `protobuf:"bytes,1,opt,name=networkAddress,proto3" json:"networkAddress,omitempty"`
+	LocalRank            float64          `protobuf:"fixed64,2,opt,name=localRank,proto3" json:"localRank,omitempty"`
+	NormalizedScore      float64          `protobuf:"fixed64,3,opt,name=normalizedScore,proto3" json:"normalizedScore,omitempty"`
+	Outlier              bool             `protobuf:"varint,4,opt,name=outlier,proto3" json:"outlier,omitempty"`
+	Neighbours           []*RankNeighbour `protobuf:"bytes,5,rep,name=neighbours,proto3" json:"neighbours,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *NodeReport) Reset()         { *m = NodeReport{} }
+func (m *NodeReport) String() string { return proto.CompactTextString(m) }
+func (*NodeReport) ProtoMessage()    {}
+func (*NodeReport) Descriptor() ([]byte, []int) {
+	return fileDescriptor_947ca98c6f36e503, []int{42}
+}
+
+func (m *NodeReport) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NodeReport.Unmarshal(m, b)
+}
+func (m *NodeReport) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NodeReport.Marshal(b, m, deterministic)
+}
+func (m *NodeReport) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NodeReport.Merge(m, src)
+}
+func (m *NodeReport) XXX_Size() int {
+	return xxx_messageInfo_NodeReport.Size(m)
+}
+func (m *NodeReport) XXX_DiscardUnknown() {
+	xxx_messageInfo_NodeReport.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NodeReport proto.InternalMessageInfo
+
+func (m *NodeReport) GetNetworkAddress() string {
+	if m != nil {
+		return m.NetworkAddress
+	}
+	return ""
+}
+
+func (m *NodeReport) GetLocalRank() float64 {
+	if m != nil {
+		return m.LocalRank
+	}
+	return 0
+}
+
+func (m *NodeReport) GetNormalizedScore() float64 {
+	if m != nil {
+		return m.NormalizedScore
+	}
+	return 0
+}
+
+func (m *NodeReport) GetOutlier() bool {
+	if m != nil {
+		return m.Outlier
+	}
+	return false
+}
+
+func (m *NodeReport) GetNeighbours() []*RankNeighbour {
+	if m != nil {
+		return m.Neighbours
+	}
+	return nil
+}
+
+type ExplainRequest struct {
+	Query                string   `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	Url                  string   `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExplainRequest) Reset()         { *m = ExplainRequest{} }
+func (m *ExplainRequest) String() string { return proto.CompactTextString(m) }
+func (*ExplainRequest) ProtoMessage()    {}
+func (*ExplainRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_947ca98c6f36e503, []int{43}
+}
+
+func (m *ExplainRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExplainRequest.Unmarshal(m, b)
+}
+func (m *ExplainRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExplainRequest.Marshal(b, m, deterministic)
+}
+func (m *ExplainRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExplainRequest.Merge(m, src)
+}
+func (m *ExplainRequest) XXX_Size() int {
+	return xxx_messageInfo_ExplainRequest.Size(m)
+}
+func (m *ExplainRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExplainRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExplainRequest proto.InternalMessageInfo
+
+func (m *ExplainRequest) GetQuery() string {
+	if m != nil {
+		return m.Query
+	}
+	return ""
+}
+
+func (m *ExplainRequest) GetUrl() string {
+	if m != nil {
+		return m.Url
+	}
+	return ""
+}
+
+type Explanation struct {
+	Url                  string        `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Found                bool          `protobuf:"varint,2,opt,name=found,proto3" json:"found,omitempty"`
+	Position             int32         `protobuf:"varint,3,opt,name=position,proto3" json:"position,omitempty"`
+	Reports              []*NodeReport `protobuf:"bytes,4,rep,name=reports,proto3" json:"reports,omitempty"`
+	Median               float64       `protobuf:"fixed64,5,opt,name=median,proto3" json:"median,omitempty"`
+	AggregatedScore      float64       `protobuf:"fixed64,6,opt,name=aggregatedScore,proto3" json:"aggregatedScore,omitempty"`
+	Fields               []Field       `protobuf:"varint,7,rep,packed,name=fields,proto3,enum=doogle.Field" json:"fields,omitempty"`
+	FieldBoost           float64       `protobuf:"fixed64,8,opt,name=fieldBoost,proto3" json:"fieldBoost,omitempty"`
+	Score                float64       `protobuf:"fixed64,9,opt,name=score,proto3" json:"score,omitempty"`
+	Stats                *SearchStats  `protobuf:"bytes,10,opt,name=stats,proto3" json:"stats,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *Explanation) Reset()         { *m = Explanation{} }
+func (m *Explanation) String() string { return proto.CompactTextString(m) }
+func (*Explanation) ProtoMessage()    {}
+func (*Explanation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_947ca98c6f36e503, []int{44}
+}
+
+func (m *Explanation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Explanation.Unmarshal(m, b)
+}
+func (m *Explanation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Explanation.Marshal(b, m, deterministic)
+}
+func (m *Explanation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Explanation.Merge(m, src)
+}
+func (m *Explanation) XXX_Size() int {
+	return xxx_messageInfo_Explanation.Size(m)
+}
+func (m *Explanation) XXX_DiscardUnknown() {
+	xxx_messageInfo_Explanation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Explanation proto.InternalMessageInfo
+
+func (m *Explanation) GetUrl() string {
+	if m != nil {
+		return m.Url
+	}
+	return ""
+}
+
+func (m *Explanation) GetFound() bool {
+	if m != nil {
+		return m.Found
+	}
+	return false
+}
+
+func (m *Explanation) GetPosition() int32 {
+	if m != nil {
+		return m.Position
+	}
+	return 0
+}
+
+func (m *Explanation) GetReports() []*NodeReport {
+	if m != nil {
+		return m.Reports
+	}
+	return nil
+}
+
+func (m *Explanation) GetMedian() float64 {
+	if m != nil {
+		return m.Median
+	}
+	return 0
+}
+
+func (m *Explanation) GetAggregatedScore() float64 {
+	if m != nil {
+		return m.AggregatedScore
+	}
+	return 0
+}
+
+func (m *Explanation) GetFields() []Field {
+	if m != nil {
+		return m.Fields
+	}
+	return nil
+}
+
+func (m *Explanation) GetFieldBoost() float64 {
+	if m != nil {
+		return m.FieldBoost
+	}
+	return 0
+}
+
+func (m *Explanation) GetScore() float64 {
+	if m != nil {
+		return m.Score
+	}
+	return 0
+}
+
+func (m *Explanation) GetStats() *SearchStats {
+	if m != nil {
+		return m.Stats
+	}
+	return nil
+}
+
+type Spelling struct {
+	Term                 string   `protobuf:"bytes,1,opt,name=term,proto3" json:"term,omitempty"`
+	DocFreq              int64    `protobuf:"varint,2,opt,name=docFreq,proto3" json:"docFreq,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Spelling) Reset()         { *m = Spelling{} }
+func (m *Spelling) String() string { return proto.CompactTextString(m) }
+func (*Spelling) ProtoMessage()    {}
+func (*Spelling) Descriptor() ([]byte, []int) {
+	return fileDescriptor_947ca98c6f36e503, []int{45}
+}
+
+func (m *Spelling) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Spelling.Unmarshal(m, b)
+}
+func (m *Spelling) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Spelling.Marshal(b, m, deterministic)
+}
+func (m *Spelling) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Spelling.Merge(m, src)
+}
+func (m *Spelling) XXX_Size() int {
+	return xxx_messageInfo_Spelling.Size(m)
+}
+func (m *Spelling) XXX_DiscardUnknown() {
+	xxx_messageInfo_Spelling.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Spelling proto.InternalMessageInfo
+
+func (m *Spelling) GetTerm() string {
+	if m != nil {
+		return m.Term
+	}
+	return ""
+}
+
+func (m *Spelling) GetDocFreq() int64 {
+	if m != nil {
+		return m.DocFreq
+	}
+	return 0
+}
+
+type Spellings struct {
+	Spellings            []*Spelling `protobuf:"bytes,1,rep,name=spellings,proto3" json:"spellings,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *Spellings) Reset()         { *m = Spellings{} }
+func (m *Spellings) String() string { return proto.CompactTextString(m) }
+func (*Spellings) ProtoMessage()    {}
+func (*Spellings) Descriptor() ([]byte, []int) {
+	return fileDescriptor_947ca98c6f36e503, []int{46}
+}
+
+func (m *Spellings) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Spellings.Unmarshal(m, b)
+}
+func (m *Spellings) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Spellings.Marshal(b, m, deterministic)
+}
+func (m *Spellings) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Spellings.Merge(m, src)
+}
+func (m *Spellings) XXX_Size() int {
+	return xxx_messageInfo_Spellings.Size(m)
+}
+func (m *Spellings) XXX_DiscardUnknown() {
+	xxx_messageInfo_Spellings.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Spellings proto.InternalMessageInfo
+
+func (m *Spellings) GetSpellings() []*Spelling {
+	if m != nil {
+		return m.Spellings
+	}
+	return nil
+}
+
+type StoreSpellingRequest struct {
+	Certificate          *NodeCertificate `protobuf:"bytes,1,opt,name=certificate,proto3" json:"certificate,omitempty"`
+	DoogleAddress        []byte           `protobuf:"bytes,2,opt,name=doogleAddress,proto3" json:"doogleAddress,omitempty"`
+	Spelling             *Spelling        `protobuf:"bytes,3,opt,name=spelling,proto3" json:"spelling,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *StoreSpellingRequest) Reset()         { *m = StoreSpellingRequest{} }
+func (m *StoreSpellingRequest) String() string { return proto.CompactTextString(m) }
+func (*StoreSpellingRequest) ProtoMessage()    {}
+func (*StoreSpellingRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_947ca98c6f36e503, []int{47}
+}
+
+func (m *StoreSpellingRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StoreSpellingRequest.Unmarshal(m, b)
+}
+func (m *StoreSpellingRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StoreSpellingRequest.Marshal(b, m, deterministic)
+}
+func (m *StoreSpellingRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StoreSpellingRequest.Merge(m, src)
+}
+func (m *StoreSpellingRequest) XXX_Size() int {
+	return xxx_messageInfo_StoreSpellingRequest.Size(m)
+}
+func (m *StoreSpellingRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StoreSpellingRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StoreSpellingRequest proto.InternalMessageInfo
+
+func (m *StoreSpellingRequest) GetCertificate() *NodeCertificate {
+	if m != nil {
+		return m.Certificate
+	}
+	return nil
+}
+
+func (m *StoreSpellingRequest) GetDoogleAddress() []byte {
+	if m != nil {
+		return m.DoogleAddress
+	}
+	return nil
+}
+
+func (m *StoreSpellingRequest) GetSpelling() *Spelling {
+	if m != nil {
+		return m.Spelling
+	}
+	return nil
+}
+
+type FindSpellingRequest struct {
+	Certificate          *NodeCertificate `protobuf:"bytes,1,opt,name=certificate,proto3" json:"certificate,omitempty"`
+	DoogleAddress        []byte           `protobuf:"bytes,2,opt,name=doogleAddress,proto3" json:"doogleAddress,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *FindSpellingRequest) Reset()         { *m = FindSpellingRequest{} }
+func (m *FindSpellingRequest) String() string { return proto.CompactTextString(m) }
+func (*FindSpellingRequest) ProtoMessage()    {}
+func (*FindSpellingRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_947ca98c6f36e503, []int{48}
+}
+
+func (m *FindSpellingRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FindSpellingRequest.Unmarshal(m, b)
+}
+func (m *FindSpellingRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FindSpellingRequest.Marshal(b, m, deterministic)
+}
+func (m *FindSpellingRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FindSpellingRequest.Merge(m, src)
+}
+func (m *FindSpellingRequest) XXX_Size() int {
+	return xxx_messageInfo_FindSpellingRequest.Size(m)
+}
+func (m *FindSpellingRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FindSpellingRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FindSpellingRequest proto.InternalMessageInfo
+
+func (m *FindSpellingRequest) GetCertificate() *NodeCertificate {
+	if m != nil {
+		return m.Certificate
+	}
+	return nil
+}
+
+func (m *FindSpellingRequest) GetDoogleAddress() []byte {
+	if m != nil {
+		return m.DoogleAddress
+	}
+	return nil
+}
+
+type Completion struct {
+	Term                 string   `protobuf:"bytes,1,opt,name=term,proto3" json:"term,omitempty"`
+	Score                float64  `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Completion) Reset()         { *m = Completion{} }
+func (m *Completion) String() string { return proto.CompactTextString(m) }
+func (*Completion) ProtoMessage()    {}
+func (*Completion) Descriptor() ([]byte, []int) {
+	return fileDescriptor_947ca98c6f36e503, []int{49}
+}
+
+func (m *Completion) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Completion.Unmarshal(m, b)
+}
+func (m *Completion) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Completion.Marshal(b, m, deterministic)
+}
+func (m *Completion) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Completion.Merge(m, src)
+}
+func (m *Completion) XXX_Size() int {
+	return xxx_messageInfo_Completion.Size(m)
+}
+func (m *Completion) XXX_DiscardUnknown() {
+	xxx_messageInfo_Completion.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Completion proto.InternalMessageInfo
+
+func (m *Completion) GetTerm() string {
+	if m != nil {
+		return m.Term
+	}
+	return ""
+}
+
+func (m *Completion) GetScore() float64 {
+	if m != nil {
+		return m.Score
+	}
+	return 0
+}
+
+type Completions struct {
+	Completions          []*Completion `protobuf:"bytes,1,rep,name=completions,proto3" json:"completions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *Completions) Reset()         { *m = Completions{} }
+func (m *Completions) String() string { return proto.CompactTextString(m) }
+func (*Completions) ProtoMessage()    {}
+func (*Completions) Descriptor() ([]byte, []int) {
+	return fileDescriptor_947ca98c6f36e503, []int{50}
+}
+
+func (m *Completions) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Completions.Unmarshal(m, b)
+}
+func (m *Completions) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Completions.Marshal(b, m, deterministic)
+}
+func (m *Completions) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Completions.Merge(m, src)
+}
+func (m *Completions) XXX_Size() int {
+	return xxx_messageInfo_Completions.Size(m)
+}
+func (m *Completions) XXX_DiscardUnknown() {
+	xxx_messageInfo_Completions.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Completions proto.InternalMessageInfo
+
+func (m *Completions) GetCompletions() []*Completion {
+	if m != nil {
+		return m.Completions
+	}
+	return nil
+}
+
+type StoreCompletionRequest struct {
+	Certificate          *NodeCertificate `protobuf:"bytes,1,opt,name=certificate,proto3" json:"certificate,omitempty"`
+	DoogleAddress        []byte           `protobuf:"bytes,2,opt,name=doogleAddress,proto3" json:"doogleAddress,omitempty"`
+	Completion           *Completion      `protobuf:"bytes,3,opt,name=completion,proto3" json:"completion,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *StoreCompletionRequest) Reset()         { *m = StoreCompletionRequest{} }
+func (m *StoreCompletionRequest) String() string { return proto.CompactTextString(m) }
+func (*StoreCompletionRequest) ProtoMessage()    {}
+func (*StoreCompletionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_947ca98c6f36e503, []int{51}
+}
+
+func (m *StoreCompletionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StoreCompletionRequest.Unmarshal(m, b)
+}
+func (m *StoreCompletionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StoreCompletionRequest.Marshal(b, m, deterministic)
+}
+func (m *StoreCompletionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StoreCompletionRequest.Merge(m, src)
+}
+func (m *StoreCompletionRequest) XXX_Size() int {
+	return xxx_messageInfo_StoreCompletionRequest.Size(m)
+}
+func (m *StoreCompletionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StoreCompletionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StoreCompletionRequest proto.InternalMessageInfo
+
+func (m *StoreCompletionRequest) GetCertificate() *NodeCertificate {
+	if m != nil {
+		return m.Certificate
+	}
+	return nil
+}
+
+func (m *StoreCompletionRequest) GetDoogleAddress() []byte {
+	if m != nil {
+		return m.DoogleAddress
+	}
+	return nil
+}
+
+func (m *StoreCompletionRequest) GetCompletion() *Completion {
+	if m != nil {
+		return m.Completion
+	}
+	return nil
+}
+
+type FindCompletionRequest struct {
+	Certificate          *NodeCertificate `protobuf:"bytes,1,opt,name=certificate,proto3" json:"certificate,omitempty"`
+	DoogleAddress        []byte           `protobuf:"bytes,2,opt,name=doogleAddress,proto3" json:"doogleAddress,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *FindCompletionRequest) Reset()         { *m = FindCompletionRequest{} }
+func (m *FindCompletionRequest) String() string { return proto.CompactTextString(m) }
+func (*FindCompletionRequest) ProtoMessage()    {}
+func (*FindCompletionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_947ca98c6f36e503, []int{52}
+}
+
+func (m *FindCompletionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FindCompletionRequest.Unmarshal(m, b)
+}
+func (m *FindCompletionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FindCompletionRequest.Marshal(b, m, deterministic)
+}
+func (m *FindCompletionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FindCompletionRequest.Merge(m, src)
+}
+func (m *FindCompletionRequest) XXX_Size() int {
+	return xxx_messageInfo_FindCompletionRequest.Size(m)
+}
+func (m *FindCompletionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FindCompletionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FindCompletionRequest proto.InternalMessageInfo
+
+func (m *FindCompletionRequest) GetCertificate() *NodeCertificate {
+	if m != nil {
+		return m.Certificate
+	}
+	return nil
+}
+
+func (m *FindCompletionRequest) GetDoogleAddress() []byte {
+	if m != nil {
+		return m.DoogleAddress
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterEnum("doogle.Field", Field_name, Field_value)
+	proto.RegisterEnum("doogle.GraphFormat", GraphFormat_name, GraphFormat_value)
+	proto.RegisterType((*Empty)(nil), "doogle.Empty")
+	proto.RegisterType((*StringMessage)(nil), "doogle.StringMessage")
+	proto.RegisterType((*NodeInfo)(nil), "doogle.NodeInfo")
+	proto.RegisterType((*NodeInfos)(nil), "doogle.NodeInfos")
+	proto.RegisterType((*NodeCertificate)(nil), "doogle.NodeCertificate")
+	proto.RegisterType((*ExportGraphRequest)(nil), "doogle.ExportGraphRequest")
+	proto.RegisterType((*GraphChunk)(nil), "doogle.GraphChunk")
+	proto.RegisterType((*StoreItemRequest)(nil), "doogle.StoreItemRequest")
+	proto.RegisterType((*Tombstone)(nil), "doogle.Tombstone")
+	proto.RegisterType((*DeleteItemRequest)(nil), "doogle.DeleteItemRequest")
+	proto.RegisterType((*Document)(nil), "doogle.Document")
+	proto.RegisterType((*Backlink)(nil), "doogle.Backlink")
+	proto.RegisterType((*Backlinks)(nil), "doogle.Backlinks")
+	proto.RegisterType((*StoreBacklinkRequest)(nil), "doogle.StoreBacklinkRequest")
+	proto.RegisterType((*FindBacklinksRequest)(nil), "doogle.FindBacklinksRequest")
+	proto.RegisterType((*FindCoLinksRequest)(nil), "doogle.FindCoLinksRequest")
+	proto.RegisterType((*CoLink)(nil), "doogle.CoLink")
+	proto.RegisterType((*CoLinks)(nil), "doogle.CoLinks")
+	proto.RegisterType((*RelatedPage)(nil), "doogle.RelatedPage")
+	proto.RegisterType((*RelatedPages)(nil), "doogle.RelatedPages")
+	proto.RegisterType((*PageScore)(nil), "doogle.PageScore")
+	proto.RegisterType((*MeetRequest)(nil), "doogle.MeetRequest")
+	proto.RegisterType((*MeetReply)(nil), "doogle.MeetReply")
+	proto.RegisterType((*StoreDocumentRequest)(nil), "doogle.StoreDocumentRequest")
+	proto.RegisterType((*FindDocumentRequest)(nil), "doogle.FindDocumentRequest")
+	proto.RegisterType((*Item)(nil), "doogle.Item")
+	proto.RegisterType((*Items)(nil), "doogle.Items")
+	proto.RegisterType((*Filter)(nil), "doogle.Filter")
+	proto.RegisterType((*FindIndexRequest)(nil), "doogle.FindIndexRequest")
+	proto.RegisterType((*FindIndexReply)(nil), "doogle.FindIndexReply")
+	proto.RegisterType((*CacheIndexRequest)(nil), "doogle.CacheIndexRequest")
+	proto.RegisterType((*FindNodeRequest)(nil), "doogle.FindNodeRequest")
+	proto.RegisterType((*GetIndexReply)(nil), "doogle.GetIndexReply")
+	proto.RegisterType((*SearchStats)(nil), "doogle.SearchStats")
+	proto.RegisterType((*SearchStreamReply)(nil), "doogle.SearchStreamReply")
+	proto.RegisterType((*CacheStats)(nil), "doogle.CacheStats")
+	proto.RegisterType((*RankStats)(nil), "doogle.RankStats")
+	proto.RegisterType((*RankRecord)(nil), "doogle.RankRecord")
+	proto.RegisterType((*RankHistory)(nil), "doogle.RankHistory")
+	proto.RegisterType((*FindRankNeighboursRequest)(nil), "doogle.FindRankNeighboursRequest")
+	proto.RegisterType((*RankNeighbour)(nil), "doogle.RankNeighbour")
+	proto.RegisterType((*RankNeighbours)(nil), "doogle.RankNeighbours")
+	proto.RegisterType((*NodeReport)(nil), "doogle.NodeReport")
+	proto.RegisterType((*ExplainRequest)(nil), "doogle.ExplainRequest")
+	proto.RegisterType((*Explanation)(nil), "doogle.Explanation")
+	proto.RegisterType((*Spelling)(nil), "doogle.Spelling")
+	proto.RegisterType((*Spellings)(nil), "doogle.Spellings")
+	proto.RegisterType((*StoreSpellingRequest)(nil), "doogle.StoreSpellingRequest")
+	proto.RegisterType((*FindSpellingRequest)(nil), "doogle.FindSpellingRequest")
+	proto.RegisterType((*Completion)(nil), "doogle.Completion")
+	proto.RegisterType((*Completions)(nil), "doogle.Completions")
+	proto.RegisterType((*StoreCompletionRequest)(nil), "doogle.StoreCompletionRequest")
+	proto.RegisterType((*FindCompletionRequest)(nil), "doogle.FindCompletionRequest")
+}
+
+func init() { proto.RegisterFile("doogle.proto", fileDescriptor_947ca98c6f36e503) }
+
+var fileDescriptor_947ca98c6f36e503 = []byte{
+	// 2456 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x3a, 0x4b, 0x73, 0x24, 0x47,
+	0xd1, 0xee, 0x79, 0x4f, 0x8e, 0x9e, 0xb5, 0x0f, 0xb7, 0xe7, 0xdb, 0x6f, 0x43, 0x74, 0xd8, 0x6b,
+	0x79, 0xbd, 0xb1, 0xb6, 0xb5, 0x0f, 0xd6, 0x60, 0x83, 0xf5, 0x96, 0x02, 0xed, 0x6a, 0xa3, 0x24,
+	0x07, 0x70, 0x81, 0x68, 0x4d, 0x97, 0x46, 0x15, 0xea, 0xc7, 0x6c, 0x77, 0x35, 0x5a, 0x39, 0xb8,
+	0x70, 0x81, 0x03, 0x04, 0x57, 0x0e, 0xe0, 0x23, 0x37, 0x38, 0x11, 0xf0, 0x17, 0x08, 0x0e, 0xf0,
+	0x0f, 0xf8, 0x2f, 0x44, 0x56, 0x75, 0x75, 0x57, 0xf7, 0xcc, 0x58, 0x5a, 0x50, 0xe8, 0x34, 0x95,
+	0x59, 0x59, 0x55, 0x99, 0x59, 0x59, 0xf9, 0xea, 0x81, 0x19, 0x2f, 0x8a, 0x86, 0x3e, 0x7b, 0x38,
+	0x8a, 0x23, 0x11, 0x91, 0x96, 0x82, 0x9c, 0x36, 0x34, 0x37, 0x83, 0x91, 0x38, 0x77, 0x3e, 0x80,
+	0xd9, 0x03, 0x11, 0xf3, 0x70, 0xf8, 0x9c, 0x25, 0x89, 0x3b, 0x64, 0xc4, 0x86, 0x76, 0xa0, 0x86,
+	0xb6, 0xb5, 0x64, 0x2d, 0x77, 0xa9, 0x06, 0x9d, 0x1f, 0x41, 0xe7, 0x45, 0xe4, 0xb1, 0xdd, 0xf0,
+	0x38, 0x22, 0xef, 0xc2, 0xac, 0xda, 0x69, 0xd5, 0xf3, 0x62, 0x96, 0x24, 0x92, 0x76, 0x86, 0x96,
+	0x91, 0xe4, 0x1e, 0xcc, 0x85, 0x4c, 0x9c, 0x45, 0xf1, 0xa9, 0x26, 0xab, 0xc9, 0x2d, 0x2b, 0x58,
+	0xe7, 0x11, 0x74, 0xf5, 0xce, 0xb8, 0xa8, 0xc9, 0x71, 0x60, 0x5b, 0x4b, 0xf5, 0xe5, 0xde, 0xca,
+	0xc2, 0xc3, 0x4c, 0x00, 0x4d, 0x41, 0xd5, 0xb4, 0xf3, 0x17, 0x0b, 0xe6, 0x11, 0xb7, 0xce, 0x62,
+	0xc1, 0x8f, 0xf9, 0xc0, 0x15, 0xec, 0x6a, 0xd9, 0x22, 0x77, 0xa0, 0x3b, 0x4a, 0x8f, 0x7c, 0x3e,
+	0xf8, 0x01, 0x3b, 0xb7, 0xeb, 0x72, 0xa7, 0x02, 0x41, 0x6e, 0x42, 0x33, 0x8c, 0xc2, 0x01, 0xb3,
+	0x1b, 0x72, 0x46, 0x01, 0xe4, 0x2e, 0x80, 0xc7, 0x8f, 0x8f, 0xf9, 0x20, 0xf5, 0xc5, 0xb9, 0xdd,
+	0x5c, 0xb2, 0x96, 0x9b, 0xd4, 0xc0, 0x38, 0x1c, 0xc8, 0xe6, 0xeb, 0x51, 0x14, 0x8b, 0xed, 0xd8,
+	0x1d, 0x9d, 0x50, 0xf6, 0x2a, 0x65, 0x89, 0x20, 0x1f, 0x42, 0xeb, 0x38, 0x8a, 0x03, 0x57, 0x48,
+	0x86, 0xe7, 0x56, 0x6e, 0x68, 0xa1, 0x25, 0xd5, 0x96, 0x9c, 0xa2, 0x19, 0x09, 0xb2, 0x7f, 0xc6,
+	0xc5, 0xc9, 0xaa, 0x10, 0x31, 0x3f, 0x4a, 0x05, 0x53, 0xec, 0x77, 0x68, 0x05, 0xeb, 0x2c, 0x01,
+	0xc8, 0xe5, 0xeb, 0x27, 0x69, 0x78, 0x4a, 0x08, 0x34, 0x3c, 0x57, 0xb8, 0x99, 0x46, 0xe4, 0xd8,
+	0xf9, 0x57, 0x0d, 0x16, 0x0e, 0x44, 0x14, 0xb3, 0x5d, 0xc1, 0x02, 0xcd, 0xcb, 0xa7, 0xd0, 0x1b,
+	0x14, 0x2a, 0x95, 0xf4, 0xbd, 0x95, 0xb7, 0xcd, 0x5b, 0x30, 0x34, 0x4e, 0x4d, 0x5a, 0xb2, 0x00,
+	0xf5, 0x34, 0xf6, 0x33, 0x6d, 0xe2, 0x10, 0x95, 0x24, 0xb8, 0xf0, 0x99, 0x54, 0x5f, 0x97, 0x2a,
+	0x80, 0xf4, 0xa1, 0xc3, 0xbc, 0x21, 0xfb, 0x92, 0xee, 0x25, 0x76, 0x73, 0xa9, 0xbe, 0xdc, 0xa5,
+	0x39, 0x8c, 0x2b, 0x78, 0xe8, 0xb1, 0xd7, 0x76, 0x4b, 0xad, 0x90, 0x00, 0x79, 0x0f, 0x5a, 0xc7,
+	0x9c, 0xf9, 0x5e, 0x62, 0xb7, 0x97, 0xea, 0xcb, 0x73, 0x2b, 0xb3, 0x9a, 0x9f, 0x2d, 0xc4, 0xd2,
+	0x6c, 0x12, 0xb5, 0xef, 0x86, 0x83, 0x93, 0x28, 0xde, 0x0f, 0xfd, 0x73, 0xbb, 0x23, 0xd5, 0x62,
+	0x60, 0x50, 0x09, 0x27, 0x51, 0x22, 0xec, 0xae, 0xdc, 0x5b, 0x8e, 0x11, 0xe7, 0xbb, 0xe1, 0xd0,
+	0x06, 0x85, 0xc3, 0x31, 0x3e, 0x82, 0x84, 0x07, 0x3b, 0x6e, 0x72, 0x62, 0xf7, 0x96, 0xac, 0xe5,
+	0x06, 0xd5, 0x20, 0xda, 0x84, 0xe0, 0x01, 0x4b, 0x84, 0x1b, 0x8c, 0xec, 0x99, 0x25, 0x6b, 0xb9,
+	0x4e, 0x0b, 0x84, 0xc3, 0xa0, 0x7b, 0x18, 0x05, 0x47, 0x89, 0x88, 0xc2, 0x5c, 0x1b, 0x56, 0x49,
+	0x1b, 0x4a, 0xb6, 0x9a, 0x29, 0x5b, 0x69, 0xcb, 0x7a, 0x65, 0x4b, 0x64, 0x6f, 0x18, 0x85, 0xca,
+	0xca, 0x3a, 0x54, 0x8e, 0x9d, 0xaf, 0x2d, 0x58, 0xdc, 0x60, 0x3e, 0x13, 0x57, 0x75, 0x71, 0x1f,
+	0x41, 0x57, 0x68, 0xbe, 0x25, 0x73, 0xbd, 0x95, 0x45, 0xbd, 0x30, 0x17, 0x88, 0x16, 0x34, 0xc8,
+	0x73, 0xc2, 0x87, 0xa1, 0x2b, 0xd2, 0x98, 0xe9, 0xa7, 0x91, 0x23, 0x9c, 0x5f, 0x5a, 0xd0, 0xd9,
+	0x88, 0x06, 0x69, 0xc0, 0x42, 0x31, 0x59, 0x0d, 0x82, 0xc5, 0x01, 0xda, 0x6d, 0x5d, 0x1a, 0x05,
+	0x02, 0x17, 0xa8, 0xc1, 0x86, 0xb6, 0x27, 0x25, 0xf6, 0x32, 0x4d, 0x68, 0xf0, 0x9b, 0x8c, 0xc9,
+	0xf9, 0x8d, 0x05, 0x9d, 0x35, 0x77, 0x70, 0xea, 0xf3, 0xf0, 0x74, 0x0a, 0x23, 0xd2, 0x3a, 0x6b,
+	0xa6, 0x75, 0xfe, 0xb7, 0x8c, 0x2c, 0x41, 0x4f, 0x99, 0xda, 0xa1, 0x14, 0x4e, 0xf1, 0x62, 0xa2,
+	0x9c, 0xef, 0x42, 0x57, 0x73, 0x93, 0x90, 0x87, 0xd0, 0x3d, 0xd2, 0x40, 0xd5, 0xd7, 0x69, 0x2a,
+	0x5a, 0x90, 0x38, 0xbf, 0xb3, 0xe0, 0xa6, 0x7c, 0xac, 0xf9, 0xe4, 0xff, 0x7e, 0xef, 0xb7, 0xa1,
+	0x25, 0xdc, 0x78, 0xc8, 0x44, 0xa6, 0x81, 0x0c, 0x22, 0x0f, 0xa0, 0xa3, 0x0f, 0x96, 0x1a, 0x98,
+	0xc4, 0x5a, 0x4e, 0xe1, 0x9c, 0xc1, 0xcd, 0x2d, 0x1e, 0x7a, 0xb9, 0x68, 0x57, 0xc0, 0xd8, 0x98,
+	0x23, 0xaf, 0x4d, 0x70, 0xe4, 0xce, 0xaf, 0x2d, 0x20, 0x78, 0xf2, 0x7a, 0xb4, 0x77, 0x9d, 0xe7,
+	0xa2, 0xdd, 0xa0, 0x89, 0x25, 0x76, 0x7d, 0xa9, 0x8e, 0xae, 0x5f, 0x02, 0xce, 0x6f, 0x2d, 0x68,
+	0x29, 0x4e, 0x2e, 0x19, 0x87, 0x2e, 0xeb, 0x2e, 0x6d, 0x68, 0x0f, 0xb8, 0x60, 0xde, 0xda, 0xb9,
+	0xdd, 0x90, 0x07, 0x6a, 0x10, 0x4d, 0x75, 0x10, 0xa5, 0x23, 0x5f, 0xce, 0x35, 0xe5, 0x5c, 0x81,
+	0x70, 0x1e, 0x41, 0x3b, 0xd3, 0x0c, 0x59, 0x86, 0xf6, 0x20, 0xda, 0x33, 0x4c, 0x6d, 0x4e, 0xab,
+	0x43, 0x51, 0x50, 0x3d, 0xed, 0xfc, 0xca, 0x82, 0x1e, 0x65, 0xbe, 0x2b, 0x98, 0xf7, 0x12, 0xf3,
+	0x81, 0xcb, 0xbe, 0x9a, 0xbb, 0x00, 0x83, 0x68, 0x9d, 0x0b, 0x57, 0xf0, 0x28, 0x94, 0xfc, 0x37,
+	0xa9, 0x81, 0xc1, 0x67, 0x2a, 0x39, 0xe3, 0xe1, 0x50, 0x3e, 0x9c, 0x26, 0xcd, 0x61, 0xdc, 0x31,
+	0x19, 0x44, 0x31, 0x93, 0xf1, 0xd2, 0xa2, 0x0a, 0x70, 0x3e, 0x85, 0x19, 0x83, 0x91, 0x84, 0x7c,
+	0x00, 0xcd, 0x11, 0x0e, 0x32, 0x09, 0xf2, 0x18, 0x69, 0x10, 0x51, 0x45, 0xe1, 0x24, 0xd0, 0x45,
+	0xf0, 0x00, 0xf7, 0xb9, 0xe4, 0x65, 0xe4, 0x3c, 0xd4, 0x0c, 0x1e, 0x26, 0xdf, 0x34, 0x62, 0xa3,
+	0xb3, 0x30, 0xf7, 0x00, 0x0a, 0x70, 0x5e, 0x41, 0xef, 0x39, 0x63, 0xe2, 0x0a, 0xac, 0xf0, 0x7d,
+	0x2d, 0x69, 0x6d, 0xa9, 0x6e, 0xba, 0xe2, 0x5c, 0x26, 0x2d, 0xe7, 0x63, 0xe8, 0xaa, 0x23, 0x47,
+	0xfe, 0x39, 0x79, 0xbf, 0xac, 0x9f, 0xe9, 0xab, 0xbe, 0xd6, 0x9e, 0x44, 0xfb, 0xe8, 0x2b, 0x60,
+	0xf9, 0x01, 0x74, 0xbc, 0x6c, 0x37, 0xbb, 0x56, 0xf6, 0x18, 0xf9, 0x29, 0x39, 0xc5, 0x05, 0xe1,
+	0xe3, 0x67, 0x70, 0x03, 0x5f, 0xf5, 0x15, 0x72, 0x77, 0x39, 0x77, 0xf2, 0x6f, 0x0b, 0x1a, 0x18,
+	0x50, 0xdf, 0x24, 0x52, 0xf8, 0xd1, 0xc0, 0xf5, 0xa9, 0x1b, 0x9e, 0x4a, 0x5b, 0xb0, 0x68, 0x81,
+	0x30, 0x72, 0x96, 0xe6, 0x37, 0xe5, 0x2c, 0x3a, 0x27, 0x69, 0x4d, 0xc8, 0x49, 0xda, 0x93, 0x73,
+	0x92, 0x4e, 0x39, 0x27, 0xb9, 0x07, 0x73, 0xb1, 0x1b, 0x9e, 0xae, 0x47, 0xc1, 0x28, 0x15, 0xcc,
+	0x5b, 0x55, 0xf9, 0x4d, 0x9d, 0x56, 0xb0, 0xce, 0x87, 0xd0, 0x44, 0xf1, 0x12, 0xe2, 0x40, 0x93,
+	0xe3, 0x20, 0xb3, 0x94, 0x19, 0xcd, 0x18, 0xce, 0x52, 0x35, 0xe5, 0xfc, 0xc2, 0x82, 0xd6, 0x16,
+	0xf7, 0x05, 0x8b, 0x0d, 0x41, 0xac, 0x0b, 0x04, 0x49, 0xb8, 0xd0, 0x2a, 0x92, 0x63, 0x95, 0xf1,
+	0xa0, 0x2e, 0xeb, 0x3a, 0xe3, 0x41, 0x6d, 0x6a, 0xf1, 0x1a, 0x86, 0x78, 0x88, 0xc3, 0x70, 0xd3,
+	0xcc, 0x70, 0x18, 0x58, 0x7e, 0x6f, 0xc1, 0x02, 0x5a, 0xc2, 0x2e, 0xe6, 0x49, 0xd7, 0xe6, 0xdd,
+	0xef, 0xa1, 0xb8, 0x28, 0x78, 0x16, 0xfa, 0xe6, 0x0a, 0x71, 0x11, 0x4b, 0xb3, 0x59, 0x4c, 0x2e,
+	0xe6, 0x0c, 0xee, 0xf0, 0x09, 0x7e, 0x02, 0xdd, 0x50, 0x17, 0x32, 0x19, 0x67, 0x8b, 0xd5, 0xfa,
+	0x25, 0xd9, 0x79, 0x8b, 0x16, 0x54, 0xe4, 0x3d, 0x7d, 0x17, 0xea, 0xd5, 0xcc, 0x9a, 0x77, 0x81,
+	0xa4, 0x6a, 0x16, 0x23, 0xf5, 0xc0, 0x1d, 0x9c, 0x30, 0x4f, 0x32, 0xd5, 0xa1, 0x19, 0xb4, 0xd6,
+	0x81, 0x56, 0xcc, 0x92, 0xd4, 0x17, 0xce, 0xdf, 0x2c, 0x58, 0x5c, 0x47, 0xe4, 0xf5, 0x6a, 0x2b,
+	0xb7, 0xa5, 0xfa, 0x54, 0x5b, 0x92, 0x19, 0x95, 0xf0, 0x9f, 0x73, 0xdf, 0xe7, 0x89, 0xdd, 0xc8,
+	0x32, 0x2a, 0x8d, 0x70, 0x62, 0x98, 0x47, 0x35, 0x22, 0x2f, 0xd7, 0xf6, 0xd4, 0xff, 0x64, 0xc1,
+	0xec, 0x36, 0x13, 0xc6, 0xd5, 0x5d, 0xe2, 0x4d, 0x90, 0xfb, 0xb0, 0x10, 0xa6, 0xc1, 0x01, 0x0f,
+	0xb8, 0xef, 0xc6, 0x3b, 0xdc, 0xf3, 0x58, 0x28, 0xb7, 0x6f, 0xd2, 0x31, 0x3c, 0x66, 0x83, 0x49,
+	0x3a, 0x1c, 0xb2, 0x04, 0xa3, 0x9f, 0xd2, 0x4e, 0x97, 0x9a, 0x28, 0x8c, 0x67, 0x89, 0x70, 0x85,
+	0xd2, 0x88, 0x11, 0xcf, 0x0e, 0x98, 0x1b, 0x0f, 0x4e, 0x0e, 0x70, 0x8a, 0x2a, 0x0a, 0x27, 0x82,
+	0x9e, 0x81, 0xc5, 0xbd, 0xc3, 0x34, 0x58, 0x0d, 0x93, 0x33, 0x16, 0x33, 0x4f, 0xaa, 0xa7, 0x49,
+	0x4d, 0x14, 0x6a, 0x3c, 0x4c, 0x83, 0x2d, 0x97, 0xfb, 0xcc, 0xcb, 0x58, 0x2c, 0x10, 0xd9, 0xfa,
+	0x43, 0x1e, 0x30, 0x6f, 0x3f, 0x15, 0x59, 0xb0, 0x36, 0x51, 0xce, 0x09, 0x2c, 0xea, 0x03, 0x63,
+	0xe6, 0x06, 0x97, 0x57, 0xd1, 0x47, 0xd0, 0x4e, 0xd2, 0x20, 0x70, 0xe3, 0xf3, 0xcc, 0xa0, 0x6f,
+	0xe5, 0xa5, 0xac, 0xa9, 0x6e, 0xaa, 0xa9, 0x9c, 0x3d, 0x00, 0x69, 0xb5, 0x4a, 0x32, 0x74, 0x86,
+	0x5c, 0xa8, 0xb7, 0x53, 0xa7, 0x72, 0x8c, 0xa6, 0x1f, 0xf0, 0x24, 0xc9, 0xea, 0xdc, 0x3a, 0xcd,
+	0x20, 0xe5, 0x6f, 0xbe, 0x62, 0x19, 0xfb, 0x72, 0xec, 0xfc, 0xdd, 0x82, 0x2e, 0x3a, 0x5f, 0xb5,
+	0xdb, 0xbb, 0x30, 0x1b, 0xa6, 0xc1, 0x06, 0x8f, 0xc5, 0xf9, 0x6e, 0xc6, 0x38, 0x92, 0x96, 0x91,
+	0x26, 0xd5, 0x61, 0x56, 0x96, 0x94, 0xa8, 0x0e, 0x75, 0x79, 0xe2, 0xbb, 0x89, 0xa0, 0x69, 0xb8,
+	0x2a, 0x74, 0x55, 0x90, 0x23, 0xc8, 0x32, 0xcc, 0x67, 0xc0, 0x8b, 0x34, 0x50, 0x67, 0xa9, 0x24,
+	0xa7, 0x8a, 0x46, 0x67, 0x8d, 0xf5, 0x8e, 0xe1, 0xac, 0x9b, 0xca, 0x59, 0x97, 0xb1, 0xce, 0x4f,
+	0x00, 0x50, 0x10, 0xca, 0x06, 0x51, 0xec, 0xa9, 0xec, 0x2a, 0x5f, 0xa1, 0xb4, 0x63, 0x60, 0x50,
+	0x17, 0xe8, 0xec, 0xb3, 0xe4, 0x45, 0x8e, 0xcb, 0xd1, 0xa9, 0x5e, 0x89, 0x4e, 0x68, 0x52, 0xf8,
+	0xbb, 0xc3, 0x13, 0x11, 0xc5, 0xe7, 0x65, 0x62, 0xab, 0x42, 0x8c, 0x6e, 0x7c, 0x14, 0xf3, 0x28,
+	0xd6, 0xc9, 0x91, 0x04, 0xc8, 0x03, 0x68, 0xc7, 0x92, 0x3d, 0xfd, 0xf8, 0x49, 0x9e, 0x92, 0xe5,
+	0x9c, 0x53, 0x4d, 0xe2, 0xfc, 0x1c, 0xde, 0xc1, 0x67, 0x8e, 0x53, 0x2f, 0x18, 0x1f, 0x9e, 0x1c,
+	0x45, 0x69, 0x7c, 0x7d, 0xa5, 0xc2, 0x5f, 0x2d, 0x98, 0x2d, 0x1d, 0x3d, 0x21, 0xc8, 0x5f, 0x3a,
+	0xf9, 0x57, 0x89, 0x62, 0xdd, 0x4c, 0x14, 0xef, 0x40, 0x37, 0x4a, 0xc5, 0x06, 0x1b, 0xc6, 0x8c,
+	0x65, 0x57, 0x5f, 0x20, 0x88, 0x03, 0x33, 0x83, 0x28, 0x54, 0x9d, 0x19, 0x4c, 0x8f, 0x55, 0x9e,
+	0x5b, 0xc2, 0xe1, 0xbe, 0x67, 0x51, 0xec, 0x7b, 0x32, 0x11, 0xe8, 0x50, 0x05, 0x38, 0xdb, 0x30,
+	0x57, 0xd6, 0x18, 0x79, 0x02, 0x10, 0xe6, 0x50, 0xf6, 0x14, 0x6f, 0x99, 0x8a, 0xcf, 0x69, 0xa9,
+	0x41, 0xe8, 0xfc, 0xd3, 0x02, 0x50, 0x2e, 0x16, 0xbb, 0x4f, 0x13, 0x7a, 0x60, 0xd6, 0xb4, 0x1e,
+	0x58, 0x61, 0x17, 0xb5, 0xaa, 0x5d, 0x2c, 0xc3, 0x7c, 0x88, 0x4d, 0x29, 0x9f, 0x7f, 0xc5, 0xbc,
+	0x03, 0x43, 0x2b, 0x55, 0x34, 0x66, 0x2f, 0x51, 0x2a, 0x7c, 0xce, 0x62, 0x5d, 0x36, 0x67, 0x60,
+	0x45, 0x9e, 0xe6, 0x65, 0xe5, 0x79, 0x06, 0x73, 0x9b, 0xaf, 0x47, 0xbe, 0xcb, 0x43, 0x6d, 0x43,
+	0x37, 0xa1, 0xf9, 0x2a, 0x65, 0xf1, 0x79, 0x26, 0x89, 0x02, 0xc6, 0x8b, 0x2c, 0xe7, 0x1f, 0x35,
+	0xe8, 0xc9, 0xa5, 0xa1, 0xaa, 0x4c, 0x26, 0x66, 0x7b, 0xc7, 0x51, 0x1a, 0x7a, 0x59, 0x63, 0x4d,
+	0x01, 0x58, 0xc1, 0x8c, 0xa2, 0x84, 0x1b, 0xf5, 0x4d, 0x0e, 0xab, 0xa7, 0x80, 0x8a, 0x4d, 0xec,
+	0x46, 0xf9, 0x29, 0x14, 0x3a, 0xa7, 0x9a, 0x44, 0x7a, 0x34, 0xe6, 0x71, 0x57, 0x1b, 0x42, 0x06,
+	0xa1, 0x3a, 0xdd, 0xe1, 0x30, 0x66, 0x43, 0x57, 0x68, 0x75, 0xb6, 0x94, 0x3a, 0x2b, 0xe8, 0x37,
+	0xe8, 0x87, 0xc9, 0xd1, 0x5a, 0x84, 0x19, 0x66, 0x47, 0xee, 0x65, 0x60, 0x0a, 0x5b, 0xee, 0x9a,
+	0xb6, 0x9c, 0x07, 0x26, 0xb8, 0x30, 0x30, 0x3d, 0x83, 0xce, 0xc1, 0x88, 0xf9, 0xb2, 0x8a, 0x23,
+	0xd0, 0x40, 0x1f, 0x96, 0x29, 0x52, 0x8e, 0x65, 0xb7, 0x24, 0x1a, 0x6c, 0xc5, 0xec, 0x55, 0xe6,
+	0xbc, 0x35, 0x88, 0xbd, 0x10, 0xbd, 0x52, 0xf6, 0x42, 0x12, 0x0d, 0x54, 0x7b, 0x21, 0x9a, 0x8a,
+	0x16, 0x24, 0xce, 0x1f, 0x75, 0x05, 0x93, 0x4f, 0x5e, 0x57, 0xba, 0xf3, 0x00, 0x3a, 0x9a, 0x8d,
+	0x6a, 0x67, 0x24, 0xe7, 0x25, 0xa7, 0xd0, 0x95, 0xcc, 0x75, 0x73, 0xe9, 0x3c, 0x05, 0xc0, 0x50,
+	0xe2, 0x33, 0x69, 0x9c, 0x93, 0x2e, 0x66, 0x62, 0xb9, 0xeb, 0xac, 0x43, 0xaf, 0x58, 0x97, 0x90,
+	0xc7, 0xd0, 0x1b, 0x14, 0xa0, 0x6d, 0x95, 0x2d, 0xbb, 0xa0, 0xa4, 0x26, 0x99, 0xf3, 0x67, 0x0b,
+	0x6e, 0xcb, 0xcb, 0x31, 0x08, 0xae, 0xeb, 0x7a, 0x56, 0x54, 0x9c, 0x54, 0xa7, 0x66, 0x17, 0x34,
+	0x89, 0x61, 0x83, 0xca, 0x79, 0x0d, 0xb7, 0x54, 0x13, 0xe9, 0xba, 0xb9, 0xbd, 0xff, 0x04, 0x9a,
+	0xf2, 0xbd, 0x92, 0x0e, 0x34, 0xd6, 0xf6, 0x37, 0x7e, 0xbc, 0xf0, 0x16, 0xe9, 0x42, 0xf3, 0x70,
+	0xf7, 0x70, 0x6f, 0x73, 0xc1, 0x22, 0x3d, 0x68, 0xef, 0x6c, 0xae, 0x6e, 0xec, 0xbe, 0xd8, 0x5e,
+	0xa8, 0x11, 0x80, 0xd6, 0xea, 0x8b, 0xf5, 0x9d, 0x7d, 0xba, 0x50, 0xbf, 0xbf, 0x02, 0x3d, 0xe3,
+	0xbb, 0x00, 0x99, 0x85, 0xee, 0xe6, 0xc6, 0xf6, 0xe6, 0x4f, 0xf7, 0x76, 0x0f, 0x0e, 0x17, 0xde,
+	0x22, 0x6d, 0xa8, 0x6f, 0xec, 0x1f, 0xaa, 0xf5, 0xdb, 0x74, 0xf5, 0xe5, 0xce, 0xf3, 0xbd, 0x85,
+	0xda, 0xca, 0x1f, 0xe6, 0xa0, 0xb5, 0x21, 0x0f, 0x27, 0x8f, 0xa1, 0x9b, 0x37, 0xfd, 0x89, 0x9d,
+	0x5b, 0x6f, 0xe5, 0x3b, 0x40, 0x3f, 0x77, 0x29, 0xf2, 0x43, 0x11, 0x79, 0x0a, 0x50, 0xb4, 0x9c,
+	0xc9, 0x3b, 0x79, 0x71, 0x5f, 0x6d, 0x43, 0x57, 0xd7, 0x7d, 0x06, 0xb3, 0xa5, 0x5e, 0x03, 0xb9,
+	0x53, 0x3a, 0xb1, 0x52, 0xe4, 0x57, 0x57, 0x7f, 0x0e, 0x33, 0x66, 0x2b, 0x80, 0xfc, 0x5f, 0xe1,
+	0xe7, 0xc6, 0x1a, 0x04, 0xfd, 0xb1, 0x8e, 0x43, 0x7e, 0x78, 0xde, 0x03, 0x2e, 0x1f, 0x5e, 0xe9,
+	0xa4, 0x56, 0x0f, 0xff, 0x02, 0x66, 0x4b, 0x7d, 0xcd, 0x62, 0xf5, 0xa4, 0x76, 0x67, 0x7f, 0xb1,
+	0xda, 0x22, 0x45, 0xbf, 0xd6, 0xc0, 0xfe, 0x0c, 0xc9, 0x5d, 0xa8, 0xd1, 0x20, 0xea, 0x2f, 0x96,
+	0x91, 0x98, 0x61, 0xef, 0xab, 0x7e, 0x66, 0x25, 0xe2, 0x7f, 0xcb, 0x3c, 0x76, 0x62, 0xfe, 0xd4,
+	0xbf, 0x3d, 0x31, 0x60, 0x26, 0xe4, 0x3b, 0xd0, 0x33, 0x1a, 0xa4, 0xa4, 0x6f, 0xee, 0x54, 0xee,
+	0x9a, 0xf6, 0xe7, 0xcb, 0x1d, 0xc1, 0x84, 0x7c, 0x0e, 0xdd, 0xbc, 0xbc, 0x2d, 0xec, 0xa4, 0x5a,
+	0x8f, 0xf7, 0x6f, 0x4f, 0x98, 0x41, 0x59, 0x9e, 0x66, 0x89, 0xbd, 0x5a, 0x9f, 0x1b, 0xcc, 0x58,
+	0x89, 0x3a, 0x6e, 0x68, 0x1d, 0x5d, 0x0e, 0x92, 0xb7, 0xcd, 0xbd, 0x8d, 0x02, 0xb1, 0x3f, 0x5e,
+	0x55, 0x93, 0x6d, 0xb8, 0xf1, 0x92, 0x87, 0xc3, 0x1f, 0x72, 0x71, 0x62, 0x7e, 0x12, 0x9c, 0xf6,
+	0x5e, 0xfb, 0xd3, 0x26, 0x72, 0xa3, 0xc9, 0x03, 0x5b, 0xd9, 0x68, 0x2a, 0xce, 0xbc, 0xca, 0xfe,
+	0xf7, 0x94, 0xc5, 0xe6, 0x8b, 0x4b, 0x16, 0x5b, 0x5d, 0xbb, 0x58, 0x8d, 0x1d, 0x09, 0xf9, 0x02,
+	0xe6, 0x2b, 0xce, 0x93, 0xdc, 0x2d, 0x9d, 0x3f, 0xe6, 0xa7, 0xaa, 0x1c, 0x6c, 0xa8, 0xb6, 0x84,
+	0xb1, 0xc1, 0xff, 0x97, 0xaf, 0xbd, 0xba, 0xfe, 0xc6, 0xb8, 0x83, 0x44, 0xdf, 0xdf, 0x40, 0x75,
+	0x92, 0x5b, 0xc5, 0xe1, 0xc6, 0x67, 0xe2, 0xfe, 0x64, 0x34, 0xf9, 0x04, 0x5a, 0xb8, 0xea, 0x30,
+	0x22, 0x63, 0xdf, 0x6d, 0xa7, 0x2d, 0x79, 0x06, 0x1d, 0x5d, 0x1a, 0x5e, 0x78, 0x58, 0xb9, 0x64,
+	0x5f, 0x83, 0x19, 0xb3, 0x48, 0x9d, 0xb6, 0xfa, 0x9d, 0x6a, 0xfe, 0x92, 0x57, 0xb4, 0x1f, 0x5b,
+	0xe4, 0xdb, 0xd0, 0x7e, 0x19, 0x25, 0xe2, 0xcb, 0xd8, 0x7f, 0x43, 0x49, 0x9f, 0x40, 0xfb, 0x40,
+	0x15, 0xf3, 0xd3, 0x16, 0x4e, 0x54, 0xeb, 0x8a, 0xec, 0x3b, 0x18, 0x15, 0x6f, 0xf9, 0xf2, 0xfa,
+	0xa4, 0xf4, 0x4e, 0x14, 0xc9, 0x33, 0x98, 0xd9, 0x66, 0xa2, 0x28, 0x6b, 0xa7, 0x9c, 0xb7, 0x68,
+	0xfa, 0x00, 0x45, 0xf9, 0x19, 0xcc, 0x65, 0x2b, 0x75, 0x9d, 0x77, 0x11, 0xaf, 0x26, 0xed, 0x53,
+	0x68, 0x67, 0x29, 0x36, 0xc9, 0x1f, 0x79, 0x39, 0xe7, 0xee, 0xdf, 0x28, 0xe1, 0xb3, 0x84, 0xfa,
+	0xfb, 0xd0, 0x33, 0xbe, 0x71, 0x17, 0x4e, 0x67, 0xfc, 0xc3, 0x77, 0x21, 0x6e, 0xf1, 0xa5, 0xfa,
+	0x63, 0x2b, 0x13, 0xb8, 0x70, 0xa3, 0x17, 0x09, 0x5c, 0x50, 0x3e, 0x85, 0x76, 0xf6, 0x39, 0x60,
+	0xda, 0xa2, 0x9b, 0x13, 0x3e, 0x1b, 0x24, 0x47, 0x2d, 0xf9, 0xf7, 0x88, 0x47, 0xff, 0x19, 0x00,
+	0x38, 0xea, 0x55, 0xd8, 0x2e, 0x21, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// DoogleClient is the client API for Doogle service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type DoogleClient interface {
+	// Store give index
+	StoreItem(ctx context.Context, in *StoreItemRequest, opts ...grpc.CallOption) (*Empty, error)
+	// delete the url from the posting list of given index, leaving a tombstone
+	DeleteItem(ctx context.Context, in *DeleteItemRequest, opts ...grpc.CallOption) (*Empty, error)
+	// store and find the indices of given url
+	StoreDocument(ctx context.Context, in *StoreDocumentRequest, opts ...grpc.CallOption) (*Empty, error)
+	FindDocument(ctx context.Context, in *FindDocumentRequest, opts ...grpc.CallOption) (*Document, error)
+	// store and find the links to the url of given address
+	StoreBacklink(ctx context.Context, in *StoreBacklinkRequest, opts ...grpc.CallOption) (*Empty, error)
+	FindBacklinks(ctx context.Context, in *FindBacklinksRequest, opts ...grpc.CallOption) (*Backlinks, error)
+	// exchange the scores of pages and the knowledge of the world node (JXP meeting)
+	Meet(ctx context.Context, in *MeetRequest, opts ...grpc.CallOption) (*MeetReply, error)
+	// find the pages linking to the page of given address, which contribute to its rank on the node
+	FindRankNeighbours(ctx context.Context, in *FindRankNeighboursRequest, opts ...grpc.CallOption) (*RankNeighbours, error)
+	// find pages co-cited with or bibliographically coupled to the page of given address among the items it holds
+	FindCoLinks(ctx context.Context, in *FindCoLinksRequest, opts ...grpc.CallOption) (*CoLinks, error)
+	// find index of given key
+	FindIndex(ctx context.Context, in *FindIndexRequest, opts ...grpc.CallOption) (*FindIndexReply, error)
+	// store a cached copy of the posting list of given key
+	CacheIndex(ctx context.Context, in *CacheIndexRequest, opts ...grpc.CallOption) (*Empty, error)
+	// return k closed nodes to given address
+	FindNode(ctx context.Context, in *FindNodeRequest, opts ...grpc.CallOption) (*NodeInfos, error)
+	// health check
+	PingWithCertificate(ctx context.Context, in *NodeCertificate, opts ...grpc.CallOption) (*NodeCertificate, error)
+	// store spelling of an index on the given deletion-neighbourhood key
+	StoreSpelling(ctx context.Context, in *StoreSpellingRequest, opts ...grpc.CallOption) (*Empty, error)
+	// find spellings stored on given key
+	FindSpelling(ctx context.Context, in *FindSpellingRequest, opts ...grpc.CallOption) (*Spellings, error)
+	// add score of a term on the given prefix key
+	StoreCompletion(ctx context.Context, in *StoreCompletionRequest, opts ...grpc.CallOption) (*Empty, error)
+	// find completions stored on given prefix key
+	FindCompletion(ctx context.Context, in *FindCompletionRequest, opts ...grpc.CallOption) (*Completions, error)
+	// the following endpoints can be accessed from outside of the network.
+	Ping(ctx context.Context, in *StringMessage, opts ...grpc.CallOption) (*StringMessage, error)
+	PingTo(ctx context.Context, in *NodeInfo, opts ...grpc.CallOption) (*StringMessage, error)
+	GetIndex(ctx context.Context, in *StringMessage, opts ...grpc.CallOption) (*GetIndexReply, error)
+	SearchStream(ctx context.Context, in *StringMessage, opts ...grpc.CallOption) (Doogle_SearchStreamClient, error)
+	PostUrl(ctx context.Context, in *StringMessage, opts ...grpc.CallOption) (*StringMessage, error)
+	Suggest(ctx context.Context, in *StringMessage, opts ...grpc.CallOption) (*Completions, error)
+	GetCacheStats(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*CacheStats, error)
+	GetRankStats(ctx context.Context, in *StringMessage, opts ...grpc.CallOption) (*RankStats, error)
+	GetRankHistory(ctx context.Context, in *StringMessage, opts ...grpc.CallOption) (*RankHistory, error)
+	Explain(ctx context.Context, in *ExplainRequest, opts ...grpc.CallOption) (*Explanation, error)
+	ExportGraph(ctx context.Context, in *ExportGraphRequest, opts ...grpc.CallOption) (Doogle_ExportGraphClient, error)
+	GetBacklinks(ctx context.Context, in *StringMessage, opts ...grpc.CallOption) (*Backlinks, error)
+	Related(ctx context.Context, in *StringMessage, opts ...grpc.CallOption) (*RelatedPages, error)
 }
 
 type doogleClient struct {
 	cc *grpc.ClientConn
 }
 
-func NewDoogleClient(cc *grpc.ClientConn) DoogleClient {
-	return &doogleClient{cc}
+func NewDoogleClient(cc *grpc.ClientConn) DoogleClient {
+	return &doogleClient{cc}
+}
+
+func (c *doogleClient) StoreItem(ctx context.Context, in *StoreItemRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/doogle.Doogle/StoreItem", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *doogleClient) DeleteItem(ctx context.Context, in *DeleteItemRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/doogle.Doogle/DeleteItem", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *doogleClient) StoreDocument(ctx context.Context, in *StoreDocumentRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/doogle.Doogle/StoreDocument", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *doogleClient) FindDocument(ctx context.Context, in *FindDocumentRequest, opts ...grpc.CallOption) (*Document, error) {
+	out := new(Document)
+	err := c.cc.Invoke(ctx, "/doogle.Doogle/FindDocument", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *doogleClient) StoreBacklink(ctx context.Context, in *StoreBacklinkRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/doogle.Doogle/StoreBacklink", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *doogleClient) FindBacklinks(ctx context.Context, in *FindBacklinksRequest, opts ...grpc.CallOption) (*Backlinks, error) {
+	out := new(Backlinks)
+	err := c.cc.Invoke(ctx, "/doogle.Doogle/FindBacklinks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *doogleClient) Meet(ctx context.Context, in *MeetRequest, opts ...grpc.CallOption) (*MeetReply, error) {
+	out := new(MeetReply)
+	err := c.cc.Invoke(ctx, "/doogle.Doogle/Meet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *doogleClient) FindRankNeighbours(ctx context.Context, in *FindRankNeighboursRequest, opts ...grpc.CallOption) (*RankNeighbours, error) {
+	out := new(RankNeighbours)
+	err := c.cc.Invoke(ctx, "/doogle.Doogle/FindRankNeighbours", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *doogleClient) FindCoLinks(ctx context.Context, in *FindCoLinksRequest, opts ...grpc.CallOption) (*CoLinks, error) {
+	out := new(CoLinks)
+	err := c.cc.Invoke(ctx, "/doogle.Doogle/FindCoLinks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *doogleClient) FindIndex(ctx context.Context, in *FindIndexRequest, opts ...grpc.CallOption) (*FindIndexReply, error) {
+	out := new(FindIndexReply)
+	err := c.cc.Invoke(ctx, "/doogle.Doogle/FindIndex", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *doogleClient) CacheIndex(ctx context.Context, in *CacheIndexRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/doogle.Doogle/CacheIndex", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *doogleClient) FindNode(ctx context.Context, in *FindNodeRequest, opts ...grpc.CallOption) (*NodeInfos, error) {
+	out := new(NodeInfos)
+	err := c.cc.Invoke(ctx, "/doogle.Doogle/FindNode", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *doogleClient) PingWithCertificate(ctx context.Context, in *NodeCertificate, opts ...grpc.CallOption) (*NodeCertificate, error) {
+	out := new(NodeCertificate)
+	err := c.cc.Invoke(ctx, "/doogle.Doogle/PingWithCertificate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *doogleClient) StoreSpelling(ctx context.Context, in *StoreSpellingRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/doogle.Doogle/StoreSpelling", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *doogleClient) FindSpelling(ctx context.Context, in *FindSpellingRequest, opts ...grpc.CallOption) (*Spellings, error) {
+	out := new(Spellings)
+	err := c.cc.Invoke(ctx, "/doogle.Doogle/FindSpelling", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *doogleClient) StoreCompletion(ctx context.Context, in *StoreCompletionRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/doogle.Doogle/StoreCompletion", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *doogleClient) FindCompletion(ctx context.Context, in *FindCompletionRequest, opts ...grpc.CallOption) (*Completions, error) {
+	out := new(Completions)
+	err := c.cc.Invoke(ctx, "/doogle.Doogle/FindCompletion", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *doogleClient) Ping(ctx context.Context, in *StringMessage, opts ...grpc.CallOption) (*StringMessage, error) {
+	out := new(StringMessage)
+	err := c.cc.Invoke(ctx, "/doogle.Doogle/Ping", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *doogleClient) PingTo(ctx context.Context, in *NodeInfo, opts ...grpc.CallOption) (*StringMessage, error) {
+	out := new(StringMessage)
+	err := c.cc.Invoke(ctx, "/doogle.Doogle/PingTo", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *doogleClient) GetIndex(ctx context.Context, in *StringMessage, opts ...grpc.CallOption) (*GetIndexReply, error) {
+	out := new(GetIndexReply)
+	err := c.cc.Invoke(ctx, "/doogle.Doogle/GetIndex", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *doogleClient) SearchStream(ctx context.Context, in *StringMessage, opts ...grpc.CallOption) (Doogle_SearchStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Doogle_serviceDesc.Streams[0], "/doogle.Doogle/SearchStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &doogleSearchStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Doogle_SearchStreamClient interface {
+	Recv() (*SearchStreamReply, error)
+	grpc.ClientStream
+}
+
+type doogleSearchStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *doogleSearchStreamClient) Recv() (*SearchStreamReply, error) {
+	m := new(SearchStreamReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *doogleClient) PostUrl(ctx context.Context, in *StringMessage, opts ...grpc.CallOption) (*StringMessage, error) {
+	out := new(StringMessage)
+	err := c.cc.Invoke(ctx, "/doogle.Doogle/PostUrl", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *doogleClient) Suggest(ctx context.Context, in *StringMessage, opts ...grpc.CallOption) (*Completions, error) {
+	out := new(Completions)
+	err := c.cc.Invoke(ctx, "/doogle.Doogle/Suggest", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *doogleClient) GetCacheStats(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*CacheStats, error) {
+	out := new(CacheStats)
+	err := c.cc.Invoke(ctx, "/doogle.Doogle/GetCacheStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *doogleClient) GetRankStats(ctx context.Context, in *StringMessage, opts ...grpc.CallOption) (*RankStats, error) {
+	out := new(RankStats)
+	err := c.cc.Invoke(ctx, "/doogle.Doogle/GetRankStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *doogleClient) GetRankHistory(ctx context.Context, in *StringMessage, opts ...grpc.CallOption) (*RankHistory, error) {
+	out := new(RankHistory)
+	err := c.cc.Invoke(ctx, "/doogle.Doogle/GetRankHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *doogleClient) Explain(ctx context.Context, in *ExplainRequest, opts ...grpc.CallOption) (*Explanation, error) {
+	out := new(Explanation)
+	err := c.cc.Invoke(ctx, "/doogle.Doogle/Explain", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *doogleClient) ExportGraph(ctx context.Context, in *ExportGraphRequest, opts ...grpc.CallOption) (Doogle_ExportGraphClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Doogle_serviceDesc.Streams[1], "/doogle.Doogle/ExportGraph", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &doogleExportGraphClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Doogle_ExportGraphClient interface {
+	Recv() (*GraphChunk, error)
+	grpc.ClientStream
+}
+
+type doogleExportGraphClient struct {
+	grpc.ClientStream
+}
+
+func (x *doogleExportGraphClient) Recv() (*GraphChunk, error) {
+	m := new(GraphChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *doogleClient) GetBacklinks(ctx context.Context, in *StringMessage, opts ...grpc.CallOption) (*Backlinks, error) {
+	out := new(Backlinks)
+	err := c.cc.Invoke(ctx, "/doogle.Doogle/GetBacklinks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *doogleClient) Related(ctx context.Context, in *StringMessage, opts ...grpc.CallOption) (*RelatedPages, error) {
+	out := new(RelatedPages)
+	err := c.cc.Invoke(ctx, "/doogle.Doogle/Related", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DoogleServer is the server API for Doogle service.
+type DoogleServer interface {
+	// Store give index
+	StoreItem(context.Context, *StoreItemRequest) (*Empty, error)
+	// delete the url from the posting list of given index, leaving a tombstone
+	DeleteItem(context.Context, *DeleteItemRequest) (*Empty, error)
+	// store and find the indices of given url
+	StoreDocument(context.Context, *StoreDocumentRequest) (*Empty, error)
+	FindDocument(context.Context, *FindDocumentRequest) (*Document, error)
+	// store and find the links to the url of given address
+	StoreBacklink(context.Context, *StoreBacklinkRequest) (*Empty, error)
+	FindBacklinks(context.Context, *FindBacklinksRequest) (*Backlinks, error)
+	// exchange the scores of pages and the knowledge of the world node (JXP meeting)
+	Meet(context.Context, *MeetRequest) (*MeetReply, error)
+	// find the pages linking to the page of given address, which contribute to its rank on the node
+	FindRankNeighbours(context.Context, *FindRankNeighboursRequest) (*RankNeighbours, error)
+	// find pages co-cited with or bibliographically coupled to the page of given address among the items it holds
+	FindCoLinks(context.Context, *FindCoLinksRequest) (*CoLinks, error)
+	// find index of given key
+	FindIndex(context.Context, *FindIndexRequest) (*FindIndexReply, error)
+	// store a cached copy of the posting list of given key
+	CacheIndex(context.Context, *CacheIndexRequest) (*Empty, error)
+	// return k closed nodes to given address
+	FindNode(context.Context, *FindNodeRequest) (*NodeInfos, error)
+	// health check
+	PingWithCertificate(context.Context, *NodeCertificate) (*NodeCertificate, error)
+	// store spelling of an index on the given deletion-neighbourhood key
+	StoreSpelling(context.Context, *StoreSpellingRequest) (*Empty, error)
+	// find spellings stored on given key
+	FindSpelling(context.Context, *FindSpellingRequest) (*Spellings, error)
+	// add score of a term on the given prefix key
+	StoreCompletion(context.Context, *StoreCompletionRequest) (*Empty, error)
+	// find completions stored on given prefix key
+	FindCompletion(context.Context, *FindCompletionRequest) (*Completions, error)
+	// the following endpoints can be accessed from outside of the network.
+	Ping(context.Context, *StringMessage) (*StringMessage, error)
+	PingTo(context.Context, *NodeInfo) (*StringMessage, error)
+	GetIndex(context.Context, *StringMessage) (*GetIndexReply, error)
+	SearchStream(*StringMessage, Doogle_SearchStreamServer) error
+	PostUrl(context.Context, *StringMessage) (*StringMessage, error)
+	Suggest(context.Context, *StringMessage) (*Completions, error)
+	GetCacheStats(context.Context, *Empty) (*CacheStats, error)
+	GetRankStats(context.Context, *StringMessage) (*RankStats, error)
+	GetRankHistory(context.Context, *StringMessage) (*RankHistory, error)
+	Explain(context.Context, *ExplainRequest) (*Explanation, error)
+	ExportGraph(*ExportGraphRequest, Doogle_ExportGraphServer) error
+	GetBacklinks(context.Context, *StringMessage) (*Backlinks, error)
+	Related(context.Context, *StringMessage) (*RelatedPages, error)
+}
+
+func RegisterDoogleServer(s *grpc.Server, srv DoogleServer) {
+	s.RegisterService(&_Doogle_serviceDesc, srv)
+}
+
+func _Doogle_StoreItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StoreItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DoogleServer).StoreItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/doogle.Doogle/StoreItem",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DoogleServer).StoreItem(ctx, req.(*StoreItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Doogle_DeleteItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DoogleServer).DeleteItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/doogle.Doogle/DeleteItem",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DoogleServer).DeleteItem(ctx, req.(*DeleteItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Doogle_StoreDocument_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StoreDocumentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DoogleServer).StoreDocument(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/doogle.Doogle/StoreDocument",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DoogleServer).StoreDocument(ctx, req.(*StoreDocumentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Doogle_FindDocument_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindDocumentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DoogleServer).FindDocument(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/doogle.Doogle/FindDocument",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DoogleServer).FindDocument(ctx, req.(*FindDocumentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Doogle_StoreBacklink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StoreBacklinkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DoogleServer).StoreBacklink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/doogle.Doogle/StoreBacklink",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DoogleServer).StoreBacklink(ctx, req.(*StoreBacklinkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Doogle_FindBacklinks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindBacklinksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DoogleServer).FindBacklinks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/doogle.Doogle/FindBacklinks",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DoogleServer).FindBacklinks(ctx, req.(*FindBacklinksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Doogle_Meet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MeetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DoogleServer).Meet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/doogle.Doogle/Meet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DoogleServer).Meet(ctx, req.(*MeetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Doogle_FindRankNeighbours_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindRankNeighboursRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DoogleServer).FindRankNeighbours(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/doogle.Doogle/FindRankNeighbours",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DoogleServer).FindRankNeighbours(ctx, req.(*FindRankNeighboursRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Doogle_FindCoLinks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindCoLinksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DoogleServer).FindCoLinks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/doogle.Doogle/FindCoLinks",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DoogleServer).FindCoLinks(ctx, req.(*FindCoLinksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Doogle_FindIndex_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindIndexRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DoogleServer).FindIndex(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/doogle.Doogle/FindIndex",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DoogleServer).FindIndex(ctx, req.(*FindIndexRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Doogle_CacheIndex_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CacheIndexRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DoogleServer).CacheIndex(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/doogle.Doogle/CacheIndex",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DoogleServer).CacheIndex(ctx, req.(*CacheIndexRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Doogle_FindNode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindNodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DoogleServer).FindNode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/doogle.Doogle/FindNode",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DoogleServer).FindNode(ctx, req.(*FindNodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Doogle_PingWithCertificate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NodeCertificate)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DoogleServer).PingWithCertificate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/doogle.Doogle/PingWithCertificate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DoogleServer).PingWithCertificate(ctx, req.(*NodeCertificate))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *doogleClient) StoreItem(ctx context.Context, in *StoreItemRequest, opts ...grpc.CallOption) (*Empty, error) {
-	out := new(Empty)
-	err := c.cc.Invoke(ctx, "/doogle.Doogle/StoreItem", in, out, opts...)
-	if err != nil {
+func _Doogle_StoreSpelling_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StoreSpellingRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(DoogleServer).StoreSpelling(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/doogle.Doogle/StoreSpelling",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DoogleServer).StoreSpelling(ctx, req.(*StoreSpellingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *doogleClient) FindIndex(ctx context.Context, in *FindIndexRequest, opts ...grpc.CallOption) (*FindIndexReply, error) {
-	out := new(FindIndexReply)
-	err := c.cc.Invoke(ctx, "/doogle.Doogle/FindIndex", in, out, opts...)
-	if err != nil {
+func _Doogle_FindSpelling_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindSpellingRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(DoogleServer).FindSpelling(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/doogle.Doogle/FindSpelling",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DoogleServer).FindSpelling(ctx, req.(*FindSpellingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *doogleClient) FindNode(ctx context.Context, in *FindNodeRequest, opts ...grpc.CallOption) (*NodeInfos, error) {
-	out := new(NodeInfos)
-	err := c.cc.Invoke(ctx, "/doogle.Doogle/FindNode", in, out, opts...)
-	if err != nil {
+func _Doogle_StoreCompletion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StoreCompletionRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(DoogleServer).StoreCompletion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/doogle.Doogle/StoreCompletion",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DoogleServer).StoreCompletion(ctx, req.(*StoreCompletionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *doogleClient) PingWithCertificate(ctx context.Context, in *NodeCertificate, opts ...grpc.CallOption) (*NodeCertificate, error) {
-	out := new(NodeCertificate)
-	err := c.cc.Invoke(ctx, "/doogle.Doogle/PingWithCertificate", in, out, opts...)
-	if err != nil {
+func _Doogle_FindCompletion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindCompletionRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(DoogleServer).FindCompletion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/doogle.Doogle/FindCompletion",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DoogleServer).FindCompletion(ctx, req.(*FindCompletionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *doogleClient) Ping(ctx context.Context, in *StringMessage, opts ...grpc.CallOption) (*StringMessage, error) {
-	out := new(StringMessage)
-	err := c.cc.Invoke(ctx, "/doogle.Doogle/Ping", in, out, opts...)
-	if err != nil {
+func _Doogle_Ping_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StringMessage)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(DoogleServer).Ping(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/doogle.Doogle/Ping",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DoogleServer).Ping(ctx, req.(*StringMessage))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *doogleClient) PingTo(ctx context.Context, in *NodeInfo, opts ...grpc.CallOption) (*StringMessage, error) {
-	out := new(StringMessage)
-	err := c.cc.Invoke(ctx, "/doogle.Doogle/PingTo", in, out, opts...)
-	if err != nil {
+func _Doogle_PingTo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NodeInfo)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(DoogleServer).PingTo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/doogle.Doogle/PingTo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DoogleServer).PingTo(ctx, req.(*NodeInfo))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *doogleClient) GetIndex(ctx context.Context, in *StringMessage, opts ...grpc.CallOption) (*GetIndexReply, error) {
-	out := new(GetIndexReply)
-	err := c.cc.Invoke(ctx, "/doogle.Doogle/GetIndex", in, out, opts...)
-	if err != nil {
+func _Doogle_GetIndex_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StringMessage)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(DoogleServer).GetIndex(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/doogle.Doogle/GetIndex",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DoogleServer).GetIndex(ctx, req.(*StringMessage))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *doogleClient) PostUrl(ctx context.Context, in *StringMessage, opts ...grpc.CallOption) (*StringMessage, error) {
-	out := new(StringMessage)
-	err := c.cc.Invoke(ctx, "/doogle.Doogle/PostUrl", in, out, opts...)
-	if err != nil {
-		return nil, err
+func _Doogle_SearchStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StringMessage)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
 	}
-	return out, nil
+	return srv.(DoogleServer).SearchStream(m, &doogleSearchStreamServer{stream})
 }
 
-// DoogleServer is the server API for Doogle service.
-type DoogleServer interface {
-	// Store give index
-	StoreItem(context.Context, *StoreItemRequest) (*Empty, error)
-	// find index of given key
-	FindIndex(context.Context, *FindIndexRequest) (*FindIndexReply, error)
-	// return k closed nodes to given address
-	FindNode(context.Context, *FindNodeRequest) (*NodeInfos, error)
-	// health check
-	PingWithCertificate(context.Context, *NodeCertificate) (*NodeCertificate, error)
-	// the following endpoints can be accessed from outside of the network.
-	Ping(context.Context, *StringMessage) (*StringMessage, error)
-	PingTo(context.Context, *NodeInfo) (*StringMessage, error)
-	GetIndex(context.Context, *StringMessage) (*GetIndexReply, error)
-	PostUrl(context.Context, *StringMessage) (*StringMessage, error)
+type Doogle_SearchStreamServer interface {
+	Send(*SearchStreamReply) error
+	grpc.ServerStream
 }
 
-func RegisterDoogleServer(s *grpc.Server, srv DoogleServer) {
-	s.RegisterService(&_Doogle_serviceDesc, srv)
+type doogleSearchStreamServer struct {
+	grpc.ServerStream
 }
 
-func _Doogle_StoreItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(StoreItemRequest)
+func (x *doogleSearchStreamServer) Send(m *SearchStreamReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Doogle_PostUrl_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StringMessage)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DoogleServer).StoreItem(ctx, in)
+		return srv.(DoogleServer).PostUrl(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/doogle.Doogle/StoreItem",
+		FullMethod: "/doogle.Doogle/PostUrl",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DoogleServer).StoreItem(ctx, req.(*StoreItemRequest))
+		return srv.(DoogleServer).PostUrl(ctx, req.(*StringMessage))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Doogle_FindIndex_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(FindIndexRequest)
+func _Doogle_Suggest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StringMessage)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DoogleServer).FindIndex(ctx, in)
+		return srv.(DoogleServer).Suggest(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/doogle.Doogle/FindIndex",
+		FullMethod: "/doogle.Doogle/Suggest",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DoogleServer).FindIndex(ctx, req.(*FindIndexRequest))
+		return srv.(DoogleServer).Suggest(ctx, req.(*StringMessage))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Doogle_FindNode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(FindNodeRequest)
+func _Doogle_GetCacheStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DoogleServer).FindNode(ctx, in)
+		return srv.(DoogleServer).GetCacheStats(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/doogle.Doogle/FindNode",
+		FullMethod: "/doogle.Doogle/GetCacheStats",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DoogleServer).FindNode(ctx, req.(*FindNodeRequest))
+		return srv.(DoogleServer).GetCacheStats(ctx, req.(*Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Doogle_PingWithCertificate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(NodeCertificate)
+func _Doogle_GetRankStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StringMessage)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DoogleServer).PingWithCertificate(ctx, in)
+		return srv.(DoogleServer).GetRankStats(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/doogle.Doogle/PingWithCertificate",
+		FullMethod: "/doogle.Doogle/GetRankStats",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DoogleServer).PingWithCertificate(ctx, req.(*NodeCertificate))
+		return srv.(DoogleServer).GetRankStats(ctx, req.(*StringMessage))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Doogle_Ping_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _Doogle_GetRankHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(StringMessage)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DoogleServer).Ping(ctx, in)
+		return srv.(DoogleServer).GetRankHistory(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/doogle.Doogle/Ping",
+		FullMethod: "/doogle.Doogle/GetRankHistory",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DoogleServer).Ping(ctx, req.(*StringMessage))
+		return srv.(DoogleServer).GetRankHistory(ctx, req.(*StringMessage))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Doogle_PingTo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(NodeInfo)
+func _Doogle_Explain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExplainRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DoogleServer).PingTo(ctx, in)
+		return srv.(DoogleServer).Explain(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/doogle.Doogle/PingTo",
+		FullMethod: "/doogle.Doogle/Explain",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DoogleServer).PingTo(ctx, req.(*NodeInfo))
+		return srv.(DoogleServer).Explain(ctx, req.(*ExplainRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Doogle_GetIndex_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _Doogle_ExportGraph_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportGraphRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DoogleServer).ExportGraph(m, &doogleExportGraphServer{stream})
+}
+
+type Doogle_ExportGraphServer interface {
+	Send(*GraphChunk) error
+	grpc.ServerStream
+}
+
+type doogleExportGraphServer struct {
+	grpc.ServerStream
+}
+
+func (x *doogleExportGraphServer) Send(m *GraphChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Doogle_GetBacklinks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(StringMessage)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DoogleServer).GetIndex(ctx, in)
+		return srv.(DoogleServer).GetBacklinks(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/doogle.Doogle/GetIndex",
+		FullMethod: "/doogle.Doogle/GetBacklinks",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DoogleServer).GetIndex(ctx, req.(*StringMessage))
+		return srv.(DoogleServer).GetBacklinks(ctx, req.(*StringMessage))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Doogle_PostUrl_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _Doogle_Related_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(StringMessage)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DoogleServer).PostUrl(ctx, in)
+		return srv.(DoogleServer).Related(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/doogle.Doogle/PostUrl",
+		FullMethod: "/doogle.Doogle/Related",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DoogleServer).PostUrl(ctx, req.(*StringMessage))
+		return srv.(DoogleServer).Related(ctx, req.(*StringMessage))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -1031,10 +4357,46 @@ var _Doogle_serviceDesc = grpc.ServiceDesc{
 			MethodName: "StoreItem",
 			Handler:    _Doogle_StoreItem_Handler,
 		},
+		{
+			MethodName: "DeleteItem",
+			Handler:    _Doogle_DeleteItem_Handler,
+		},
+		{
+			MethodName: "StoreDocument",
+			Handler:    _Doogle_StoreDocument_Handler,
+		},
+		{
+			MethodName: "FindDocument",
+			Handler:    _Doogle_FindDocument_Handler,
+		},
+		{
+			MethodName: "StoreBacklink",
+			Handler:    _Doogle_StoreBacklink_Handler,
+		},
+		{
+			MethodName: "FindBacklinks",
+			Handler:    _Doogle_FindBacklinks_Handler,
+		},
+		{
+			MethodName: "Meet",
+			Handler:    _Doogle_Meet_Handler,
+		},
+		{
+			MethodName: "FindRankNeighbours",
+			Handler:    _Doogle_FindRankNeighbours_Handler,
+		},
+		{
+			MethodName: "FindCoLinks",
+			Handler:    _Doogle_FindCoLinks_Handler,
+		},
 		{
 			MethodName: "FindIndex",
 			Handler:    _Doogle_FindIndex_Handler,
 		},
+		{
+			MethodName: "CacheIndex",
+			Handler:    _Doogle_CacheIndex_Handler,
+		},
 		{
 			MethodName: "FindNode",
 			Handler:    _Doogle_FindNode_Handler,
@@ -1043,6 +4405,22 @@ var _Doogle_serviceDesc = grpc.ServiceDesc{
 			MethodName: "PingWithCertificate",
 			Handler:    _Doogle_PingWithCertificate_Handler,
 		},
+		{
+			MethodName: "StoreSpelling",
+			Handler:    _Doogle_StoreSpelling_Handler,
+		},
+		{
+			MethodName: "FindSpelling",
+			Handler:    _Doogle_FindSpelling_Handler,
+		},
+		{
+			MethodName: "StoreCompletion",
+			Handler:    _Doogle_StoreCompletion_Handler,
+		},
+		{
+			MethodName: "FindCompletion",
+			Handler:    _Doogle_FindCompletion_Handler,
+		},
 		{
 			MethodName: "Ping",
 			Handler:    _Doogle_Ping_Handler,
@@ -1059,7 +4437,46 @@ var _Doogle_serviceDesc = grpc.ServiceDesc{
 			MethodName: "PostUrl",
 			Handler:    _Doogle_PostUrl_Handler,
 		},
+		{
+			MethodName: "Suggest",
+			Handler:    _Doogle_Suggest_Handler,
+		},
+		{
+			MethodName: "GetCacheStats",
+			Handler:    _Doogle_GetCacheStats_Handler,
+		},
+		{
+			MethodName: "GetRankStats",
+			Handler:    _Doogle_GetRankStats_Handler,
+		},
+		{
+			MethodName: "GetRankHistory",
+			Handler:    _Doogle_GetRankHistory_Handler,
+		},
+		{
+			MethodName: "Explain",
+			Handler:    _Doogle_Explain_Handler,
+		},
+		{
+			MethodName: "GetBacklinks",
+			Handler:    _Doogle_GetBacklinks_Handler,
+		},
+		{
+			MethodName: "Related",
+			Handler:    _Doogle_Related_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SearchStream",
+			Handler:       _Doogle_SearchStream_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ExportGraph",
+			Handler:       _Doogle_ExportGraph_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "doogle.proto",
 }
@@ -6,6 +6,7 @@ import (
 	"net"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
@@ -22,17 +23,48 @@ var (
 	difficulty int
 	queueCap   int
 	numWorker  int
+	userAgent  string
+	politeness = crawler.DefaultPoliteness()
+
+	meetingInterval time.Duration
+	worldNodePath   string
+
+	rankerCfg      = node.DefaultRankerConfig()
+	trustedDomains string
+	rankAgg        = node.DefaultRankAggregation()
 )
 
 func main() {
 	// initialize logger
 	logger := logrus.New()
 
+	// subcommands
+	if len(os.Args) > 1 && os.Args[1] == "export" {
+		if err := runExport(os.Args[2:]); err != nil {
+			logger.Fatalf("failed to export graph: %v", err)
+		}
+		return
+	}
+
 	// parse params
 	flag.StringVar(&port, "p", "", "port for node")
 	flag.IntVar(&difficulty, "d", 0, "difficulty for cryptographic puzzle")
 	flag.IntVar(&queueCap, "c", 0, "crawler's channel capacity")
 	flag.IntVar(&numWorker, "w", 0, "number of crawler's worker")
+	flag.IntVar(&politeness.MaxConnsPerHost, "hostconns", politeness.MaxConnsPerHost, "max number of concurrent requests to a host")
+	flag.DurationVar(&politeness.MinDelay, "hostdelay", politeness.MinDelay, "min interval between requests to a host")
+	flag.DurationVar(&politeness.MaxDelay, "maxhostdelay", politeness.MaxDelay, "max interval between requests to a host, which also caps Crawl-delay")
+	flag.StringVar(&userAgent, "ua", crawler.DefaultUserAgent, "user-agent token of the crawler matched against robots.txt")
+	flag.DurationVar(&meetingInterval, "m", time.Minute, "interval of meetings with random nodes for PageRank estimation")
+	flag.StringVar(&worldNodePath, "s", "", "file persisting the knowledge of world node")
+	flag.StringVar(&rankerCfg.Algorithm, "ranker", rankerCfg.Algorithm,
+		"ranking algorithm: pagerank, hits-authority, hits-hub or personalized-pagerank")
+	flag.Float64Var(&rankerCfg.Damping, "damping", rankerCfg.Damping, "damping factor of PageRank")
+	flag.Float64Var(&rankerCfg.Tolerance, "tolerance", rankerCfg.Tolerance, "convergence tolerance of the ranking algorithm")
+	flag.IntVar(&rankerCfg.MaxIterations, "iterations", rankerCfg.MaxIterations, "max number of iterations of the ranking algorithm")
+	flag.StringVar(&trustedDomains, "trusted", "", "comma separated domains from which personalized PageRank flows")
+	flag.Float64Var(&rankAgg.Smoothing, "smoothing", rankAgg.Smoothing, "weight of newly computed ranks in the moving average")
+	flag.DurationVar(&rankAgg.HalfLife, "halflife", rankAgg.HalfLife, "half-life of ranks not recomputed (0 for no decay)")
 	flag.Parse()
 
 	// listen port
@@ -42,7 +74,7 @@ func main() {
 	}
 
 	// create crawler
-	cr, err := crawler.NewCrawler(queueCap, numWorker, logger)
+	cr, err := crawler.NewCrawler(queueCap, numWorker, userAgent, politeness, logger)
 	if err != nil {
 		logger.Fatalf("failed to initialize crawler: %v", err)
 	}
@@ -55,6 +87,21 @@ func main() {
 
 	defer srv.CloseConnections()
 
+	// set ranker
+	if trustedDomains != "" {
+		rankerCfg.TrustedDomains = strings.Split(trustedDomains, ",")
+	}
+
+	ranker, err := node.NewRanker(rankerCfg)
+	if err != nil {
+		logger.Fatalf("failed to create ranker: %v", err)
+	}
+	srv.SetRanker(ranker)
+
+	if err := srv.SetRankAggregation(rankAgg); err != nil {
+		logger.Fatalf("failed to set rank aggregation: %v", err)
+	}
+
 	logger.Infof("node created: doogleAddress=%v\n", hex.EncodeToString(srv.DAddr[:]))
 
 	// register node
@@ -71,7 +118,12 @@ func main() {
 		}
 	}()
 
-	srv.StartPageRankComputer(numWorker)
+	srv.StartRankScheduler()
+	srv.StartPublisher(numWorker)
+	srv.StartTombstoneCollector()
+	if err := srv.StartMeetings(meetingInterval, worldNodePath); err != nil {
+		logger.Fatalf("failed to start meetings: %v", err)
+	}
 
 	// make gRPC connection to doogle node for crawler service
 	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithInsecure())
@@ -0,0 +1,126 @@
+package node
+
+import (
+	"context"
+	"crypto/sha1"
+	"math"
+	"time"
+
+	"github.com/mathetake/doogle/grpc"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// max number of rank computations kept in the history of each item
+const maxRankHistory = 16
+
+// RankAggregation configures how the computed ranks of an item are aggregated into its localRank
+type RankAggregation struct {
+	// weight of the newly computed rank in the exponentially weighted moving average
+	Smoothing float64
+
+	// time in which the difference between the rank and the prior halves unless it is recomputed.
+	// zero means no decay
+	HalfLife time.Duration
+}
+
+func DefaultRankAggregation() RankAggregation {
+	return RankAggregation{Smoothing: 0.5, HalfLife: time.Hour}
+}
+
+// SetRankAggregation replaces the way of aggregating computed ranks
+func (n *Node) SetRankAggregation(a RankAggregation) error {
+	if a.Smoothing <= 0 || a.Smoothing > 1 {
+		return errors.Errorf("smoothing must be in (0, 1]: %v", a.Smoothing)
+	}
+
+	if a.HalfLife < 0 {
+		return errors.Errorf("negative half-life: %v", a.HalfLife)
+	}
+
+	n.rankerMux.Lock()
+	defer n.rankerMux.Unlock()
+	n.rankAggregation = a
+	return nil
+}
+
+func (n *Node) getRankAggregation() RankAggregation {
+	n.rankerMux.Lock()
+	defer n.rankerMux.Unlock()
+	return n.rankAggregation
+}
+
+type rankRecord struct {
+	computedAt int64
+	rank       float64
+	localRank  float64
+}
+
+// decayedRank returns localRank decayed toward the prior by the time elapsed since the last computation.
+// the caller must hold the lock of the item
+func (it *item) decayedRank(now time.Time, halfLife time.Duration) float64 {
+	if halfLife <= 0 || it.rankComputedAt == 0 {
+		return it.localRank
+	}
+
+	elapsed := now.Sub(time.Unix(0, it.rankComputedAt))
+	if elapsed <= 0 {
+		return it.localRank
+	}
+
+	w := math.Pow(0.5, float64(elapsed)/float64(halfLife))
+	return it.rankPrior + (it.localRank-it.rankPrior)*w
+}
+
+// updateRank aggregates the newly computed rank into localRank with the exponentially weighted moving average.
+// `prior` is the rank which the item would have without any link. the caller must hold the lock of the item
+func (it *item) updateRank(rank, prior float64, now time.Time, a RankAggregation) {
+	if it.rankComputedCount == 0 {
+		it.localRank = rank
+	} else {
+		it.localRank = a.Smoothing*rank + (1-a.Smoothing)*it.decayedRank(now, a.HalfLife)
+	}
+
+	it.rankComputedCount++
+	it.rankComputedAt = now.UnixNano()
+	it.rankPrior = prior
+
+	it.rankHistory = append(it.rankHistory, rankRecord{computedAt: it.rankComputedAt, rank: rank, localRank: it.localRank})
+	if len(it.rankHistory) > maxRankHistory {
+		it.rankHistory = it.rankHistory[len(it.rankHistory)-maxRankHistory:]
+	}
+}
+
+// currentRank returns the rank of the item decayed until now
+func (n *Node) currentRank(it *item) float64 {
+	halfLife := n.getRankAggregation().HalfLife
+
+	it.mux.Lock()
+	defer it.mux.Unlock()
+	return it.decayedRank(time.Now(), halfLife)
+}
+
+func (n *Node) GetRankHistory(ctx context.Context, in *doogle.StringMessage) (*doogle.RankHistory, error) {
+	h := sha1.Sum([]byte(normalizeURL(in.Message)))
+	raw, ok := n.items.Load(doogleAddressStr(h[:]))
+	if !ok {
+		return nil, status.Error(codes.NotFound, "item not found")
+	}
+
+	it := raw.(*item)
+	ret := &doogle.RankHistory{LocalRank: n.currentRank(it)}
+
+	it.mux.Lock()
+	defer it.mux.Unlock()
+
+	ret.Prior = it.rankPrior
+	for _, r := range it.rankHistory {
+		ret.Records = append(ret.Records, &doogle.RankRecord{
+			ComputedAt: r.computedAt,
+			Rank:       r.rank,
+			LocalRank:  r.localRank,
+		})
+	}
+	return ret, nil
+}
@@ -0,0 +1,116 @@
+package node
+
+import (
+	"context"
+	"crypto/sha1"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/mathetake/doogle/grpc"
+	"gotest.tools/assert"
+)
+
+func TestItem_decayedRank(t *testing.T) {
+	now := time.Now()
+	it := &item{localRank: 0.5, rankPrior: 0.1, rankComputedAt: now.Add(-2 * time.Hour).UnixNano()}
+
+	for i, cc := range []struct {
+		halfLife time.Duration
+		exp      float64
+	}{
+		{halfLife: 0, exp: 0.5},
+		{halfLife: 2 * time.Hour, exp: 0.3},
+		{halfLife: time.Hour, exp: 0.2},
+	} {
+		c := cc
+		t.Run(fmt.Sprintf("%d-th case", i), func(t *testing.T) {
+			assert.Assert(t, math.Abs(c.exp-it.decayedRank(now, c.halfLife)) < 1e-9)
+		})
+	}
+
+	// never computed
+	assert.Equal(t, 0.0, (&item{rankPrior: 0.1}).decayedRank(now, time.Hour))
+}
+
+func TestItem_updateRank(t *testing.T) {
+	now := time.Now()
+	a := RankAggregation{Smoothing: 0.5, HalfLife: time.Hour}
+	it := &item{}
+
+	for i, cc := range []struct {
+		rank    float64
+		elapsed time.Duration
+		exp     float64
+	}{
+		// the first computation is taken as it is
+		{rank: 0.4, exp: 0.4},
+		{rank: 0.2, exp: 0.3},
+		{rank: 0.2, exp: 0.25},
+		// decayed to 0.1 + (0.25 - 0.1) / 2 before the update
+		{rank: 0.1, elapsed: time.Hour, exp: 0.1375},
+	} {
+		c := cc
+		t.Run(fmt.Sprintf("%d-th case", i), func(t *testing.T) {
+			now = now.Add(c.elapsed)
+			it.updateRank(c.rank, 0.1, now, a)
+			assert.Assert(t, math.Abs(c.exp-it.localRank) < 1e-9)
+			assert.Equal(t, now.UnixNano(), it.rankComputedAt)
+		})
+	}
+
+	for i := 0; i < maxRankHistory; i++ {
+		it.updateRank(0.1, 0.1, now, a)
+	}
+	assert.Equal(t, maxRankHistory, len(it.rankHistory))
+	assert.Equal(t, float64(4+maxRankHistory), it.rankComputedCount)
+}
+
+func TestNode_SetRankAggregation(t *testing.T) {
+	node, err := NewNode(1, "doogle", logger, nil, 10)
+	if err != nil {
+		t.Fatalf("NewNode failed: %v", err)
+	}
+
+	assert.Assert(t, node.SetRankAggregation(RankAggregation{Smoothing: 0}) != nil)
+	assert.Assert(t, node.SetRankAggregation(RankAggregation{Smoothing: 1.5}) != nil)
+	assert.Assert(t, node.SetRankAggregation(RankAggregation{Smoothing: 0.5, HalfLife: -time.Second}) != nil)
+
+	a := RankAggregation{Smoothing: 1}
+	assert.Equal(t, nil, node.SetRankAggregation(a))
+	assert.Equal(t, a, node.getRankAggregation())
+}
+
+func TestNode_GetRankHistory(t *testing.T) {
+	resetRoutingTable()
+	defer resetRoutingTable()
+	resetDHT()
+	defer resetDHT()
+
+	srv := testServers[0].node
+	for _, url := range []string{"url1", "url2"} {
+		_, err := srv.StoreItem(context.Background(), &doogle.StoreItemRequest{
+			Certificate: srv.certificate, Url: url, Index: "doogle", EdgeURLs: []string{"url1"},
+		})
+		assert.Equal(t, nil, err)
+	}
+
+	_, err := srv.GetRankHistory(context.Background(), &doogle.StringMessage{Message: "url1"})
+	assert.Equal(t, nil, err)
+
+	h := sha1.Sum([]byte(normalizeURL("url1")))
+	for i := 0; i < 2; i++ {
+		srv.rankScheduler.markItem(doogleAddressStr(h[:]), storePriority)
+		srv.runRankScheduler()
+	}
+
+	res, err := srv.GetRankHistory(context.Background(), &doogle.StringMessage{Message: "url1"})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 2, len(res.Records))
+	assert.Assert(t, res.Records[0].ComputedAt <= res.Records[1].ComputedAt)
+	assert.Assert(t, res.LocalRank > res.Prior)
+
+	_, err = srv.GetRankHistory(context.Background(), &doogle.StringMessage{Message: "unknown"})
+	assert.Assert(t, err != nil)
+}
@@ -0,0 +1,141 @@
+package node
+
+import (
+	"context"
+	"crypto/sha1"
+	"sort"
+	"sync"
+
+	"github.com/mathetake/doogle/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type backlinkValue struct {
+	// type: map{source url -> *doogle.Backlink}
+	backlinks map[string]*doogle.Backlink
+	mux       sync.Mutex
+}
+
+func (n *Node) StoreBacklink(ctx context.Context, in *doogle.StoreBacklinkRequest) (*doogle.Empty, error) {
+	if !n.isValidSender(in.Certificate) {
+		return nil, status.Error(codes.InvalidArgument, "invalid certificate")
+	}
+
+	if in.Backlink == nil || in.Backlink.Url == "" || in.Target == "" {
+		return nil, status.Error(codes.InvalidArgument, "empty backlink")
+	}
+
+	n.storeBacklink(normalizeURL(in.Target), in.Backlink)
+	return &doogle.Empty{}, nil
+}
+
+// storeBacklink keeps the latest backlink from each page, including deleted ones
+func (n *Node) storeBacklink(target string, bl *doogle.Backlink) {
+	h := sha1.Sum([]byte(target))
+	actual, _ := n.backlinks.LoadOrStore(doogleAddressStr(h[:]), &backlinkValue{
+		backlinks: map[string]*doogle.Backlink{},
+		mux:       sync.Mutex{},
+	})
+
+	bv := actual.(*backlinkValue)
+	bv.mux.Lock()
+	defer bv.mux.Unlock()
+
+	if prev, ok := bv.backlinks[bl.Url]; !ok || bl.Timestamp >= prev.Timestamp {
+		bv.backlinks[bl.Url] = bl
+	}
+}
+
+func (n *Node) FindBacklinks(ctx context.Context, in *doogle.FindBacklinksRequest) (*doogle.Backlinks, error) {
+	if !n.isValidSender(in.Certificate) {
+		return nil, status.Error(codes.InvalidArgument, "invalid certificate")
+	}
+	return &doogle.Backlinks{Backlinks: n.findLocalBacklinks(doogleAddressStr(in.DoogleAddress))}, nil
+}
+
+func (n *Node) findLocalBacklinks(addr doogleAddressStr) []*doogle.Backlink {
+	raw, ok := n.backlinks.Load(addr)
+	if !ok {
+		return nil
+	}
+
+	bv := raw.(*backlinkValue)
+	bv.mux.Lock()
+	defer bv.mux.Unlock()
+
+	ret := make([]*doogle.Backlink, 0, len(bv.backlinks))
+	for _, bl := range bv.backlinks {
+		ret = append(ret, bl)
+	}
+	return ret
+}
+
+// store the backlinks from the page on `source` on the nodes closest to each of `targets`.
+// anchorTerms maps the targets to the terms of their anchor texts
+func (n *Node) publishBacklinks(ctx context.Context, source, title string, targets []string, anchorTerms map[string][]string, ts int64, deleted bool) {
+	for _, target := range targets {
+		bl := &doogle.Backlink{Url: source, Title: title, Timestamp: ts, Deleted: deleted, AnchorTerms: anchorTerms[target]}
+		req := &doogle.StoreBacklinkRequest{Certificate: n.certificate, Target: target, Backlink: bl}
+
+		addr := sha1.Sum([]byte(target))
+		if !n.forEachClosestNode(ctx, addr, func(ctx context.Context, c doogle.DoogleClient) error {
+			_, err := c.StoreBacklink(ctx, req)
+			return err
+		}) {
+			// if no node is closer, store backlink into its own table
+			n.storeBacklink(target, bl)
+		}
+	}
+}
+
+// findBacklinks returns the pages currently linking to the url among its own and the closest nodes
+func (n *Node) findBacklinks(ctx context.Context, url string) []*doogle.Backlink {
+	addr := sha1.Sum([]byte(url))
+
+	latest := map[string]*doogle.Backlink{}
+	var mux sync.Mutex
+	merge := func(bls []*doogle.Backlink) {
+		mux.Lock()
+		defer mux.Unlock()
+		for _, bl := range bls {
+			if prev, ok := latest[bl.Url]; !ok || bl.Timestamp > prev.Timestamp {
+				latest[bl.Url] = bl
+			}
+		}
+	}
+
+	merge(n.findLocalBacklinks(doogleAddressStr(addr[:])))
+
+	n.forEachClosestNode(ctx, addr, func(ctx context.Context, c doogle.DoogleClient) error {
+		res, err := c.FindBacklinks(ctx, &doogle.FindBacklinksRequest{
+			Certificate:   n.certificate,
+			DoogleAddress: addr[:],
+		})
+		if err != nil {
+			return err
+		}
+		merge(res.Backlinks)
+		return nil
+	})
+
+	ret := make([]*doogle.Backlink, 0, len(latest))
+	for _, bl := range latest {
+		if !bl.Deleted {
+			ret = append(ret, bl)
+		}
+	}
+
+	sort.Slice(ret, func(i, j int) bool { return ret[i].Url < ret[j].Url })
+	return ret
+}
+
+func (n *Node) GetBacklinks(ctx context.Context, in *doogle.StringMessage) (*doogle.Backlinks, error) {
+	if in.Message == "" {
+		return nil, status.Error(codes.InvalidArgument, "empty url")
+	}
+
+	ctx, cancel := withQueryTimeout(ctx, 0)
+	defer cancel()
+	return &doogle.Backlinks{Backlinks: n.findBacklinks(ctx, normalizeURL(in.Message))}, nil
+}
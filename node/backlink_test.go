@@ -0,0 +1,124 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/mathetake/doogle/crawler"
+	"github.com/mathetake/doogle/grpc"
+	"gotest.tools/assert"
+)
+
+func TestNode_storeBacklink(t *testing.T) {
+	resetRoutingTable()
+	defer resetRoutingTable()
+	resetDHT()
+	defer resetDHT()
+
+	srv := testServers[0].node
+
+	for i, cc := range []struct {
+		bl      *doogle.Backlink
+		expUrls []string
+	}{
+		{bl: &doogle.Backlink{Url: "url1", Timestamp: 10}, expUrls: []string{"url1"}},
+		{bl: &doogle.Backlink{Url: "url2", Timestamp: 10}, expUrls: []string{"url1", "url2"}},
+		{bl: &doogle.Backlink{Url: "url1", Timestamp: 20, Deleted: true}, expUrls: []string{"url2"}},
+		{
+			// older than the deletion
+			bl:      &doogle.Backlink{Url: "url1", Timestamp: 15},
+			expUrls: []string{"url2"},
+		},
+		{bl: &doogle.Backlink{Url: "url1", Timestamp: 30}, expUrls: []string{"url1", "url2"}},
+	} {
+		c := cc
+		t.Run(fmt.Sprintf("%d-th case", i), func(t *testing.T) {
+			_, err := srv.StoreBacklink(context.Background(), &doogle.StoreBacklinkRequest{
+				Certificate: srv.certificate,
+				Target:      "target",
+				Backlink:    c.bl,
+			})
+			assert.Equal(t, nil, err)
+
+			res, err := srv.GetBacklinks(context.Background(), &doogle.StringMessage{Message: "target"})
+			assert.Equal(t, nil, err)
+			assert.Equal(t, len(c.expUrls), len(res.Backlinks))
+			for j, bl := range res.Backlinks {
+				assert.Equal(t, c.expUrls[j], bl.Url)
+			}
+		})
+	}
+}
+
+func TestNode_PostUrl_backlinks(t *testing.T) {
+	resetRoutingTable()
+	defer resetRoutingTable()
+	resetDHT()
+	defer resetDHT()
+
+	srv := testServers[0].node
+	defer func() { srv.crawler = &mockCrawler{} }()
+
+	tokens := []*crawler.Token{{Term: "doogle"}}
+	for i, cc := range []struct {
+		url    string
+		cr     *mockCrawler
+		expFoo []string
+		expBar []string
+	}{
+		{
+			url:    "https://a.com",
+			cr:     &mockCrawler{title: "a", tokens: tokens, edgeURLs: []string{"foo.com", "bar.com"}},
+			expFoo: []string{"https://a.com/"},
+			expBar: []string{"https://a.com/"},
+		},
+		{
+			url:    "https://b.com",
+			cr:     &mockCrawler{title: "b", tokens: tokens, edgeURLs: []string{"foo.com"}},
+			expFoo: []string{"https://a.com/", "https://b.com/"},
+			expBar: []string{"https://a.com/"},
+		},
+		{
+			// a.com no longer links to bar.com
+			url:    "https://a.com",
+			cr:     &mockCrawler{title: "a", tokens: tokens, edgeURLs: []string{"foo.com"}},
+			expFoo: []string{"https://a.com/", "https://b.com/"},
+			expBar: []string{},
+		},
+		{
+			url:    "https://b.com",
+			cr:     &mockCrawler{err: crawler.ErrPageGone},
+			expFoo: []string{"https://a.com/"},
+			expBar: []string{},
+		},
+	} {
+		c := cc
+		t.Run(fmt.Sprintf("%d-th case", i), func(t *testing.T) {
+			srv.crawler = c.cr
+			_, err := srv.PostUrl(context.Background(), &doogle.StringMessage{Message: c.url})
+			assert.Equal(t, nil, err)
+
+			for target, exp := range map[string][]string{"foo.com": c.expFoo, "bar.com": c.expBar} {
+				res, err := srv.GetIndex(context.Background(), &doogle.StringMessage{Message: "link:" + target})
+				assert.Equal(t, nil, err)
+
+				actual := []string{}
+				for _, it := range res.Items {
+					actual = append(actual, it.Url)
+				}
+				assert.DeepEqual(t, exp, actual)
+			}
+		})
+	}
+
+	// the term combined with link: is filtered by outgoing links
+	res, err := srv.GetIndex(context.Background(), &doogle.StringMessage{Message: "doogle link:foo.com"})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 1, len(res.Items))
+	assert.Equal(t, "https://a.com/", res.Items[0].Url)
+
+	res, err = srv.GetIndex(context.Background(), &doogle.StringMessage{Message: "link:foo.com site:b.com"})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 0, len(res.Items))
+}
@@ -0,0 +1,153 @@
+package node
+
+import (
+	"container/list"
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mathetake/doogle/grpc"
+)
+
+const (
+	queryCacheSize = 1024
+	queryCacheTTL  = time.Minute
+)
+
+type queryCacheEntry struct {
+	key       string
+	termAddr  doogleAddressStr
+	rep       *doogle.GetIndexReply
+	expiresAt time.Time
+}
+
+// queryCache is a LRU cache of query results with TTL
+type queryCache struct {
+	size int
+	ttl  time.Duration
+
+	// front is the most recently used
+	ll      *list.List
+	entries map[string]*list.Element
+
+	// incremented on every invalidation in order not to store results computed before it
+	generation uint64
+
+	// generations at which the terms are invalidated last, so that invalidations of other terms don't refuse results.
+	// it's cleared beyond the size of the cache, and then the results older than `floor` are refused
+	// type: map{doogleAddressStr -> uint64}
+	invalidatedAt map[doogleAddressStr]uint64
+	floor         uint64
+
+	hits, misses int64
+	mux          sync.Mutex
+}
+
+func newQueryCache(size int, ttl time.Duration) *queryCache {
+	return &queryCache{
+		size:          size,
+		ttl:           ttl,
+		ll:            list.New(),
+		entries:       map[string]*list.Element{},
+		invalidatedAt: map[doogleAddressStr]uint64{},
+	}
+}
+
+// queryCacheKey returns the normalized form of the query consisting of the term and the filter
+func queryCacheKey(term string, filter *doogle.Filter) string {
+	fs := make([]string, 0, len(filter.Fields))
+	for _, f := range filter.Fields {
+		fs = append(fs, f.String())
+	}
+	sort.Strings(fs)
+
+	return strings.Join([]string{
+		term, strings.Join(fs, ","), filter.Site, filter.Inurl, filter.Lang, filter.Link,
+	}, "\x00")
+}
+
+// get returns the cached reply and the current generation of the cache
+func (c *queryCache) get(key string) (*doogle.GetIndexReply, uint64, bool) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok {
+		c.misses++
+		return nil, c.generation, false
+	}
+
+	ent := e.Value.(*queryCacheEntry)
+	if time.Now().After(ent.expiresAt) {
+		c.remove(e)
+		c.misses++
+		return nil, c.generation, false
+	}
+
+	c.ll.MoveToFront(e)
+	c.hits++
+	return ent.rep, c.generation, true
+}
+
+// put stores the reply unless the term is invalidated after the generation `gen`
+func (c *queryCache) put(key string, termAddr doogleAddressStr, rep *doogle.GetIndexReply, gen uint64) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	if gen < c.floor || gen < c.invalidatedAt[termAddr] {
+		return
+	}
+
+	if e, ok := c.entries[key]; ok {
+		c.remove(e)
+	}
+
+	c.entries[key] = c.ll.PushFront(&queryCacheEntry{
+		key:       key,
+		termAddr:  termAddr,
+		rep:       rep,
+		expiresAt: time.Now().Add(c.ttl),
+	})
+
+	for c.ll.Len() > c.size {
+		c.remove(c.ll.Back())
+	}
+}
+
+// invalidate removes the entries of queries on the term whose address is `termAddr`
+func (c *queryCache) invalidate(termAddr doogleAddressStr) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	c.generation++
+	if len(c.invalidatedAt) >= c.size {
+		c.invalidatedAt, c.floor = map[doogleAddressStr]uint64{}, c.generation
+	}
+	c.invalidatedAt[termAddr] = c.generation
+
+	for e := c.ll.Front(); e != nil; {
+		next := e.Next()
+		if e.Value.(*queryCacheEntry).termAddr == termAddr {
+			c.remove(e)
+		}
+		e = next
+	}
+}
+
+// remove the element. the caller must hold the lock
+func (c *queryCache) remove(e *list.Element) {
+	c.ll.Remove(e)
+	delete(c.entries, e.Value.(*queryCacheEntry).key)
+}
+
+func (c *queryCache) stats() *doogle.CacheStats {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	return &doogle.CacheStats{Hits: c.hits, Misses: c.misses, Size: int32(c.ll.Len())}
+}
+
+func (n *Node) GetCacheStats(ctx context.Context, in *doogle.Empty) (*doogle.CacheStats, error) {
+	return n.queryCache.stats(), nil
+}
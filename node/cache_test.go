@@ -0,0 +1,151 @@
+package node
+
+import (
+	"context"
+	"crypto/sha1"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mathetake/doogle/grpc"
+	"gotest.tools/assert"
+)
+
+func TestQueryCacheKey(t *testing.T) {
+	for i, cc := range []struct {
+		q1, q2 string
+		equal  bool
+	}{
+		{q1: "doogle", q2: " doogle ", equal: true},
+		{q1: "doogle lang:en site:a.com", q2: "site:A.com doogle lang:EN timeout:1s", equal: true},
+		{q1: "doogle", q2: "title:doogle", equal: false},
+		{q1: "doogle", q2: "google", equal: false},
+		{q1: "doogle site:a.com", q2: "doogle inurl:a.com", equal: false},
+	} {
+		c := cc
+		t.Run(fmt.Sprintf("%d-th case", i), func(t *testing.T) {
+			t1, f1, _ := parseQuery(c.q1)
+			t2, f2, _ := parseQuery(c.q2)
+			assert.Equal(t, c.equal, queryCacheKey(t1, f1) == queryCacheKey(t2, f2))
+		})
+	}
+}
+
+func TestQueryCache(t *testing.T) {
+	c := newQueryCache(2, time.Hour)
+	rep := &doogle.GetIndexReply{}
+
+	_, gen, ok := c.get("a")
+	assert.Equal(t, false, ok)
+	c.put("a", "addrA", rep, gen)
+	c.put("b", "addrB", rep, gen)
+
+	// "a" becomes the most recently used, so "b" is evicted
+	_, _, ok = c.get("a")
+	assert.Equal(t, true, ok)
+	c.put("c", "addrC", rep, gen)
+
+	_, _, ok = c.get("b")
+	assert.Equal(t, false, ok)
+	_, _, ok = c.get("c")
+	assert.Equal(t, true, ok)
+
+	c.invalidate("addrA")
+	_, _, ok = c.get("a")
+	assert.Equal(t, false, ok)
+	_, _, ok = c.get("c")
+	assert.Equal(t, true, ok)
+
+	// results computed before the invalidation are not stored
+	c.put("a", "addrA", rep, gen)
+	_, _, ok = c.get("a")
+	assert.Equal(t, false, ok)
+
+	// while the ones of other terms are
+	c.put("b", "addrB", rep, gen)
+	_, _, ok = c.get("b")
+	assert.Equal(t, true, ok)
+
+	assert.DeepEqual(t, &doogle.CacheStats{Hits: 4, Misses: 4, Size: 2}, c.stats())
+
+	// the invalidations beyond the size refuse all the older results
+	c.invalidate("addrC")
+	c.invalidate("addrD")
+	c.put("e", "addrE", rep, gen)
+	_, _, ok = c.get("e")
+	assert.Equal(t, false, ok)
+
+	// expired
+	c = newQueryCache(2, 0)
+	c.put("a", "addrA", rep, 0)
+	_, _, ok = c.get("a")
+	assert.Equal(t, false, ok)
+	assert.Equal(t, int32(0), c.stats().Size)
+}
+
+func TestNode_GetIndex_cache(t *testing.T) {
+	resetRoutingTable()
+	defer resetRoutingTable()
+	resetDHT()
+	defer resetDHT()
+
+	srv := testServers[0].node
+	ct := srv.certificate
+
+	_, err := srv.StoreItem(context.Background(), &doogle.StoreItemRequest{Certificate: ct, Url: "url1", Index: "doogle"})
+	assert.Equal(t, nil, err)
+
+	for i, cc := range []struct {
+		store     *doogle.StoreItemRequest
+		expUrls   []string
+		expHits   int64
+		expMisses int64
+	}{
+		{expUrls: []string{"url1"}, expMisses: 1},
+		{expUrls: []string{"url1"}, expHits: 1, expMisses: 1},
+		{
+			// index of another term doesn't invalidate the cache
+			store:   &doogle.StoreItemRequest{Certificate: ct, Url: "url2", Index: "google"},
+			expUrls: []string{"url1"}, expHits: 2, expMisses: 1,
+		},
+		{
+			store:   &doogle.StoreItemRequest{Certificate: ct, Url: "url2", Index: "doogle"},
+			expUrls: []string{"url1", "url2"}, expHits: 2, expMisses: 2,
+		},
+		{
+			// unchanged index doesn't invalidate the cache
+			store:   &doogle.StoreItemRequest{Certificate: ct, Url: "url2", Index: "doogle"},
+			expUrls: []string{"url1", "url2"}, expHits: 3, expMisses: 2,
+		},
+		{
+			store: &doogle.StoreItemRequest{
+				Certificate: ct, Url: "url2", Index: "doogle", Fields: []doogle.Field{doogle.Field_TITLE},
+			},
+			expUrls: []string{"url2", "url1"}, expHits: 3, expMisses: 3,
+		},
+	} {
+		c := cc
+		t.Run(fmt.Sprintf("%d-th case", i), func(t *testing.T) {
+			if c.store != nil {
+				_, err := srv.StoreItem(context.Background(), c.store)
+				assert.Equal(t, nil, err)
+			}
+
+			res, err := srv.GetIndex(context.Background(), &doogle.StringMessage{Message: "doogle"})
+			assert.Equal(t, nil, err)
+			assert.Equal(t, len(c.expUrls), len(res.Items))
+			for j, it := range res.Items {
+				assert.Equal(t, normalizeURL(c.expUrls[j]), it.Url)
+			}
+
+			stats, err := srv.GetCacheStats(context.Background(), &doogle.Empty{})
+			assert.Equal(t, nil, err)
+			assert.Equal(t, c.expHits, stats.Hits)
+			assert.Equal(t, c.expMisses, stats.Misses)
+		})
+	}
+
+	h := sha1.Sum([]byte("doogle"))
+	srv.queryCache.invalidate(doogleAddressStr(h[:]))
+	assert.Equal(t, int32(0), srv.queryCache.stats().Size)
+}
@@ -0,0 +1,230 @@
+package node
+
+import (
+	"context"
+	"crypto/sha1"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mathetake/doogle/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// prefix of completion keys in order not to collide with indices
+	completionKeyPrefix = "completion:"
+
+	// completions are stored on the prefixes of terms with these lengths
+	minCompletionPrefixLen = 2
+	maxCompletionPrefixLen = 4
+
+	maxNumCompletionsPerKey = 100
+	maxNumCompletions       = 10
+
+	// score added to a term each time it is searched
+	queryCompletionScore = 1.0
+
+	// max score added by a single request from other nodes
+	maxCompletionScore = 1 << 12
+
+	// timeout on asking remote nodes for completions
+	completionTimeout = 300 * time.Millisecond
+)
+
+type completionValue struct {
+	// type: map{term -> score}
+	scores map[string]float64
+	mux    sync.Mutex
+}
+
+// completionPrefixes returns the prefixes of `term` on which its completion is stored
+func completionPrefixes(term string) []string {
+	rs := []rune(term)
+	var ret []string
+	for l := minCompletionPrefixLen; l <= maxCompletionPrefixLen && l <= len(rs); l++ {
+		ret = append(ret, string(rs[:l]))
+	}
+	return ret
+}
+
+func completionAddress(prefix string) doogleAddress {
+	return sha1.Sum([]byte(completionKeyPrefix + prefix))
+}
+
+func (n *Node) StoreCompletion(ctx context.Context, in *doogle.StoreCompletionRequest) (*doogle.Empty, error) {
+	if !n.isValidSender(in.Certificate) {
+		return nil, status.Error(codes.InvalidArgument, "invalid certificate")
+	}
+
+	if in.Completion == nil || in.Completion.Term == "" {
+		return nil, status.Error(codes.InvalidArgument, "empty completion")
+	}
+
+	score := in.Completion.Score
+	if math.IsNaN(score) || math.IsInf(score, 0) || score <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid score")
+	}
+
+	// no single request overwhelms the popularity of the other terms
+	n.storeCompletion(doogleAddressStr(in.DoogleAddress), &doogle.Completion{
+		Term:  in.Completion.Term,
+		Score: math.Min(score, maxCompletionScore),
+	})
+	return &doogle.Empty{}, nil
+}
+
+func (n *Node) storeCompletion(addr doogleAddressStr, cp *doogle.Completion) {
+	actual, _ := n.completions.LoadOrStore(addr, &completionValue{
+		scores: map[string]float64{},
+		mux:    sync.Mutex{},
+	})
+
+	cv := actual.(*completionValue)
+	cv.mux.Lock()
+	defer cv.mux.Unlock()
+
+	cv.scores[cp.Term] += cp.Score
+	if len(cv.scores) <= maxNumCompletionsPerKey {
+		return
+	}
+
+	// evict the least popular one other than the given term
+	var minTerm string
+	for term, score := range cv.scores {
+		if term != cp.Term && (minTerm == "" || score < cv.scores[minTerm]) {
+			minTerm = term
+		}
+	}
+	delete(cv.scores, minTerm)
+}
+
+func (n *Node) FindCompletion(ctx context.Context, in *doogle.FindCompletionRequest) (*doogle.Completions, error) {
+	if !n.isValidSender(in.Certificate) {
+		return nil, status.Error(codes.InvalidArgument, "invalid certificate")
+	}
+	return &doogle.Completions{Completions: n.findCompletion(doogleAddressStr(in.DoogleAddress))}, nil
+}
+
+func (n *Node) findCompletion(addr doogleAddressStr) []*doogle.Completion {
+	raw, ok := n.completions.Load(addr)
+	if !ok {
+		return nil
+	}
+
+	cv := raw.(*completionValue)
+	cv.mux.Lock()
+	defer cv.mux.Unlock()
+
+	ret := make([]*doogle.Completion, 0, len(cv.scores))
+	for term, score := range cv.scores {
+		ret = append(ret, &doogle.Completion{Term: term, Score: score})
+	}
+	return ret
+}
+
+// add the score of the completion on the nodes closest to each prefix of its term
+func (n *Node) publishCompletion(cp *doogle.Completion) {
+	for _, prefix := range completionPrefixes(cp.Term) {
+		addr := completionAddress(prefix)
+		req := &doogle.StoreCompletionRequest{
+			Certificate:   n.certificate,
+			DoogleAddress: addr[:],
+			Completion:    cp,
+		}
+
+		if !n.forEachClosestNode(context.Background(), addr, func(ctx context.Context, c doogle.DoogleClient) error {
+			_, err := c.StoreCompletion(ctx, req)
+			return err
+		}) {
+			// if no node is closer, store completion into its own table
+			n.storeCompletion(doogleAddressStr(addr[:]), cp)
+		}
+	}
+}
+
+func (n *Node) Suggest(ctx context.Context, in *doogle.StringMessage) (*doogle.Completions, error) {
+	typed := strings.ToLower(strings.TrimSpace(in.Message))
+	rs := []rune(typed)
+	if len(rs) < minCompletionPrefixLen {
+		return &doogle.Completions{}, nil
+	}
+
+	prefix := typed
+	if len(rs) > maxCompletionPrefixLen {
+		prefix = string(rs[:maxCompletionPrefixLen])
+	}
+	addr := completionAddress(prefix)
+
+	// completions are replicated on the closest nodes, so take the max score among them
+	scores := map[string]float64{}
+	var mux sync.Mutex
+	merge := func(cps []*doogle.Completion) {
+		mux.Lock()
+		defer mux.Unlock()
+		for _, cp := range cps {
+			if !strings.HasPrefix(cp.Term, typed) {
+				continue
+			}
+
+			if s, ok := scores[cp.Term]; !ok || cp.Score > s {
+				scores[cp.Term] = cp.Score
+			}
+		}
+	}
+
+	merge(n.findCompletion(doogleAddressStr(addr[:])))
+
+	rep, err := n.findNode(addr)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "findNode failed: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, completionTimeout)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	for _, ni := range rep {
+		wg.Add(1)
+		go func(ni *doogle.NodeInfo) {
+			defer wg.Done()
+
+			conn, err := n.getConnByNetworkAddress(ctx, ni.NetworkAddress)
+			if err != nil {
+				return
+			}
+
+			c := doogle.NewDoogleClient(conn)
+			res, err := c.FindCompletion(ctx, &doogle.FindCompletionRequest{
+				Certificate:   n.certificate,
+				DoogleAddress: addr[:],
+			})
+			if err != nil {
+				n.logger.Errorf("failed to call FindCompletion: %v", err)
+				return
+			}
+			merge(res.Completions)
+		}(ni)
+	}
+	wg.Wait()
+
+	ret := make([]*doogle.Completion, 0, len(scores))
+	for term, score := range scores {
+		ret = append(ret, &doogle.Completion{Term: term, Score: score})
+	}
+
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].Score != ret[j].Score {
+			return ret[i].Score > ret[j].Score
+		}
+		return ret[i].Term < ret[j].Term
+	})
+
+	if len(ret) > maxNumCompletions {
+		ret = ret[:maxNumCompletions]
+	}
+	return &doogle.Completions{Completions: ret}, nil
+}
@@ -0,0 +1,135 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"testing"
+
+	"github.com/mathetake/doogle/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gotest.tools/assert"
+)
+
+func TestCompletionPrefixes(t *testing.T) {
+	for i, cc := range []struct {
+		term string
+		exp  []string
+	}{
+		{term: "doogle", exp: []string{"do", "doo", "doog"}},
+		{term: "dht", exp: []string{"dh", "dht"}},
+		{term: "a", exp: nil},
+	} {
+		c := cc
+		t.Run(fmt.Sprintf("%d-th case", i), func(t *testing.T) {
+			assert.DeepEqual(t, c.exp, completionPrefixes(c.term))
+		})
+	}
+}
+
+func TestNode_storeCompletion(t *testing.T) {
+	srv := testServers[0].node
+	srv.completions = sync.Map{}
+	defer func() { srv.completions = sync.Map{} }()
+
+	addr := doogleAddressStr("prefix")
+	for i := 0; i < maxNumCompletionsPerKey; i++ {
+		srv.storeCompletion(addr, &doogle.Completion{Term: fmt.Sprintf("term%d", i), Score: float64(i + 1)})
+	}
+	srv.storeCompletion(addr, &doogle.Completion{Term: "term0", Score: 10})
+	srv.storeCompletion(addr, &doogle.Completion{Term: "new", Score: 1})
+
+	scores := map[string]float64{}
+	for _, cp := range srv.findCompletion(addr) {
+		scores[cp.Term] = cp.Score
+	}
+
+	assert.Equal(t, maxNumCompletionsPerKey, len(scores))
+	assert.Equal(t, 11.0, scores["term0"])
+	assert.Equal(t, 1.0, scores["new"])
+
+	// the least popular one is evicted
+	_, ok := scores["term1"]
+	assert.Equal(t, false, ok)
+}
+
+func TestNode_StoreCompletion(t *testing.T) {
+	srv := testServers[0].node
+	srv.completions = sync.Map{}
+	defer func() { srv.completions = sync.Map{} }()
+
+	addr := doogleAddressStr("prefix")
+	for i, cc := range []struct {
+		score    float64
+		exp      codes.Code
+		expScore float64
+	}{
+		{score: 0, exp: codes.InvalidArgument},
+		{score: -1, exp: codes.InvalidArgument},
+		{score: math.NaN(), exp: codes.InvalidArgument},
+		{score: math.Inf(1), exp: codes.InvalidArgument},
+		{score: 2, exp: codes.OK, expScore: 2},
+		{score: 1e300, exp: codes.OK, expScore: 2 + maxCompletionScore},
+	} {
+		c := cc
+		t.Run(fmt.Sprintf("%d-th case", i), func(t *testing.T) {
+			_, err := srv.StoreCompletion(context.Background(), &doogle.StoreCompletionRequest{
+				Certificate:   srv.certificate,
+				DoogleAddress: []byte(addr),
+				Completion:    &doogle.Completion{Term: "doogle", Score: c.score},
+			})
+			assert.Equal(t, c.exp, status.Code(err))
+
+			var score float64
+			for _, cp := range srv.findCompletion(addr) {
+				score = cp.Score
+			}
+			assert.Equal(t, c.expScore, score)
+		})
+	}
+}
+
+func TestNode_Suggest(t *testing.T) {
+	resetRoutingTable()
+	defer resetRoutingTable()
+
+	srv := testServers[0].node
+	srv.completions = sync.Map{}
+	defer func() { srv.completions = sync.Map{} }()
+
+	for _, cp := range []*doogle.Completion{
+		{Term: "doogle", Score: 3},
+		{Term: "dongle", Score: 1},
+		{Term: "doorway", Score: 5},
+		{Term: "doogle", Score: 3},
+		{Term: "kademlia", Score: 1},
+	} {
+		srv.publishCompletion(cp)
+	}
+
+	for i, cc := range []struct {
+		typed string
+		exp   []string
+	}{
+		{typed: "d", exp: []string{}},
+		{typed: "do", exp: []string{"doogle", "doorway", "dongle"}},
+		{typed: "Doo ", exp: []string{"doogle", "doorway"}},
+		{typed: "doog", exp: []string{"doogle"}},
+		{typed: "doogl", exp: []string{"doogle"}},
+		{typed: "dox", exp: []string{}},
+	} {
+		c := cc
+		t.Run(fmt.Sprintf("%d-th case", i), func(t *testing.T) {
+			res, err := srv.Suggest(context.Background(), &doogle.StringMessage{Message: c.typed})
+			assert.Equal(t, nil, err)
+
+			actual := make([]string, 0, len(res.Completions))
+			for _, cp := range res.Completions {
+				actual = append(actual, cp.Term)
+			}
+			assert.DeepEqual(t, c.exp, actual)
+		})
+	}
+}
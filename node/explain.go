@@ -0,0 +1,167 @@
+package node
+
+import (
+	"context"
+	"crypto/sha1"
+	"sort"
+	"sync"
+
+	"github.com/mathetake/doogle/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (n *Node) FindRankNeighbours(ctx context.Context, in *doogle.FindRankNeighboursRequest) (*doogle.RankNeighbours, error) {
+	if !n.isValidSender(in.Certificate) {
+		return nil, status.Error(codes.InvalidArgument, "invalid certificate")
+	}
+	return &doogle.RankNeighbours{Neighbours: n.rankNeighbours(doogleAddressStr(in.DoogleAddress))}, nil
+}
+
+// rankNeighbours returns the pages linking to `addr` in the graph on which its rank is computed on this node,
+// i.e. the local items and the pages known to the world node, in the descending order of the contribution
+func (n *Node) rankNeighbours(addr doogleAddressStr) []*doogle.RankNeighbour {
+	var ret []*doogle.RankNeighbour
+	linksTo := func(edges []doogleAddressStr) bool {
+		for _, e := range edges {
+			if e == addr {
+				return true
+			}
+		}
+		return false
+	}
+
+	n.items.Range(func(_, raw interface{}) bool {
+		it := raw.(*item)
+		if it.anchorOnly || !linksTo(it.edges) {
+			return true
+		}
+
+		score := n.currentRank(it)
+		ret = append(ret, &doogle.RankNeighbour{
+			Url:           it.url,
+			DoogleAddress: []byte(it.dAddrStr),
+			Score:         score,
+			OutDegree:     int32(len(it.edges)),
+			Contribution:  score / float64(len(it.edges)),
+		})
+		return true
+	})
+
+	n.world.mux.Lock()
+	for a, wp := range n.world.pages {
+		if !linksTo(wp.Edges) {
+			continue
+		}
+
+		ret = append(ret, &doogle.RankNeighbour{
+			DoogleAddress: []byte(a),
+			Score:         wp.Score,
+			OutDegree:     int32(len(wp.Edges)),
+			Contribution:  wp.Score / float64(len(wp.Edges)),
+			World:         true,
+		})
+	}
+	n.world.mux.Unlock()
+
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].Contribution != ret[j].Contribution {
+			return ret[i].Contribution > ret[j].Contribution
+		}
+		return string(ret[i].DoogleAddress) < string(ret[j].DoogleAddress)
+	})
+	return ret
+}
+
+// explain returns the breakdown of the score of the url. the caller must hold the lock
+func (sr *searchResult) explain(url string) *doogle.Explanation {
+	ret := &doogle.Explanation{Url: url, Stats: sr.stats}
+	sc, ok := sr.scores[url]
+	if !ok {
+		return ret
+	}
+
+	ret.Found = true
+	ret.Median = median(sc.scores)
+	ret.AggregatedScore = sc.agg
+	ret.Fields = sc.fields
+	ret.FieldBoost = sc.boost
+	ret.Score = sc.agg * sc.boost
+
+	for i, o := range outliers(sc.scores) {
+		ret.Reports = append(ret.Reports, &doogle.NodeReport{
+			NetworkAddress:  sc.nodes[i],
+			LocalRank:       sc.ranks[i],
+			NormalizedScore: sc.scores[i],
+			Outlier:         o,
+		})
+	}
+	return ret
+}
+
+func (n *Node) Explain(ctx context.Context, in *doogle.ExplainRequest) (*doogle.Explanation, error) {
+	if in.Url == "" {
+		return nil, status.Error(codes.InvalidArgument, "empty url")
+	}
+	in.Url = normalizeURL(in.Url)
+
+	term, filter, timeout := parseQuery(in.Query)
+	if term == "" && filter.Link == "" {
+		return nil, status.Error(codes.InvalidArgument, "empty query")
+	}
+
+	ctx, cancel := withQueryTimeout(ctx, timeout)
+	defer cancel()
+
+	// results are computed without the query cache in order to know which nodes reported the item
+	sr := newSearchResult()
+	if err := n.search(ctx, term, filter, sr, func([]*doogle.Item) {}); err != nil {
+		return nil, err
+	}
+
+	ranked, _ := sr.ranked()
+
+	sr.mux.Lock()
+	ret := sr.explain(in.Url)
+	sr.mux.Unlock()
+
+	for i, it := range ranked {
+		if it.Url == in.Url {
+			ret.Position = int32(i + 1)
+			break
+		}
+	}
+
+	// the neighbours contributing to the rank on each node reported it
+	h := sha1.Sum([]byte(in.Url))
+	var wg sync.WaitGroup
+	for _, r := range ret.Reports {
+		if r.NetworkAddress == n.certificate.NetworkAddress {
+			r.Neighbours = n.rankNeighbours(doogleAddressStr(h[:]))
+			continue
+		}
+
+		wg.Add(1)
+		go func(r *doogle.NodeReport) {
+			defer wg.Done()
+
+			conn, err := n.getConnByNetworkAddress(ctx, r.NetworkAddress)
+			if err != nil {
+				return
+			}
+
+			c := doogle.NewDoogleClient(conn)
+			res, err := c.FindRankNeighbours(ctx, &doogle.FindRankNeighboursRequest{
+				Certificate:   n.certificate,
+				DoogleAddress: h[:],
+			})
+			if err != nil {
+				n.logger.Errorf("failed to call FindRankNeighbours: %v", err)
+				return
+			}
+			r.Neighbours = res.Neighbours
+		}(r)
+	}
+	wg.Wait()
+	return ret, nil
+}
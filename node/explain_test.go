@@ -0,0 +1,69 @@
+package node
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mathetake/doogle/grpc"
+	"gotest.tools/assert"
+)
+
+func TestNode_Explain(t *testing.T) {
+	resetRoutingTable()
+	defer resetRoutingTable()
+	resetDHT()
+	defer resetDHT()
+
+	from, to := testServers[0], testServers[1]
+	from.node.updateRoutingTable(&nodeInfo{dAddr: to.node.DAddr, nAddr: localhost + to.port})
+
+	for i, items := range [][]*doogle.StoreItemRequest{
+		{
+			{Url: "url1", EdgeURLs: []string{"url2", "url4"}},
+			{Url: "url2", Fields: []doogle.Field{doogle.Field_TITLE}},
+		},
+		{
+			{Url: "url2"},
+			{Url: "url3", EdgeURLs: []string{"url2"}},
+		},
+	} {
+		srv := testServers[i].node
+		for _, in := range items {
+			in.Certificate = srv.certificate
+			in.Index = "doogle"
+			_, err := srv.StoreItem(context.Background(), in)
+			assert.Equal(t, nil, err)
+		}
+		srv.runRankScheduler()
+	}
+
+	res, err := from.node.Explain(context.Background(), &doogle.ExplainRequest{Query: "doogle", Url: "url2"})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, res.Found)
+	assert.Assert(t, res.Position > 0)
+	assert.DeepEqual(t, []doogle.Field{doogle.Field_TITLE}, res.Fields)
+	assert.Equal(t, fieldBoosts[doogle.Field_TITLE], res.FieldBoost)
+	assert.Equal(t, res.AggregatedScore*res.FieldBoost, res.Score)
+	assert.Equal(t, int32(1), res.Stats.NumAnswered)
+
+	neighbours := map[string][]string{}
+	for _, r := range res.Reports {
+		assert.Equal(t, false, r.Outlier)
+		for _, nb := range r.Neighbours {
+			neighbours[r.NetworkAddress] = append(neighbours[r.NetworkAddress], nb.Url)
+			assert.Equal(t, nb.Score/float64(nb.OutDegree), nb.Contribution)
+		}
+	}
+	assert.DeepEqual(t, map[string][]string{
+		from.node.certificate.NetworkAddress: {normalizeURL("url1")},
+		to.node.certificate.NetworkAddress:   {normalizeURL("url3")},
+	}, neighbours)
+
+	res, err = from.node.Explain(context.Background(), &doogle.ExplainRequest{Query: "doogle", Url: "unknown"})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, false, res.Found)
+	assert.Equal(t, int32(0), res.Position)
+
+	_, err = from.node.Explain(context.Background(), &doogle.ExplainRequest{Query: "", Url: "url2"})
+	assert.Assert(t, err != nil)
+}
@@ -0,0 +1,212 @@
+package node
+
+import (
+	"bufio"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/mathetake/doogle/grpc"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// size of data in a chunk of the exported graph
+const graphChunkSize = 32 * 1024
+
+// graphWriter writes a graph in a format node by node so that large graphs can be streamed
+type graphWriter interface {
+	begin(w io.Writer) error
+
+	// node is called once for each node. url is empty for the nodes only known as link targets
+	node(w io.Writer, id, url string, rank float64) error
+	edge(w io.Writer, from, to string) error
+	end(w io.Writer) error
+}
+
+func newGraphWriter(format doogle.GraphFormat, withAttrs bool) (graphWriter, error) {
+	switch format {
+	case doogle.GraphFormat_EDGE_LIST:
+		return &edgeListWriter{withAttrs: withAttrs}, nil
+	case doogle.GraphFormat_DOT:
+		return &dotWriter{withAttrs: withAttrs}, nil
+	case doogle.GraphFormat_GRAPHML:
+		return &graphMLWriter{withAttrs: withAttrs}, nil
+	}
+	return nil, errors.Errorf("unknown format: %v", format)
+}
+
+// edgeListWriter writes tab separated lines of edges. nodes are listed in comment lines with attributes
+type edgeListWriter struct {
+	withAttrs bool
+}
+
+func (e *edgeListWriter) begin(w io.Writer) error {
+	if !e.withAttrs {
+		return nil
+	}
+	_, err := io.WriteString(w, "# node\tid\turl\trank\n")
+	return err
+}
+
+func (e *edgeListWriter) node(w io.Writer, id, url string, rank float64) error {
+	if !e.withAttrs || url == "" {
+		return nil
+	}
+	_, err := fmt.Fprintf(w, "# node\t%s\t%s\t%s\n", id, url, strconv.FormatFloat(rank, 'g', -1, 64))
+	return err
+}
+
+func (e *edgeListWriter) edge(w io.Writer, from, to string) error {
+	_, err := fmt.Fprintf(w, "%s\t%s\n", from, to)
+	return err
+}
+
+func (e *edgeListWriter) end(w io.Writer) error { return nil }
+
+type dotWriter struct {
+	withAttrs bool
+}
+
+func (d *dotWriter) begin(w io.Writer) error {
+	_, err := io.WriteString(w, "digraph doogle {\n")
+	return err
+}
+
+func (d *dotWriter) node(w io.Writer, id, url string, rank float64) error {
+	if !d.withAttrs || url == "" {
+		_, err := fmt.Fprintf(w, "  %q;\n", id)
+		return err
+	}
+	_, err := fmt.Fprintf(w, "  %q [url=%q, rank=%s];\n", id, url, strconv.FormatFloat(rank, 'g', -1, 64))
+	return err
+}
+
+func (d *dotWriter) edge(w io.Writer, from, to string) error {
+	_, err := fmt.Fprintf(w, "  %q -> %q;\n", from, to)
+	return err
+}
+
+func (d *dotWriter) end(w io.Writer) error {
+	_, err := io.WriteString(w, "}\n")
+	return err
+}
+
+type graphMLWriter struct {
+	withAttrs bool
+}
+
+func (g *graphMLWriter) begin(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	b.WriteString(`<graphml xmlns="http://graphml.graphdrawing.org/xmlns">` + "\n")
+	if g.withAttrs {
+		b.WriteString(`  <key id="url" for="node" attr.name="url" attr.type="string"/>` + "\n")
+		b.WriteString(`  <key id="rank" for="node" attr.name="rank" attr.type="double"/>` + "\n")
+	}
+	b.WriteString(`  <graph id="doogle" edgedefault="directed">` + "\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func (g *graphMLWriter) node(w io.Writer, id, url string, rank float64) error {
+	if !g.withAttrs || url == "" {
+		_, err := fmt.Fprintf(w, "    <node id=\"%s\"/>\n", id)
+		return err
+	}
+
+	var escaped strings.Builder
+	if err := xml.EscapeText(&escaped, []byte(url)); err != nil {
+		return err
+	}
+
+	_, err := fmt.Fprintf(w, "    <node id=\"%s\"><data key=\"url\">%s</data><data key=\"rank\">%s</data></node>\n",
+		id, escaped.String(), strconv.FormatFloat(rank, 'g', -1, 64))
+	return err
+}
+
+func (g *graphMLWriter) edge(w io.Writer, from, to string) error {
+	_, err := fmt.Fprintf(w, "    <edge source=\"%s\" target=\"%s\"/>\n", from, to)
+	return err
+}
+
+func (g *graphMLWriter) end(w io.Writer) error {
+	_, err := io.WriteString(w, "  </graph>\n</graphml>\n")
+	return err
+}
+
+// exportGraph writes the link graph of the items on this node. nodes are identified by hex encoded addresses
+func (n *Node) exportGraph(w io.Writer, gw graphWriter) error {
+	if err := gw.begin(w); err != nil {
+		return err
+	}
+
+	// link targets not held by this node are written after all the items
+	written := map[doogleAddressStr]struct{}{}
+	targets := map[doogleAddressStr]struct{}{}
+
+	var err error
+	n.items.Range(func(_, raw interface{}) bool {
+		it := raw.(*item)
+		id := hex.EncodeToString([]byte(it.dAddrStr))
+		if err = gw.node(w, id, it.url, n.currentRank(it)); err != nil {
+			return false
+		}
+		written[it.dAddrStr] = struct{}{}
+
+		for _, e := range it.edges {
+			if err = gw.edge(w, id, hex.EncodeToString([]byte(e))); err != nil {
+				return false
+			}
+			targets[e] = struct{}{}
+		}
+		return true
+	})
+	if err != nil {
+		return err
+	}
+
+	for t := range targets {
+		if _, ok := written[t]; ok {
+			continue
+		}
+		if err := gw.node(w, hex.EncodeToString([]byte(t)), "", 0); err != nil {
+			return err
+		}
+	}
+	return gw.end(w)
+}
+
+// chunkWriter sends the written data as chunks of the stream
+type chunkWriter struct {
+	stream doogle.Doogle_ExportGraphServer
+}
+
+func (c *chunkWriter) Write(p []byte) (int, error) {
+	data := append([]byte{}, p...)
+	if err := c.stream.Send(&doogle.GraphChunk{Data: data}); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func (n *Node) ExportGraph(in *doogle.ExportGraphRequest, stream doogle.Doogle_ExportGraphServer) error {
+	gw, err := newGraphWriter(in.Format, in.WithAttributes)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	w := bufio.NewWriterSize(&chunkWriter{stream: stream}, graphChunkSize)
+	if err := n.exportGraph(w, gw); err != nil {
+		return status.Errorf(codes.Internal, "failed to export graph: %v", err)
+	}
+
+	if err := w.Flush(); err != nil {
+		return status.Errorf(codes.Internal, "failed to send graph: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package node
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/mathetake/doogle/grpc"
+	"gotest.tools/assert"
+)
+
+type exportGraphStream struct {
+	doogle.Doogle_ExportGraphServer
+	chunks [][]byte
+}
+
+func (s *exportGraphStream) Send(c *doogle.GraphChunk) error {
+	s.chunks = append(s.chunks, c.Data)
+	return nil
+}
+
+func exportTestID(url string) string {
+	return hex.EncodeToString([]byte(exportTestAddr(url)))
+}
+
+func TestNode_ExportGraph(t *testing.T) {
+	resetDHT()
+	defer resetDHT()
+
+	n := testServers[0].node
+	for _, it := range []*item{
+		{url: "a.com", edges: []doogleAddressStr{exportTestAddr("b.com"), exportTestAddr("c.com")}, localRank: 0.5},
+		{url: "b.com", edges: []doogleAddressStr{exportTestAddr("a.com")}, localRank: 0.25},
+	} {
+		it.dAddrStr = exportTestAddr(it.url)
+		n.items.Store(it.dAddrStr, it)
+	}
+
+	a, b, c := exportTestID("a.com"), exportTestID("b.com"), exportTestID("c.com")
+
+	for i, cc := range []struct {
+		in    *doogle.ExportGraphRequest
+		lines []string
+	}{
+		{
+			in:    &doogle.ExportGraphRequest{Format: doogle.GraphFormat_EDGE_LIST},
+			lines: []string{a + "\t" + b, a + "\t" + c, b + "\t" + a},
+		},
+		{
+			in: &doogle.ExportGraphRequest{Format: doogle.GraphFormat_EDGE_LIST, WithAttributes: true},
+			lines: []string{
+				"# node\tid\turl\trank",
+				"# node\t" + a + "\ta.com\t0.5",
+				"# node\t" + b + "\tb.com\t0.25",
+				a + "\t" + b, a + "\t" + c, b + "\t" + a,
+			},
+		},
+		{
+			in: &doogle.ExportGraphRequest{Format: doogle.GraphFormat_DOT},
+			lines: []string{
+				"digraph doogle {", "}",
+				fmt.Sprintf("  %q;", a), fmt.Sprintf("  %q;", b), fmt.Sprintf("  %q;", c),
+				fmt.Sprintf("  %q -> %q;", a, b), fmt.Sprintf("  %q -> %q;", a, c), fmt.Sprintf("  %q -> %q;", b, a),
+			},
+		},
+		{
+			in: &doogle.ExportGraphRequest{Format: doogle.GraphFormat_DOT, WithAttributes: true},
+			lines: []string{
+				"digraph doogle {", "}",
+				fmt.Sprintf("  %q [url=\"a.com\", rank=0.5];", a), fmt.Sprintf("  %q [url=\"b.com\", rank=0.25];", b),
+				fmt.Sprintf("  %q;", c),
+				fmt.Sprintf("  %q -> %q;", a, b), fmt.Sprintf("  %q -> %q;", a, c), fmt.Sprintf("  %q -> %q;", b, a),
+			},
+		},
+	} {
+		c := cc
+		t.Run(fmt.Sprintf("%d-th case", i), func(t *testing.T) {
+			s := &exportGraphStream{}
+			assert.Equal(t, nil, n.ExportGraph(c.in, s))
+
+			actual := strings.Split(strings.TrimSpace(string(bytes.Join(s.chunks, nil))), "\n")
+			sort.Strings(actual)
+			sort.Strings(c.lines)
+			assert.DeepEqual(t, c.lines, actual)
+		})
+	}
+
+	assert.Assert(t, n.ExportGraph(&doogle.ExportGraphRequest{Format: 100}, &exportGraphStream{}) != nil)
+}
+
+func TestNode_ExportGraph_graphML(t *testing.T) {
+	resetDHT()
+	defer resetDHT()
+
+	n := testServers[0].node
+	it := &item{url: "a.com/?x=1&y=<2>", edges: []doogleAddressStr{exportTestAddr("b.com")}, localRank: 0.5}
+	it.dAddrStr = exportTestAddr(it.url)
+	n.items.Store(it.dAddrStr, it)
+
+	s := &exportGraphStream{}
+	assert.Equal(t, nil, n.ExportGraph(&doogle.ExportGraphRequest{Format: doogle.GraphFormat_GRAPHML, WithAttributes: true}, s))
+
+	var actual struct {
+		Graph struct {
+			Nodes []struct {
+				ID   string `xml:"id,attr"`
+				Data []struct {
+					Key   string `xml:"key,attr"`
+					Value string `xml:",chardata"`
+				} `xml:"data"`
+			} `xml:"node"`
+			Edges []struct {
+				Source string `xml:"source,attr"`
+				Target string `xml:"target,attr"`
+			} `xml:"edge"`
+		} `xml:"graph"`
+	}
+	assert.Equal(t, nil, xml.Unmarshal(bytes.Join(s.chunks, nil), &actual))
+
+	assert.Equal(t, 2, len(actual.Graph.Nodes))
+	assert.Equal(t, exportTestID(it.url), actual.Graph.Nodes[0].ID)
+	assert.Equal(t, it.url, actual.Graph.Nodes[0].Data[0].Value)
+	assert.Equal(t, "0.5", actual.Graph.Nodes[0].Data[1].Value)
+	assert.Equal(t, exportTestID("b.com"), actual.Graph.Nodes[1].ID)
+
+	assert.Equal(t, 1, len(actual.Graph.Edges))
+	assert.Equal(t, exportTestID(it.url), actual.Graph.Edges[0].Source)
+	assert.Equal(t, exportTestID("b.com"), actual.Graph.Edges[0].Target)
+}
+
+func exportTestAddr(url string) doogleAddressStr {
+	h := sha1.Sum([]byte(url))
+	return doogleAddressStr(h[:])
+}
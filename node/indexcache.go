@@ -0,0 +1,153 @@
+package node
+
+import (
+	"context"
+	"time"
+
+	"github.com/mathetake/doogle/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// TTL of the copy cached on the node next to the closest one holding the posting list
+	// it halves for each level of distance further from the key
+	maxIndexCacheTTL = 10 * time.Minute
+	minIndexCacheTTL = 10 * time.Second
+)
+
+type cachedIndex struct {
+	items     []*doogle.Item
+	expiresAt time.Time
+}
+
+func (n *Node) CacheIndex(ctx context.Context, in *doogle.CacheIndexRequest) (*doogle.Empty, error) {
+	if !n.isValidSender(in.Certificate) {
+		return nil, status.Error(codes.InvalidArgument, "invalid certificate")
+	}
+
+	ttl := time.Duration(in.TtlMillis) * time.Millisecond
+	if ttl > maxIndexCacheTTL {
+		ttl = maxIndexCacheTTL
+	}
+
+	if ttl <= 0 || len(in.Items) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "empty cache")
+	}
+
+	if len(in.DoogleAddress) != addressLength {
+		return nil, status.Error(codes.InvalidArgument, "invalid address")
+	}
+
+	// the copy is cached on the path from the sender toward the key,
+	// so that no node can plant copies on the nodes further from the key than itself
+	addr := toDoogleAddress(in.DoogleAddress)
+	sender := toDoogleAddress(in.Certificate.DoogleAddress)
+	if sender.xor(addr).lessThanEqual(n.DAddr.xor(addr)) {
+		return nil, status.Error(codes.PermissionDenied, "not on the path from the sender to the key")
+	}
+
+	n.cacheIndex(doogleAddressStr(in.DoogleAddress), in.Items, ttl)
+	return &doogle.Empty{}, nil
+}
+
+func (n *Node) cacheIndex(addr doogleAddressStr, items []*doogle.Item, ttl time.Duration) {
+	n.indexCaches.Store(addr, &cachedIndex{items: items, expiresAt: time.Now().Add(ttl)})
+
+	// results of local queries change with the cached copy
+	n.queryCache.invalidate(addr)
+}
+
+// findCachedIndex returns the unexpired cached copy of the posting list on `addr` satisfying the filter
+func (n *Node) findCachedIndex(addr doogleAddressStr, filter *doogle.Filter) ([]*doogle.Item, bool) {
+	if filter != nil && filter.Link != "" {
+		// the cached copy doesn't hold the outgoing links of the items
+		return nil, false
+	}
+
+	raw, ok := n.indexCaches.Load(addr)
+	if !ok {
+		return nil, false
+	}
+
+	ci := raw.(*cachedIndex)
+	if time.Now().After(ci.expiresAt) {
+		n.indexCaches.Delete(addr)
+		return nil, false
+	}
+
+	ret := make([]*doogle.Item, 0, len(ci.items))
+	for _, it := range ci.items {
+		if matchFilter(filter, &item{url: it.Url, host: it.Host, lang: it.Lang}, it.Fields) {
+			ret = append(ret, it)
+		}
+	}
+	return ret, true
+}
+
+// indexCacheTTL returns the TTL of the copy cached on the node `levels` further from the key
+// than the closest node holding the posting list
+func indexCacheTTL(levels int) time.Duration {
+	if levels < 0 {
+		levels = 0
+	}
+
+	ttl := maxIndexCacheTTL
+	for i := 0; i < levels && ttl > minIndexCacheTTL; i++ {
+		ttl /= 2
+	}
+
+	if ttl < minIndexCacheTTL {
+		ttl = minIndexCacheTTL
+	}
+	return ttl
+}
+
+// cacheTarget returns the closest node to `targetAddr` among `missed` which did not have the posting list,
+// the closest one among `holders` whose reply is cached, and the TTL of the copy cached on it.
+func cacheTarget(targetAddr doogleAddress, missed, holders []*doogle.NodeInfo) (*doogle.NodeInfo, *doogle.NodeInfo, time.Duration) {
+	if len(missed) == 0 || len(holders) == 0 {
+		return nil, nil, 0
+	}
+
+	closest := func(nis []*doogle.NodeInfo) *doogle.NodeInfo {
+		ret := nis[0]
+		for _, ni := range nis[1:] {
+			if distanceTo(targetAddr, ni).lessThanEqual(distanceTo(targetAddr, ret)) {
+				ret = ni
+			}
+		}
+		return ret
+	}
+
+	ni, holder := closest(missed), closest(holders)
+	levels := getMostSignificantBit(distanceTo(targetAddr, ni)) - getMostSignificantBit(distanceTo(targetAddr, holder))
+	return ni, holder, indexCacheTTL(levels)
+}
+
+// isEmptyFilter reports whether the filter passes every item.
+// only the posting lists looked up with empty filters are cached
+func isEmptyFilter(f *doogle.Filter) bool {
+	return f == nil || (len(f.Fields) == 0 && f.Site == "" && f.Inurl == "" && f.Lang == "" && f.Link == "")
+}
+
+// store the copy of the posting list on the given node
+func (n *Node) publishIndexCache(ni *doogle.NodeInfo, targetAddr doogleAddress, items []*doogle.Item, ttl time.Duration) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	conn, err := n.getConnByNetworkAddress(ctx, ni.NetworkAddress)
+	if err != nil {
+		return
+	}
+
+	c := doogle.NewDoogleClient(conn)
+	if _, err := c.CacheIndex(ctx, &doogle.CacheIndexRequest{
+		Certificate:   n.certificate,
+		DoogleAddress: targetAddr[:],
+		Items:         items,
+		TtlMillis:     int64(ttl / time.Millisecond),
+	}); err != nil {
+		n.logger.Errorf("failed to call CacheIndex: %v", err)
+	}
+}
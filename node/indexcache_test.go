@@ -0,0 +1,143 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mathetake/doogle/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gotest.tools/assert"
+)
+
+func TestIndexCacheTTL(t *testing.T) {
+	for i, cc := range []struct {
+		levels int
+		exp    time.Duration
+	}{
+		{levels: -1, exp: maxIndexCacheTTL},
+		{levels: 0, exp: maxIndexCacheTTL},
+		{levels: 1, exp: maxIndexCacheTTL / 2},
+		{levels: 3, exp: maxIndexCacheTTL / 8},
+		{levels: 100, exp: minIndexCacheTTL},
+	} {
+		c := cc
+		t.Run(fmt.Sprintf("%d-th case", i), func(t *testing.T) {
+			assert.Equal(t, c.exp, indexCacheTTL(c.levels))
+		})
+	}
+}
+
+func TestCacheTarget(t *testing.T) {
+	var target doogleAddress
+	ni := func(b byte) *doogle.NodeInfo {
+		da := doogleAddress{}
+		da[addressLength-1] = b
+		return &doogle.NodeInfo{DoogleAddress: da[:], NetworkAddress: fmt.Sprintf("node%d", b)}
+	}
+
+	for i, cc := range []struct {
+		missed, holders    []*doogle.NodeInfo
+		expNode, expHolder string
+		expTTL             time.Duration
+	}{
+		{missed: nil, holders: []*doogle.NodeInfo{ni(1)}},
+		{missed: []*doogle.NodeInfo{ni(2)}, holders: nil},
+		{
+			missed:    []*doogle.NodeInfo{ni(0x20), ni(0x04), ni(0x80)},
+			holders:   []*doogle.NodeInfo{ni(0x02), ni(0x01)},
+			expNode:   "node4",
+			expHolder: "node1",
+			expTTL:    maxIndexCacheTTL / 4,
+		},
+		{
+			missed:    []*doogle.NodeInfo{ni(0x03)},
+			holders:   []*doogle.NodeInfo{ni(0x02)},
+			expNode:   "node3",
+			expHolder: "node2",
+			expTTL:    maxIndexCacheTTL,
+		},
+	} {
+		c := cc
+		t.Run(fmt.Sprintf("%d-th case", i), func(t *testing.T) {
+			actual, holder, ttl := cacheTarget(target, c.missed, c.holders)
+			if c.expNode == "" {
+				assert.Assert(t, actual == nil)
+				return
+			}
+			assert.Equal(t, c.expNode, actual.NetworkAddress)
+			assert.Equal(t, c.expHolder, holder.NetworkAddress)
+			assert.Equal(t, c.expTTL, ttl)
+		})
+	}
+}
+
+func TestNode_CacheIndex(t *testing.T) {
+	resetRoutingTable()
+	defer resetRoutingTable()
+	resetDHT()
+	defer resetDHT()
+
+	srv := testServers[0].node
+	other, err := NewNode(1, "other", logger, nil, 0)
+	assert.Equal(t, nil, err)
+
+	// srv is closer to the key than the sender
+	key := srv.DAddr
+	key[addressLength-1] ^= 1
+	addr := doogleAddressStr(key[:])
+	items := []*doogle.Item{
+		{Url: "url1", Host: "a.com", Lang: "en"},
+		{Url: "url2", Host: "b.com", Lang: "ja"},
+	}
+
+	for i, cc := range []struct {
+		ct   *doogle.NodeCertificate
+		addr []byte
+		ttl  int64
+		exp  codes.Code
+	}{
+		{ct: other.certificate, addr: []byte(addr), exp: codes.InvalidArgument},
+		{ct: other.certificate, addr: []byte("address"), ttl: 1000, exp: codes.InvalidArgument},
+		// the sender is closer to the key than srv
+		{ct: other.certificate, addr: other.DAddr[:], ttl: 1000, exp: codes.PermissionDenied},
+		{ct: other.certificate, addr: []byte(addr), ttl: 1000, exp: codes.OK},
+	} {
+		_, err = srv.CacheIndex(context.Background(), &doogle.CacheIndexRequest{
+			Certificate: cc.ct, DoogleAddress: cc.addr, Items: items, TtlMillis: cc.ttl,
+		})
+		assert.Equal(t, cc.exp, status.Code(err), fmt.Sprintf("%d-th case", i))
+	}
+
+	for i, cc := range []struct {
+		filter  *doogle.Filter
+		expUrls []string
+	}{
+		{filter: nil, expUrls: []string{"url1", "url2"}},
+		{filter: &doogle.Filter{Lang: "ja"}, expUrls: []string{"url2"}},
+		{filter: &doogle.Filter{Site: "c.com"}, expUrls: []string{}},
+	} {
+		c := cc
+		t.Run(fmt.Sprintf("%d-th case", i), func(t *testing.T) {
+			res, err := srv.findIndex(context.Background(), addr, c.filter)
+			assert.Equal(t, nil, err)
+			assert.Equal(t, true, res.Cached)
+
+			its := res.Result.(*doogle.FindIndexReply_Items).Items.Items
+			assert.Equal(t, len(c.expUrls), len(its))
+			for j, it := range its {
+				assert.Equal(t, c.expUrls[j], it.Url)
+			}
+		})
+	}
+
+	// expired
+	srv.cacheIndex(addr, items, 0)
+	res, err := srv.findIndex(context.Background(), addr, nil)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, false, res.Cached)
+	_, ok := res.Result.(*doogle.FindIndexReply_NodeInfos)
+	assert.Equal(t, true, ok)
+}
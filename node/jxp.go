@@ -0,0 +1,312 @@
+package node
+
+import (
+	"context"
+	"encoding/gob"
+	"math/rand"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/mathetake/doogle/grpc"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// timeout on a meeting with another node
+	meetingTimeout = 10 * time.Second
+
+	// max numbers of the pages of each kind, i.e. held by the node and known to the world node, and of the edges
+	// told at a meeting. they keep the message far below the limit of gRPC
+	maxMeetingPages = 1000
+	maxMeetingEdges = 20000
+
+	// pages not told by their owners for `worldPageTTL` are forgotten
+	worldPageTTL = 24 * time.Hour
+
+	// max number of the pages known to the world node. the ones with the lowest scores are forgotten beyond it
+	maxWorldPages = 100000
+)
+
+// worldPage is what the world node knows about a page held by other nodes
+type worldPage struct {
+	Score float64
+
+	// outgoing hyperlinks. nil if only the score is known
+	Edges []doogleAddressStr
+
+	// network address of the node which told the page as its own. empty if no owner told it
+	Owner string
+
+	// unix time in nanoseconds at which the page was learned or told by its owner last
+	UpdatedAt int64
+}
+
+// worldNode represents all the pages outside of the local graph.
+// it learns their scores and links at meetings with other nodes as in JXP
+type worldNode struct {
+	// type: map{doogleAddressStr -> *worldPage}
+	pages map[doogleAddressStr]*worldPage
+	mux   sync.Mutex
+}
+
+func newWorldNode() *worldNode {
+	return &worldNode{pages: map[doogleAddressStr]*worldPage{}}
+}
+
+// merge the pages told at a meeting by the node on `sender`. pages told by their owner (with edges) replace
+// the known ones, and otherwise the higher score is kept since JXP scores only grow toward the global PageRank.
+// a page once told as owned is replaced only by the same owner until it expires, so that the others can't overwrite it.
+// it returns the local pages linked from the pages told by their owners
+func (w *worldNode) merge(ps []*doogle.PageScore, sender string, now time.Time, isLocal func(doogleAddressStr) bool) []doogleAddressStr {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+
+	if len(ps) > 2*maxMeetingPages {
+		ps = ps[:2*maxMeetingPages]
+	}
+
+	var ret []doogleAddressStr
+
+	for _, p := range ps {
+		addr := doogleAddressStr(p.DoogleAddress)
+		if isLocal(addr) || !(p.Score >= 0 && p.Score <= 1) {
+			continue
+		}
+
+		wp, ok := w.pages[addr]
+		if !ok {
+			wp = &worldPage{UpdatedAt: now.UnixNano()}
+			w.pages[addr] = wp
+		}
+
+		if p.Owned && (wp.Owner == "" || wp.Owner == sender || wp.expired(now)) {
+			wp.Score, wp.Owner, wp.UpdatedAt = p.Score, sender, now.UnixNano()
+			wp.Edges = make([]doogleAddressStr, len(p.Edges))
+			for i, e := range p.Edges {
+				wp.Edges[i] = doogleAddressStr(e)
+				if isLocal(wp.Edges[i]) {
+					ret = append(ret, wp.Edges[i])
+				}
+			}
+		} else if p.Score > wp.Score {
+			wp.Score = p.Score
+		}
+	}
+	return ret
+}
+
+func (wp *worldPage) expired(now time.Time) bool {
+	return wp.UpdatedAt < now.Add(-worldPageTTL).UnixNano()
+}
+
+// prune forgets the pages which have become local or expired, and the ones with the lowest scores
+// beyond `maxWorldPages`. it returns the number of the forgotten pages
+func (w *worldNode) prune(now time.Time, isLocal func(doogleAddressStr) bool) int {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+
+	before := len(w.pages)
+	for addr, wp := range w.pages {
+		if isLocal(addr) || wp.expired(now) {
+			delete(w.pages, addr)
+		}
+	}
+
+	if len(w.pages) > maxWorldPages {
+		addrs := make([]doogleAddressStr, 0, len(w.pages))
+		for addr := range w.pages {
+			addrs = append(addrs, addr)
+		}
+		sort.Slice(addrs, func(i, j int) bool { return w.pages[addrs[i]].Score < w.pages[addrs[j]].Score })
+
+		for _, addr := range addrs[:len(addrs)-maxWorldPages] {
+			delete(w.pages, addr)
+		}
+	}
+	return before - len(w.pages)
+}
+
+func (w *worldNode) size() int {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+	return len(w.pages)
+}
+
+// save the state of the world node into the file on `path`
+func (w *worldNode) save(path string) error {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+
+	tmp := path + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return errors.Wrap(err, "failed to create file")
+	}
+
+	if err := gob.NewEncoder(f).Encode(w.pages); err != nil {
+		f.Close()
+		return errors.Wrap(err, "failed to encode world node")
+	}
+
+	if err := f.Close(); err != nil {
+		return errors.Wrap(err, "failed to close file")
+	}
+	return os.Rename(tmp, path)
+}
+
+// load the state of the world node from the file on `path`. nothing is loaded if the file doesn't exist
+func (w *worldNode) load(path string) error {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return errors.Wrap(err, "failed to open file")
+	}
+	defer f.Close()
+
+	pages := map[doogleAddressStr]*worldPage{}
+	if err := gob.NewDecoder(f).Decode(&pages); err != nil {
+		return errors.Wrap(err, "failed to decode world node")
+	}
+
+	// the pages saved without the time are taken as learned now
+	now := time.Now().UnixNano()
+	for _, wp := range pages {
+		if wp.UpdatedAt == 0 {
+			wp.UpdatedAt = now
+		}
+	}
+
+	w.mux.Lock()
+	defer w.mux.Unlock()
+	w.pages = pages
+	return nil
+}
+
+// meetingPages returns the scores of the pages sampled from the ones known to this node,
+// up to `maxMeetingPages` of each kind. edges are told only for the pages it holds, up to `maxMeetingEdges` in total
+func (n *Node) meetingPages() []*doogle.PageScore {
+	var its []*item
+	n.items.Range(func(_, raw interface{}) bool {
+		if it := raw.(*item); !it.anchorOnly {
+			its = append(its, it)
+		}
+		return true
+	})
+	rand.Shuffle(len(its), func(i, j int) { its[i], its[j] = its[j], its[i] })
+
+	var ret []*doogle.PageScore
+	var numEdges int
+	for _, it := range its {
+		if len(ret) >= maxMeetingPages {
+			break
+		} else if numEdges+len(it.edges) > maxMeetingEdges {
+			continue
+		}
+		numEdges += len(it.edges)
+
+		p := &doogle.PageScore{DoogleAddress: []byte(it.dAddrStr), Score: n.currentRank(it), Owned: true}
+		for _, e := range it.edges {
+			p.Edges = append(p.Edges, []byte(e))
+		}
+		ret = append(ret, p)
+	}
+
+	n.world.mux.Lock()
+	defer n.world.mux.Unlock()
+
+	addrs := make([]doogleAddressStr, 0, len(n.world.pages))
+	for addr := range n.world.pages {
+		addrs = append(addrs, addr)
+	}
+	rand.Shuffle(len(addrs), func(i, j int) { addrs[i], addrs[j] = addrs[j], addrs[i] })
+
+	if len(addrs) > maxMeetingPages {
+		addrs = addrs[:maxMeetingPages]
+	}
+	for _, addr := range addrs {
+		ret = append(ret, &doogle.PageScore{DoogleAddress: []byte(addr), Score: n.world.pages[addr].Score})
+	}
+	return ret
+}
+
+func (n *Node) isLocalPage(addr doogleAddressStr) bool {
+	raw, ok := n.items.Load(addr)
+	return ok && !raw.(*item).anchorOnly
+}
+
+func (n *Node) Meet(ctx context.Context, in *doogle.MeetRequest) (*doogle.MeetReply, error) {
+	if !n.isValidSender(in.Certificate) {
+		return nil, status.Error(codes.InvalidArgument, "invalid certificate")
+	}
+
+	rep := &doogle.MeetReply{Pages: n.meetingPages()}
+	for _, addr := range n.world.merge(in.Pages, in.Certificate.NetworkAddress, time.Now(), n.isLocalPage) {
+		n.rankScheduler.markItem(addr, storePriority)
+	}
+	n.world.prune(time.Now(), n.isLocalPage)
+	return rep, nil
+}
+
+// meet exchanges the knowledge of the world node with the given node
+func (n *Node) meet(ctx context.Context, ni *doogle.NodeInfo) error {
+	conn, err := n.getConnByNetworkAddress(ctx, ni.NetworkAddress)
+	if err != nil {
+		return errors.Wrap(err, "failed to connect")
+	}
+
+	c := doogle.NewDoogleClient(conn)
+	rep, err := c.Meet(ctx, &doogle.MeetRequest{Certificate: n.certificate, Pages: n.meetingPages()})
+	if err != nil {
+		return errors.Wrap(err, "failed to call Meet")
+	}
+
+	for _, addr := range n.world.merge(rep.Pages, ni.NetworkAddress, time.Now(), n.isLocalPage) {
+		n.rankScheduler.markItem(addr, storePriority)
+	}
+	n.world.prune(time.Now(), n.isLocalPage)
+	return nil
+}
+
+// StartMeetings periodically meets a random node in the routing table.
+// if `path` is not empty, the world node is restored from and saved into the file on it
+func (n *Node) StartMeetings(interval time.Duration, path string) error {
+	if interval <= 0 {
+		return errors.Errorf("invalid meeting interval: %v", interval)
+	}
+
+	if path != "" {
+		if err := n.world.load(path); err != nil {
+			return errors.Wrap(err, "failed to load world node")
+		}
+	}
+
+	go func() {
+		n.logger.Infof("[meeting] started: interval=%v", interval)
+
+		for range time.Tick(interval) {
+			nis := n.allNodes()
+			if len(nis) == 0 {
+				continue
+			}
+
+			ni := nis[rand.Intn(len(nis))]
+			ctx, cancel := context.WithTimeout(context.Background(), meetingTimeout)
+			if err := n.meet(ctx, ni); err != nil {
+				n.logger.Errorf("[meeting] failed to meet %s: %v", ni.NetworkAddress, err)
+			}
+			cancel()
+
+			if path != "" {
+				if err := n.world.save(path); err != nil {
+					n.logger.Errorf("[meeting] failed to save world node: %v", err)
+				}
+			}
+		}
+	}()
+	return nil
+}
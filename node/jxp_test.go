@@ -0,0 +1,192 @@
+package node
+
+import (
+	"context"
+	"crypto/sha1"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mathetake/doogle/grpc"
+	"gotest.tools/assert"
+)
+
+func TestWorldNode_merge(t *testing.T) {
+	w := newWorldNode()
+	isLocal := func(addr doogleAddressStr) bool { return addr == "local" }
+	now := time.Unix(100, 0)
+	ts := now.UnixNano()
+
+	for i, cc := range []struct {
+		sender string
+		pages  []*doogle.PageScore
+		exp    map[doogleAddressStr]*worldPage
+	}{
+		{
+			sender: "x",
+			pages: []*doogle.PageScore{
+				{DoogleAddress: []byte("a"), Score: 0.1, Edges: [][]byte{[]byte("b")}, Owned: true},
+				{DoogleAddress: []byte("b"), Score: 0.2},
+				{DoogleAddress: []byte("local"), Score: 0.3},
+				{DoogleAddress: []byte("c"), Score: 2},
+			},
+			exp: map[doogleAddressStr]*worldPage{
+				"a": {Score: 0.1, Edges: []doogleAddressStr{"b"}, Owner: "x", UpdatedAt: ts},
+				"b": {Score: 0.2, UpdatedAt: ts},
+			},
+		},
+		{
+			// scores told by others only grow
+			sender: "y",
+			pages: []*doogle.PageScore{
+				{DoogleAddress: []byte("a"), Score: 0.05},
+				{DoogleAddress: []byte("b"), Score: 0.4},
+			},
+			exp: map[doogleAddressStr]*worldPage{
+				"a": {Score: 0.1, Edges: []doogleAddressStr{"b"}, Owner: "x", UpdatedAt: ts},
+				"b": {Score: 0.4, UpdatedAt: ts},
+			},
+		},
+		{
+			// the page owned by another node is not overwritten
+			sender: "y",
+			pages: []*doogle.PageScore{
+				{DoogleAddress: []byte("a"), Score: 0.05, Owned: true},
+			},
+			exp: map[doogleAddressStr]*worldPage{
+				"a": {Score: 0.1, Edges: []doogleAddressStr{"b"}, Owner: "x", UpdatedAt: ts},
+				"b": {Score: 0.4, UpdatedAt: ts},
+			},
+		},
+		{
+			// the owner's score replaces the known one
+			sender: "x",
+			pages: []*doogle.PageScore{
+				{DoogleAddress: []byte("a"), Score: 0.05, Owned: true},
+			},
+			exp: map[doogleAddressStr]*worldPage{
+				"a": {Score: 0.05, Edges: []doogleAddressStr{}, Owner: "x", UpdatedAt: ts},
+				"b": {Score: 0.4, UpdatedAt: ts},
+			},
+		},
+	} {
+		c := cc
+		t.Run(fmt.Sprintf("%d-th case", i), func(t *testing.T) {
+			w.merge(c.pages, c.sender, now, isLocal)
+			assert.DeepEqual(t, c.exp, w.pages)
+		})
+	}
+}
+
+func TestWorldNode_prune(t *testing.T) {
+	w := newWorldNode()
+	now := time.Now()
+
+	w.pages["local"] = &worldPage{Score: 0.5, UpdatedAt: now.UnixNano()}
+	w.pages["expired"] = &worldPage{Score: 0.5, UpdatedAt: now.Add(-2 * worldPageTTL).UnixNano()}
+	for i := 0; i < maxWorldPages+1; i++ {
+		w.pages[doogleAddressStr(fmt.Sprint(i))] = &worldPage{Score: float64(i + 1), UpdatedAt: now.UnixNano()}
+	}
+
+	isLocal := func(addr doogleAddressStr) bool { return addr == "local" }
+	assert.Equal(t, 3, w.prune(now, isLocal))
+	assert.Equal(t, maxWorldPages, w.size())
+
+	// the lowest score is forgotten
+	_, ok := w.pages["0"]
+	assert.Equal(t, false, ok)
+}
+
+func TestNode_meetingPages(t *testing.T) {
+	node, err := NewNode(1, "doogle", logger, nil, 10)
+	if err != nil {
+		t.Fatalf("NewNode failed: %v", err)
+	}
+
+	edges := make([]doogleAddressStr, 30)
+	for i := range edges {
+		edges[i] = doogleAddressStr(fmt.Sprintf("e%d", i))
+	}
+	for i := 0; i < 2*maxMeetingPages; i++ {
+		addr := doogleAddressStr(fmt.Sprint(i))
+		node.items.Store(addr, &item{dAddrStr: addr, edges: edges})
+		node.world.pages[doogleAddressStr(fmt.Sprintf("w%d", i))] = &worldPage{Score: 0.1}
+	}
+
+	var numOwned, numWorld, numEdges int
+	for _, p := range node.meetingPages() {
+		if p.Owned {
+			numOwned++
+		} else {
+			numWorld++
+		}
+		numEdges += len(p.Edges)
+	}
+	assert.Equal(t, maxMeetingEdges/len(edges), numOwned)
+	assert.Equal(t, maxMeetingPages, numWorld)
+	assert.Assert(t, numEdges <= maxMeetingEdges)
+}
+
+func TestWorldNode_saveLoad(t *testing.T) {
+	dir, err := ioutil.TempDir("", "doogle")
+	assert.Equal(t, nil, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "world")
+
+	// nothing is loaded from the file not existing
+	w := newWorldNode()
+	assert.Equal(t, nil, w.load(path))
+	assert.Equal(t, 0, w.size())
+
+	w.pages["a"] = &worldPage{Score: 0.1, Edges: []doogleAddressStr{"b", "c"}, Owner: "x", UpdatedAt: 10}
+	w.pages["b"] = &worldPage{Score: 0.2, UpdatedAt: 20}
+	assert.Equal(t, nil, w.save(path))
+
+	actual := newWorldNode()
+	assert.Equal(t, nil, actual.load(path))
+	assert.DeepEqual(t, w.pages, actual.pages)
+}
+
+func TestNode_meet(t *testing.T) {
+	resetRoutingTable()
+	defer resetRoutingTable()
+	resetDHT()
+	defer resetDHT()
+
+	for i, url := range []string{"a.com", "b.com"} {
+		srv := testServers[i].node
+		_, err := srv.StoreItem(context.Background(), &doogle.StoreItemRequest{
+			Certificate: srv.certificate, Url: url, Index: "doogle", EdgeURLs: []string{"c.com"},
+		})
+		assert.Equal(t, nil, err)
+	}
+
+	from, to := testServers[0], testServers[1]
+	err := from.node.meet(context.Background(), &doogle.NodeInfo{NetworkAddress: localhost + to.port})
+	assert.Equal(t, nil, err)
+
+	h := sha1.Sum([]byte(normalizeURL("c.com")))
+	c := doogleAddressStr(h[:])
+	for _, cc := range []struct {
+		srv *Node
+		url string
+	}{
+		{srv: from.node, url: "b.com"},
+		{srv: to.node, url: "a.com"},
+	} {
+		addr := sha1.Sum([]byte(normalizeURL(cc.url)))
+		assert.Equal(t, 1, cc.srv.world.size())
+		assert.DeepEqual(t, []doogleAddressStr{c}, cc.srv.world.pages[doogleAddressStr(addr[:])].Edges)
+	}
+}
+
+func TestNode_StartMeetings(t *testing.T) {
+	srv := testServers[0].node
+	for i, interval := range []time.Duration{0, -time.Minute} {
+		assert.Assert(t, srv.StartMeetings(interval, "") != nil, fmt.Sprintf("%d-th case", i))
+	}
+}
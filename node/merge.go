@@ -0,0 +1,127 @@
+package node
+
+import (
+	"math"
+	"sort"
+
+	"github.com/mathetake/doogle/grpc"
+)
+
+// scores deviating from the median more than this times the median absolute deviation are dropped
+const outlierThreshold = 3.0
+
+// itemScore accumulates the normalized scores of an item reported by nodes
+type itemScore struct {
+	// one per node reported the item
+	scores []float64
+	nodes  []string
+	ranks  []float64
+
+	// aggregated score across nodes
+	agg   float64
+	boost float64
+
+	// union of the fields reported by nodes
+	fields []doogle.Field
+}
+
+func (sc *itemScore) add(node string, it *doogle.Item, score float64) {
+	sc.scores = append(sc.scores, score)
+	sc.nodes = append(sc.nodes, node)
+	sc.ranks = append(sc.ranks, it.LocalRank)
+	sc.agg = robustMedian(sc.scores)
+	sc.fields = mergeFields(sc.fields, it.Fields)
+	if b := fieldBoost(it.Fields); b > sc.boost {
+		sc.boost = b
+	}
+}
+
+// uniqueItems returns the items with the first occurrence of each url
+func uniqueItems(its []*doogle.Item) []*doogle.Item {
+	seen := make(map[string]struct{}, len(its))
+	ret := make([]*doogle.Item, 0, len(its))
+	for _, it := range its {
+		if _, ok := seen[it.Url]; ok {
+			continue
+		}
+		seen[it.Url] = struct{}{}
+		ret = append(ret, it)
+	}
+	return ret
+}
+
+// normalizeScores returns the z-scores of LocalRanks reported by a single node calibrated into (0, 1)
+// with the standard normal CDF, since the values computed on different subgraphs aren't comparable.
+func normalizeScores(its []*doogle.Item) []float64 {
+	ret := make([]float64, len(its))
+	if len(its) == 0 {
+		return ret
+	}
+
+	var mean float64
+	for _, it := range its {
+		mean += it.LocalRank
+	}
+	mean /= float64(len(its))
+
+	var variance float64
+	for _, it := range its {
+		variance += (it.LocalRank - mean) * (it.LocalRank - mean)
+	}
+	std := math.Sqrt(variance / float64(len(its)))
+
+	for i, it := range its {
+		var z float64
+		if std > 0 {
+			z = (it.LocalRank - mean) / std
+		}
+		ret[i] = 0.5 * (1 + math.Erf(z/math.Sqrt2))
+	}
+	return ret
+}
+
+func median(xs []float64) float64 {
+	if len(xs) == 0 {
+		return 0
+	}
+
+	s := append([]float64{}, xs...)
+	sort.Float64s(s)
+	if l := len(s); l%2 == 1 {
+		return s[l/2]
+	}
+	return (s[len(s)/2-1] + s[len(s)/2]) / 2
+}
+
+// outliers reports whether each value deviates from the median more than `outlierThreshold` times
+// the median absolute deviation. no value is an outlier if there are less than three
+func outliers(xs []float64) []bool {
+	ret := make([]bool, len(xs))
+	if len(xs) < 3 {
+		return ret
+	}
+
+	m := median(xs)
+	devs := make([]float64, len(xs))
+	for i, x := range xs {
+		devs[i] = math.Abs(x - m)
+	}
+	mad := median(devs)
+
+	for i := range xs {
+		ret[i] = devs[i] > outlierThreshold*mad
+	}
+	return ret
+}
+
+// robustMedian returns the median of the values after dropping outliers
+// by the median absolute deviation
+func robustMedian(xs []float64) float64 {
+	kept := make([]float64, 0, len(xs))
+	for i, o := range outliers(xs) {
+		if !o {
+			kept = append(kept, xs[i])
+		}
+	}
+	return median(kept)
+}
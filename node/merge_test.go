@@ -0,0 +1,76 @@
+package node
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/mathetake/doogle/grpc"
+	"gotest.tools/assert"
+)
+
+func TestNormalizeScores(t *testing.T) {
+	for i, cc := range []struct {
+		ranks []float64
+		exp   []float64
+	}{
+		{ranks: []float64{}, exp: []float64{}},
+		{ranks: []float64{0.3}, exp: []float64{0.5}},
+		{ranks: []float64{0.2, 0.2}, exp: []float64{0.5, 0.5}},
+		{ranks: []float64{0.1, 0.3}, exp: []float64{0.1587, 0.8413}},
+		// scaling the values doesn't change the scores
+		{ranks: []float64{100, 300}, exp: []float64{0.1587, 0.8413}},
+	} {
+		c := cc
+		t.Run(fmt.Sprintf("%d-th case", i), func(t *testing.T) {
+			its := make([]*doogle.Item, len(c.ranks))
+			for j, r := range c.ranks {
+				its[j] = &doogle.Item{LocalRank: r}
+			}
+
+			actual := normalizeScores(its)
+			assert.Equal(t, len(c.exp), len(actual))
+			for j := range actual {
+				assert.Assert(t, math.Abs(c.exp[j]-actual[j]) < 1e-4)
+			}
+		})
+	}
+}
+
+func TestRobustMedian(t *testing.T) {
+	for i, cc := range []struct {
+		xs  []float64
+		exp float64
+	}{
+		{xs: []float64{}, exp: 0},
+		{xs: []float64{0.4}, exp: 0.4},
+		{xs: []float64{0.2, 0.8}, exp: 0.5},
+		{xs: []float64{0.5, 0.5, 0.99}, exp: 0.5},
+		{xs: []float64{0.4, 0.5, 0.6, 0.99}, exp: 0.5},
+		{xs: []float64{0.1, 0.5, 0.6, 0.7}, exp: 0.6},
+	} {
+		c := cc
+		t.Run(fmt.Sprintf("%d-th case", i), func(t *testing.T) {
+			assert.Assert(t, math.Abs(c.exp-robustMedian(c.xs)) < 1e-9)
+		})
+	}
+}
+
+func TestSearchResult_add_outlier(t *testing.T) {
+	sr := newSearchResult()
+
+	// three replicas agree on the order while a peer reports inflated value
+	for i, its := range [][]*doogle.Item{
+		{{Url: "url1", LocalRank: 0.3}, {Url: "url2", LocalRank: 0.2}, {Url: "url3", LocalRank: 0.1}},
+		{{Url: "url1", LocalRank: 0.3}, {Url: "url2", LocalRank: 0.2}, {Url: "url3", LocalRank: 0.1}},
+		{{Url: "url1", LocalRank: 0.3}, {Url: "url2", LocalRank: 0.2}, {Url: "url3", LocalRank: 0.1}},
+		{{Url: "url1", LocalRank: 0.1}, {Url: "url2", LocalRank: 0.2}, {Url: "url3", LocalRank: 1000}},
+	} {
+		sr.add(fmt.Sprintf("node%d", i), its)
+	}
+
+	ret, _ := sr.ranked()
+	for j, exp := range []string{"url1", "url2", "url3"} {
+		assert.Equal(t, exp, ret[j].Url)
+	}
+}
@@ -22,6 +22,12 @@ const (
 	alpha         = 3
 	bucketSize    = 20
 	maxNumGetItem = 20 // TODO: add paging option
+
+	// timeout on connecting to other nodes
+	dialTimeout = 3 * time.Second
+
+	// timeout on a request to one of the closest nodes, so that a hanging node never blocks the caller
+	requestTimeout = 10 * time.Second
 )
 
 type item struct {
@@ -30,6 +36,13 @@ type item struct {
 	url   string
 	title string
 
+	// metadata for filtering
+	host string
+	lang string
+
+	// SimHash fingerprint for near-duplicate detection
+	simHash uint64
+
 	// outgoing hyperlinks
 	edges []doogleAddressStr
 
@@ -37,6 +50,18 @@ type item struct {
 	localRank         float64
 	rankComputedCount float64
 
+	// unix time in nanoseconds of the last computation of localRank
+	rankComputedAt int64
+
+	// rank toward which localRank decays while it is not recomputed
+	rankPrior float64
+
+	// the latest computations, the oldest first
+	rankHistory []rankRecord
+
+	// true if the item is only known from anchor texts of other pages
+	anchorOnly bool
+
 	mux sync.Mutex
 }
 
@@ -56,6 +81,30 @@ type Node struct {
 	// type: map{doogleAddressStr -> *item}
 	items sync.Map
 
+	// deletion-neighbourhood keys points to spellings of indices
+	// type: map{doogleAddressStr -> *spellingValue}
+	spellings sync.Map
+
+	// index addresses points to the items deleted from their posting lists
+	// type: map{doogleAddressStr -> *tombstoneValue}
+	tombstones sync.Map
+
+	// cached copies of posting lists held by other nodes
+	// type: map{doogleAddressStr -> *cachedIndex}
+	indexCaches sync.Map
+
+	// url addresses points to the pages linking to them
+	// type: map{doogleAddressStr -> *backlinkValue}
+	backlinks sync.Map
+
+	// url addresses points to the latest documents
+	// type: map{doogleAddressStr -> *documentValue}
+	documents sync.Map
+
+	// prefix keys points to popular completions
+	// type: map{doogleAddressStr -> *completionValue}
+	completions sync.Map
+
 	// for certificate creation
 	publicKey  []byte
 	secretKey  []byte
@@ -74,8 +123,22 @@ type Node struct {
 	// string -> *grpc.ClientConn
 	nAddrToConn sync.Map
 
-	// pageRank computing queue
-	pageRankComputingQueue chan doogleAddressStr
+	// scheduler of rank computation
+	rankScheduler *rankScheduler
+
+	// algorithm computing the ranks of items
+	ranker          Ranker
+	rankAggregation RankAggregation
+	rankerMux       sync.Mutex
+
+	// pages outside of this node learned at meetings with other nodes
+	world *worldNode
+
+	// queue of tasks publishing values into DHT
+	publishQueue chan func()
+
+	// cache of query results on this node
+	queryCache *queryCache
 }
 
 var _ doogle.DoogleServer = &Node{}
@@ -110,7 +173,34 @@ func (rb *routingBucket) popAndAppend(idx int, ni *nodeInfo) {
 
 type dhtValue struct {
 	itemAddresses []doogleAddressStr
-	mux           sync.Mutex
+
+	// fields of each item in which the index occurred
+	// type: map{doogleAddressStr -> []doogle.Field}
+	fields map[doogleAddressStr][]doogle.Field
+
+	// the latest timestamps each item was stored at, in unix nanoseconds
+	// type: map{doogleAddressStr -> int64}
+	storedAt map[doogleAddressStr]int64
+
+	mux sync.Mutex
+}
+
+// add fields of the item on `addr`. the caller must hold the lock
+func (dv *dhtValue) addFields(addr doogleAddressStr, fs []doogle.Field) {
+	if dv.fields == nil {
+		dv.fields = map[doogleAddressStr][]doogle.Field{}
+	}
+	dv.fields[addr] = mergeFields(dv.fields[addr], fs)
+}
+
+// record the timestamp the item on `addr` stored at. the caller must hold the lock
+func (dv *dhtValue) markStored(addr doogleAddressStr, ts int64) {
+	if dv.storedAt == nil {
+		dv.storedAt = map[doogleAddressStr]int64{}
+	}
+	if ts > dv.storedAt[addr] {
+		dv.storedAt[addr] = ts
+	}
 }
 
 func (n *Node) isValidSender(ct *doogle.NodeCertificate) bool {
@@ -190,9 +280,16 @@ func (n *Node) StoreItem(ctx context.Context, in *doogle.StoreItemRequest) (*doo
 		return nil, status.Error(codes.InvalidArgument, "invalid certificate")
 	}
 
+	// items from the future would freeze their fields and outlive any tombstone
+	if in.Timestamp > time.Now().Add(maxClockSkew).UnixNano() {
+		return nil, status.Error(codes.InvalidArgument, "invalid timestamp")
+	}
+
+	in.Url = normalizeURL(in.Url)
 	es := make([]doogleAddressStr, len(in.EdgeURLs))
 	for i, e := range in.EdgeURLs {
-		h := sha1.Sum([]byte(e))
+		in.EdgeURLs[i] = normalizeURL(e)
+		h := sha1.Sum([]byte(in.EdgeURLs[i]))
 		es[i] = doogleAddressStr(h[:])
 	}
 
@@ -205,16 +302,21 @@ func (n *Node) StoreItem(ctx context.Context, in *doogle.StoreItemRequest) (*doo
 	idxAddr := doogleAddressStr(h[:])
 
 	it := &item{
-		url:      in.Url,
-		dAddrStr: itemAddr,
-		title:    in.Title,
-		edges:    es,
-		mux:      sync.Mutex{},
+		url:        in.Url,
+		dAddrStr:   itemAddr,
+		title:      in.Title,
+		host:       in.Host,
+		lang:       in.Lang,
+		simHash:    in.SimHash,
+		edges:      es,
+		anchorOnly: in.AnchorOnly,
+		mux:        sync.Mutex{},
 	}
 
 	// store item on index
 	actual, _ := n.dht.LoadOrStore(idxAddr, &dhtValue{
 		itemAddresses: []doogleAddressStr{},
+		fields:        map[doogleAddressStr][]doogle.Field{},
 		mux:           sync.Mutex{},
 	})
 
@@ -226,6 +328,28 @@ func (n *Node) StoreItem(ctx context.Context, in *doogle.StoreItemRequest) (*doo
 	dhtV.mux.Lock()
 	defer dhtV.mux.Unlock()
 
+	if ts, ok := n.deletedAt(idxAddr, it.dAddrStr); ok && ts >= dhtV.storedAt[it.dAddrStr] {
+		if in.AnchorOnly || in.Timestamp <= ts {
+			// the item was deleted after this request was made
+			return &doogle.Empty{}, nil
+		}
+	}
+
+	// fields of a newer crawl replace the previous ones except for anchor texts of other pages
+	if !in.AnchorOnly && in.Timestamp > dhtV.storedAt[it.dAddrStr] {
+		var fs []doogle.Field
+		for _, f := range dhtV.fields[it.dAddrStr] {
+			if f == doogle.Field_ANCHOR {
+				fs = append(fs, f)
+			}
+		}
+		if len(fs) != len(dhtV.fields[it.dAddrStr]) {
+			dhtV.fields[it.dAddrStr] = fs
+			n.queryCache.invalidate(idxAddr)
+		}
+	}
+	dhtV.markStored(it.dAddrStr, in.Timestamp)
+
 	var included = false
 	for _, addr := range dhtV.itemAddresses {
 		if addr == it.dAddrStr {
@@ -235,13 +359,53 @@ func (n *Node) StoreItem(ctx context.Context, in *doogle.StoreItemRequest) (*doo
 
 	if !included {
 		dhtV.itemAddresses = append(dhtV.itemAddresses, it.dAddrStr)
+		n.rankScheduler.markTerm(idxAddr, storePriority)
+
+		// publish the spelling and completion when the document frequency reaches a power of two
+		if df := len(dhtV.itemAddresses); df&(df-1) == 0 {
+			term := in.Index
+			n.enqueuePublish(func() {
+				n.publishSpelling(&doogle.Spelling{Term: term, DocFreq: int64(df)})
+
+				// the sum of added scores equals to the document frequency
+				n.publishCompletion(&doogle.Completion{Term: term, Score: float64(df - df/2)})
+			})
+		}
+	}
+	numFields := len(dhtV.fields[it.dAddrStr])
+	dhtV.addFields(it.dAddrStr, in.Fields)
+
+	if !included || len(dhtV.fields[it.dAddrStr]) != numFields {
+		n.queryCache.invalidate(idxAddr)
 	}
 
 	if raw, loaded := n.items.LoadOrStore(it.dAddrStr, it); loaded {
 		prev := raw.(*item)
-		it.localRank = prev.localRank
+		if it.anchorOnly {
+			// anchor texts must not overwrite the item itself
+			return &doogle.Empty{}, nil
+		}
+
+		prev.mux.Lock()
+		it.localRank, it.rankComputedCount, it.rankComputedAt = prev.localRank, prev.rankComputedCount, prev.rankComputedAt
+		it.rankPrior, it.rankHistory = prev.rankPrior, prev.rankHistory
+		prev.mux.Unlock()
 		n.items.Store(it.dAddrStr, it)
+
+		if prev.anchorOnly || !equalAddresses(prev.edges, it.edges) {
+			n.rankScheduler.markItem(it.dAddrStr, storePriority)
+		}
+
+		if prev.anchorOnly {
+			// the item is crawled for the first time
+			go n.crawler.Crawl(in.EdgeURLs)
+			n.logger.Infof("[StoreItem] anchor-only item crawled: url=%s, token=%s", it.url, in.Index)
+		}
 	} else {
+		if !it.anchorOnly {
+			n.rankScheduler.markItem(it.dAddrStr, storePriority)
+		}
+
 		// pass crawler and logging
 		go n.crawler.Crawl(in.EdgeURLs)
 		n.logger.Infof("[StoreItem] new item stored: url=%s, token=%s", it.url, in.Index)
@@ -249,6 +413,27 @@ func (n *Node) StoreItem(ctx context.Context, in *doogle.StoreItemRequest) (*doo
 	return &doogle.Empty{}, nil
 }
 
+// normalizeURL returns the canonical form of the url so that the same page has the same address,
+// or the url as it is if it can't be normalized
+func normalizeURL(url string) string {
+	if nu, err := crawler.NormalizeURL(url); err == nil {
+		return nu
+	}
+	return url
+}
+
+func equalAddresses(xs, ys []doogleAddressStr) bool {
+	if len(xs) != len(ys) {
+		return false
+	}
+	for i := range xs {
+		if xs[i] != ys[i] {
+			return false
+		}
+	}
+	return true
+}
+
 func (n *Node) FindNode(ctx context.Context, in *doogle.FindNodeRequest) (*doogle.NodeInfos, error) {
 	if !n.isValidSender(in.Certificate) {
 		return nil, status.Error(codes.InvalidArgument, "invalid certificate")
@@ -277,6 +462,57 @@ func (n *Node) findNode(targetAddr doogleAddress) ([]*doogle.NodeInfo, error) {
 	return ret, nil
 }
 
+// forEachClosestNode calls `fn` concurrently with the clients of the nodes closest to `addr` and waits for them.
+// each call is bounded by `requestTimeout`.
+// it returns false if no node is closer to `addr` than this node, where the caller uses its own table instead
+func (n *Node) forEachClosestNode(ctx context.Context, addr doogleAddress, fn func(context.Context, doogle.DoogleClient) error) bool {
+	rep, err := n.findNode(addr)
+	if err != nil {
+		// the address of this node itself
+		n.logger.Errorf("failed to find node for %x : %v", addr, err)
+		return false
+	}
+
+	if len(rep) == 0 {
+		return false
+	}
+
+	var wg sync.WaitGroup
+	for _, ni := range rep {
+		wg.Add(1)
+		go func(ni *doogle.NodeInfo) {
+			defer wg.Done()
+
+			ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+			defer cancel()
+
+			conn, err := n.getConnByNetworkAddress(ctx, ni.NetworkAddress)
+			if err != nil {
+				return
+			}
+
+			if err := fn(ctx, doogle.NewDoogleClient(conn)); err != nil {
+				n.logger.Errorf("failed to call %s: %v", ni.NetworkAddress, err)
+			}
+		}(ni)
+	}
+	wg.Wait()
+	return true
+}
+
+// allNodes returns all the nodes in the routing table
+func (n *Node) allNodes() []*doogle.NodeInfo {
+	var ret []*doogle.NodeInfo
+	for _, rb := range n.routingTable {
+		rb.mux.Lock()
+		for _, ni := range rb.bucket {
+			ret = append(ret, &doogle.NodeInfo{DoogleAddress: ni.dAddr[:], NetworkAddress: ni.nAddr})
+		}
+		rb.mux.Unlock()
+	}
+	return ret
+}
+
 func getNextOffset(msb, prevOffset int) (int, error) {
 	var next = prevOffset * -1
 	if prevOffset <= 0 {
@@ -348,13 +584,33 @@ func (n *Node) FindIndex(ctx context.Context, in *doogle.FindIndexRequest) (*doo
 		return nil, status.Error(codes.InvalidArgument, "invalid certificate")
 	}
 
-	return n.findIndex(ctx, doogleAddressStr(in.DoogleAddress))
+	return n.findIndex(ctx, doogleAddressStr(in.DoogleAddress), in.Filter)
 }
 
-func (n *Node) findIndex(ctx context.Context, dAddrStr doogleAddressStr) (*doogle.FindIndexReply, error) {
+func (n *Node) findIndex(ctx context.Context, dAddrStr doogleAddressStr, filter *doogle.Filter) (*doogle.FindIndexReply, error) {
 	var rep = &doogle.FindIndexReply{}
-	raw, ok := n.dht.Load(dAddrStr)
-	if !ok {
+
+	var as []doogleAddressStr
+	var fields map[doogleAddressStr][]doogle.Field
+	if raw, ok := n.dht.Load(dAddrStr); ok {
+		dhtV := raw.(*dhtValue)
+		dhtV.mux.Lock()
+		as = dhtV.itemAddresses // copy slice
+		fields = make(map[doogleAddressStr][]doogle.Field, len(dhtV.fields))
+		for addr, fs := range dhtV.fields {
+			fields[addr] = fs
+		}
+		dhtV.mux.Unlock()
+	}
+
+	// the posting list emptied by deletions is the same as not stored
+	if len(as) == 0 {
+		if its, ok := n.findCachedIndex(dAddrStr, filter); ok {
+			rep.Result = &doogle.FindIndexReply_Items{Items: &doogle.Items{Items: its}}
+			rep.Cached = true
+			return rep, nil
+		}
+
 		res := &doogle.FindIndexReply_NodeInfos{
 			NodeInfos: &doogle.NodeInfos{},
 		}
@@ -371,12 +627,6 @@ func (n *Node) findIndex(ctx context.Context, dAddrStr doogleAddressStr) (*doogl
 		return rep, nil
 	}
 
-	dhtV, ok := raw.(*dhtValue)
-	if !ok {
-		return nil, status.Error(codes.Internal, "failed to convert to *dhtValue")
-	}
-
-	as := dhtV.itemAddresses // copy slice
 	res := &doogle.FindIndexReply_Items{
 		Items: &doogle.Items{
 			Items: make([]*doogle.Item, 0),
@@ -385,11 +635,21 @@ func (n *Node) findIndex(ctx context.Context, dAddrStr doogleAddressStr) (*doogl
 
 	for _, addr := range as {
 		if raw, ok := n.items.Load(addr); ok {
-			if it, ok := raw.(*item); ok {
+			if it, ok := raw.(*item); ok && matchFilter(filter, it, fields[addr]) {
+				rank := n.currentRank(it)
+				it.mux.Lock()
+				computedAt := it.rankComputedAt
+				it.mux.Unlock()
+
 				res.Items.Items = append(res.Items.Items, &doogle.Item{
-					Url:       it.url,
-					LocalRank: it.localRank,
-					Title:     it.title,
+					Url:            it.url,
+					LocalRank:      rank,
+					Title:          it.title,
+					Fields:         fields[addr],
+					Host:           it.host,
+					Lang:           it.lang,
+					SimHash:        it.simHash,
+					RankComputedAt: computedAt,
 				})
 			}
 		}
@@ -399,198 +659,93 @@ func (n *Node) findIndex(ctx context.Context, dAddrStr doogleAddressStr) (*doogl
 	return rep, nil
 }
 
-func (n *Node) GetIndex(ctx context.Context, in *doogle.StringMessage) (*doogle.GetIndexReply, error) {
-
-	// TODO: deal with complex queries, like AND, OR, etc.
-
-	targetAddr := sha1.Sum([]byte(in.Message))
-	var targetAddrStr = doogleAddressStr(targetAddr[:])
-
-	// enqueue PageRank computer
-	go func() {
-		select {
-		case n.pageRankComputingQueue <- targetAddrStr:
-		default: // if the queue is full, ignore it
-		}
-	}()
-
-	res, err := n.findIndex(ctx, targetAddrStr)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "findIndex failed: %v", err)
-	}
-
-	ret := make([]*doogle.Item, 0, maxNumGetItem)
-	scoreMap := map[string]*struct {
-		num int
-		sum float64
-		avg float64
-	}{}
-
-	nas := make([]string, 0, alpha)
-	if its, ok := res.Result.(*doogle.FindIndexReply_Items); ok {
-		for _, it := range its.Items.Items {
-			if v, ok := scoreMap[it.Url]; ok {
-				v.num++
-				v.sum += it.LocalRank
-			} else {
-				scoreMap[it.Url] = &struct {
-					num int
-					sum float64
-					avg float64
-				}{num: 1, sum: it.LocalRank}
-				ret = append(ret, it)
-			}
-		}
-
-		// get nearest nodes
-		var dAddr doogleAddress
-		copy(dAddr[:], targetAddrStr)
-		res, err := n.findNode(dAddr)
-		if err != nil {
-			return nil, status.Errorf(codes.Internal, "findNode failed: %v", err)
-		}
-		for _, r := range res {
-			nas = append(nas, r.NetworkAddress)
-		}
-
-	} else {
-		for _, ni := range res.Result.(*doogle.FindIndexReply_NodeInfos).NodeInfos.Infos {
-			nas = append(nas, ni.NetworkAddress)
-		}
-	}
-
-	var mux sync.Mutex
-	var wg sync.WaitGroup
-	for _, nAddr := range nas {
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-
-			conn, err := n.getConnByNetworkAddress(nAddr)
-			if err != nil {
-				return
-			}
-
-			c := doogle.NewDoogleClient(conn)
-
-			res, err = c.FindIndex(context.Background(), &doogle.FindIndexRequest{
-				Certificate:   n.certificate,
-				DoogleAddress: targetAddr[:],
-			})
-
-			if err != nil {
-				n.logger.Errorf("failed to call FindIndex: %v", err)
-				return
-			}
+func (n *Node) PostUrl(ctx context.Context, in *doogle.StringMessage) (*doogle.StringMessage, error) {
+	in.Message = normalizeURL(in.Message)
 
-			if its, ok := res.Result.(*doogle.FindIndexReply_Items); ok {
-				for _, it := range its.Items.Items {
-					mux.Lock()
-					if v, ok := scoreMap[it.Url]; ok {
-						v.num++
-						v.sum += it.LocalRank
-					} else {
-						scoreMap[it.Url] = &struct {
-							num int
-							sum float64
-							avg float64
-						}{num: 1, sum: it.LocalRank}
-						ret = append(ret, it)
-					}
-					mux.Unlock()
-				}
-				return
-			}
-			res, _ := res.Result.(*doogle.FindIndexReply_NodeInfos)
-			for _, ni := range res.NodeInfos.Infos {
-				conn, err := n.getConnByNetworkAddress(ni.NetworkAddress)
-				if err != nil {
-					return
-				}
-
-				c := doogle.NewDoogleClient(conn)
-				res, err := c.PingWithCertificate(context.Background(), n.certificate)
-				if err != nil {
-					n.logger.Errorf("failed to PingWithCertificate")
-					return
-				}
-				n.isValidSender(res)
-			}
-		}()
+	// analyze the given url
+	page, err := n.crawler.AnalyzePage(ctx, in.Message)
+	if err == crawler.ErrPageGone {
+		n.unlistPage(ctx, in.Message)
+		return &doogle.StringMessage{Message: "page unlisted"}, nil
+	} else if err == crawler.ErrDisallowed {
+		return nil, status.Errorf(codes.PermissionDenied, "url(=%s) is disallowed by robots.txt", in.Message)
+	} else if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to analyze url(=%s): %v", in.Message, err)
 	}
 
-	wg.Wait()
+	// the previous indices of the page to be replaced
+	prev := n.findDocument(ctx, in.Message)
+	now := time.Now().UnixNano()
 
-	// sort by average score
-	for _, v := range scoreMap {
-		v.avg = v.sum / float64(v.num)
-	}
-
-	sort.Slice(ret, func(i, j int) bool {
-		return scoreMap[ret[i].Url].avg > scoreMap[ret[j].Url].avg
-	})
-
-	return &doogle.GetIndexReply{Items: ret}, nil
-}
-
-func (n *Node) PostUrl(ctx context.Context, in *doogle.StringMessage) (*doogle.StringMessage, error) {
-	// analyze the given url
-	title, tokens, eURLs, err := n.crawler.AnalyzePage(in.Message)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to analyze url(=%s): %v", in.Message, err)
+	edges := make([]string, len(page.EdgeURLs))
+	for i, e := range page.EdgeURLs {
+		edges[i] = normalizeURL(e)
 	}
 
 	di := &doogle.StoreItemRequest{
 		Url:         in.Message,
-		Title:       title,
-		EdgeURLs:    eURLs,
+		Title:       page.Title,
+		EdgeURLs:    edges,
+		Host:        hostOf(in.Message),
+		Lang:        page.Lang,
+		SimHash:     page.SimHash,
+		Timestamp:   now,
 		Certificate: n.certificate,
 	}
 
 	// make StoreItem requests to store the url into DHT
-	for _, token := range tokens {
-		addr := sha1.Sum([]byte(token))
-		di.Index = token
-
-		rep, err := n.findNode(addr)
-		if err != nil {
-			n.logger.Errorf("failed to find node for %s : %v", token, err)
-			continue
+	terms := make([]string, 0, len(page.Tokens))
+	for _, token := range page.Tokens {
+		di.Index = token.Term
+		di.Fields = token.Fields
+		n.storeItemOnClosestNodes(ctx, di)
+		terms = append(terms, token.Term)
+	}
+
+	// store anchor texts as indices of the linked pages
+	anchorTerms := make(map[string][]string, len(page.Anchors))
+	for _, a := range page.Anchors {
+		url := normalizeURL(a.URL)
+		anchorTerms[url] = append(anchorTerms[url], difference(a.Terms, anchorTerms[url])...)
+		ai := &doogle.StoreItemRequest{
+			Url:         url,
+			Fields:      []doogle.Field{doogle.Field_ANCHOR},
+			AnchorOnly:  true,
+			Host:        hostOf(url),
+			Timestamp:   now,
+			Certificate: n.certificate,
 		}
 
-		// if the reply is empty, store item into its own table
-		if len(rep) == 0 {
-			_, err = n.StoreItem(context.Background(), di)
-			if err != nil {
-				n.logger.Errorf("failed to call StoreItem: %v", err)
-			}
-		} else {
-			// call StoreItem request on closest nodes
-			var wg = sync.WaitGroup{}
-			for _, ni := range rep {
-				wg.Add(1)
-				go func() {
-					defer wg.Done()
-
-					conn, err := n.getConnByNetworkAddress(ni.NetworkAddress)
-					if err != nil {
-						return
-					}
-
-					c := doogle.NewDoogleClient(conn)
-					_, err = c.StoreItem(context.Background(), di)
-					if err != nil {
-						n.logger.Errorf("failed to call StoreItem: %v", err)
-						return
-					}
-				}()
-			}
-			wg.Wait()
+		for _, term := range a.Terms {
+			ai.Index = term
+			n.storeItemOnClosestNodes(ctx, ai)
 		}
 	}
+
+	// publish reverse edges to the linked pages
+	n.publishBacklinks(ctx, in.Message, page.Title, edges, anchorTerms, now, false)
+
+	// delete the indices the page no longer contains
+	n.replaceDocument(ctx, prev, &doogle.Document{Url: in.Message, Terms: terms, EdgeURLs: edges, Timestamp: now})
 	return &doogle.StringMessage{Message: "post url finished"}, nil
 }
 
+// make StoreItem requests to the nodes closest to the index of `di`
+func (n *Node) storeItemOnClosestNodes(ctx context.Context, di *doogle.StoreItemRequest) {
+	addr := sha1.Sum([]byte(di.Index))
+	if n.forEachClosestNode(ctx, addr, func(ctx context.Context, c doogle.DoogleClient) error {
+		_, err := c.StoreItem(ctx, di)
+		return err
+	}) {
+		return
+	}
+
+	// if no node is closer, store item into its own table
+	if _, err := n.StoreItem(ctx, di); err != nil {
+		n.logger.Errorf("failed to call StoreItem: %v", err)
+	}
+}
+
 func (n *Node) PingWithCertificate(ctx context.Context, in *doogle.NodeCertificate) (*doogle.NodeCertificate, error) {
 	if n.isValidSender(in) {
 		return n.certificate, nil
@@ -603,14 +758,17 @@ func (n *Node) Ping(ctx context.Context, in *doogle.StringMessage) (*doogle.Stri
 }
 
 func (n *Node) PingTo(ctx context.Context, in *doogle.NodeInfo) (*doogle.StringMessage, error) {
-	conn, err := grpc.Dial(in.NetworkAddress, grpc.WithInsecure())
+	dialCtx, cancel := context.WithTimeout(ctx, dialTimeout)
+	defer cancel()
+
+	conn, err := grpc.DialContext(dialCtx, in.NetworkAddress, grpc.WithInsecure(), grpc.WithBlock())
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "did not connect: %v", err)
 	}
 	defer conn.Close()
 
 	c := doogle.NewDoogleClient(conn)
-	r, err := c.PingWithCertificate(context.Background(), n.certificate)
+	r, err := c.PingWithCertificate(ctx, n.certificate)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "c.Ping failed: %v", err)
 	}
@@ -622,15 +780,18 @@ func (n *Node) PingTo(ctx context.Context, in *doogle.NodeInfo) (*doogle.StringM
 	return nil, status.Errorf(codes.Internal, "recipient is invalid")
 }
 
-func (n *Node) getConnByNetworkAddress(nAddr string) (*grpc.ClientConn, error) {
+func (n *Node) getConnByNetworkAddress(ctx context.Context, nAddr string) (*grpc.ClientConn, error) {
 	var conn *grpc.ClientConn
 	var err error
 	raw, ok := n.nAddrToConn.Load(nAddr)
 	if !ok {
 		// ask nearest nodes for nodeInfo nearest to targetAddress
-		conn, err = grpc.Dial(nAddr, grpc.WithInsecure())
+		dialCtx, cancel := context.WithTimeout(ctx, dialTimeout)
+		defer cancel()
+
+		conn, err = grpc.DialContext(dialCtx, nAddr, grpc.WithInsecure(), grpc.WithBlock())
 		if err != nil {
-			return nil, errors.Errorf("did not connect: %v", err)
+			return nil, errors.Wrap(err, "did not connect")
 		}
 
 		n.nAddrToConn.Store(nAddr, conn)
@@ -669,13 +830,18 @@ func NewNode(difficulty int, nAddr string, logger *logrus.Logger, cr crawler.Cra
 
 	// set node parameters
 	node := Node{
-		publicKey:              pk,
-		secretKey:              sk,
-		difficulty:             difficulty,
-		routingTable:           rt,
-		logger:                 logger,
-		crawler:                cr,
-		pageRankComputingQueue: make(chan doogleAddressStr, queueCap),
+		publicKey:       pk,
+		secretKey:       sk,
+		difficulty:      difficulty,
+		routingTable:    rt,
+		logger:          logger,
+		crawler:         cr,
+		publishQueue:    make(chan func(), queueCap),
+		queryCache:      newQueryCache(queryCacheSize, queryCacheTTL),
+		world:           newWorldNode(),
+		rankScheduler:   newRankScheduler(),
+		ranker:          &PageRanker{Damping: damp, Tolerance: tol, MaxIterations: maxRankIterations},
+		rankAggregation: DefaultRankAggregation(),
 	}
 
 	// solve network puzzle
@@ -9,6 +9,7 @@ import (
 	"os"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -38,12 +39,18 @@ var (
 )
 
 type mockCrawler struct {
-	title            string
-	tokens, edgeURLs []string
+	title    string
+	tokens   []*crawler.Token
+	edgeURLs []string
+	anchors  []*crawler.Anchor
+	err      error
 }
 
-func (c *mockCrawler) AnalyzePage(url string) (title string, tokens, edgeURLs []string, err error) {
-	return c.title, c.tokens, c.edgeURLs, nil
+func (c *mockCrawler) AnalyzePage(ctx context.Context, url string) (*crawler.Page, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &crawler.Page{Title: c.title, Tokens: c.tokens, EdgeURLs: c.edgeURLs, Anchors: c.anchors}, nil
 }
 
 func (c *mockCrawler) Crawl([]string) {}
@@ -107,6 +114,13 @@ func resetDHT() {
 		// reset routing table on testServers[0]
 		testServers[i].node.dht = sync.Map{}
 		testServers[i].node.items = sync.Map{}
+		testServers[i].node.tombstones = sync.Map{}
+		testServers[i].node.indexCaches = sync.Map{}
+		testServers[i].node.documents = sync.Map{}
+		testServers[i].node.backlinks = sync.Map{}
+		testServers[i].node.queryCache = newQueryCache(queryCacheSize, queryCacheTTL)
+		testServers[i].node.world = newWorldNode()
+		testServers[i].node.rankScheduler = newRankScheduler()
 	}
 }
 
@@ -421,7 +435,7 @@ func TestNode_StoreItem(t *testing.T) {
 			assert.Equal(t, nil, err)
 
 			// calc item's address
-			h := sha1.Sum([]byte(c.Url))
+			h := sha1.Sum([]byte(normalizeURL(c.Url)))
 			itemAddr := doogleAddressStr(h[:])
 
 			// calc index's address
@@ -467,6 +481,82 @@ func TestNode_StoreItem(t *testing.T) {
 	}
 }
 
+func TestNode_StoreItem_anchorOnly(t *testing.T) {
+	resetDHT()
+	defer resetDHT()
+
+	srv := testServers[0].node
+	h := sha1.Sum([]byte(normalizeURL("url")))
+	itemAddr := doogleAddressStr(h[:])
+
+	for i, cc := range []struct {
+		req           *doogle.StoreItemRequest
+		expTitle      string
+		expAnchorOnly bool
+	}{
+		{
+			req: &doogle.StoreItemRequest{
+				Url: "url", Index: "anchor", AnchorOnly: true,
+				Fields: []doogle.Field{doogle.Field_ANCHOR},
+			},
+			expAnchorOnly: true,
+		},
+		{
+			req:      &doogle.StoreItemRequest{Url: "url", Index: "body", Title: "title"},
+			expTitle: "title",
+		},
+		{
+			// anchor texts must not overwrite the crawled item
+			req: &doogle.StoreItemRequest{
+				Url: "url", Index: "anchor2", AnchorOnly: true,
+				Fields: []doogle.Field{doogle.Field_ANCHOR},
+			},
+			expTitle: "title",
+		},
+	} {
+		c := cc
+		t.Run(fmt.Sprintf("%d-th case", i), func(t *testing.T) {
+			c.req.Certificate = srv.certificate
+			_, err := srv.StoreItem(context.Background(), c.req)
+			assert.Equal(t, nil, err)
+
+			raw, ok := srv.items.Load(itemAddr)
+			assert.Equal(t, true, ok)
+			it := raw.(*item)
+			assert.Equal(t, c.expTitle, it.title)
+			assert.Equal(t, c.expAnchorOnly, it.anchorOnly)
+
+			h := sha1.Sum([]byte(c.req.Index))
+			_, ok = srv.dht.Load(doogleAddressStr(h[:]))
+			assert.Equal(t, true, ok)
+		})
+	}
+}
+
+func TestNode_StoreItem_normalizeURL(t *testing.T) {
+	resetDHT()
+	defer resetDHT()
+
+	srv := testServers[0].node
+	for _, url := range []string{"a.com/x", "http://a.com/x#frag", "HTTP://A.com:80/x"} {
+		_, err := srv.StoreItem(context.Background(), &doogle.StoreItemRequest{
+			Certificate: srv.certificate, Url: url, Index: "doogle", EdgeURLs: []string{"B.com/?utm_source=doogle"},
+		})
+		assert.Equal(t, nil, err)
+	}
+
+	h := sha1.Sum([]byte("http://a.com/x"))
+	raw, ok := srv.items.Load(doogleAddressStr(h[:]))
+	assert.Equal(t, true, ok)
+
+	it := raw.(*item)
+	assert.Equal(t, "http://a.com/x", it.url)
+
+	h = sha1.Sum([]byte("http://b.com/"))
+	assert.DeepEqual(t, []doogleAddressStr{doogleAddressStr(h[:])}, it.edges)
+	assert.DeepEqual(t, []string{"http://a.com/x"}, urlsOf(srv, "doogle"))
+}
+
 func TestGetNextOffset1(t *testing.T) {
 
 	for i, cc := range []struct {
@@ -800,6 +890,34 @@ func TestNode_findNode(t *testing.T) {
 	}
 }
 
+func TestNode_forEachClosestNode(t *testing.T) {
+	resetRoutingTable()
+	defer resetRoutingTable()
+
+	srv := testServers[0].node
+	addr := sha1.Sum([]byte("doogle"))
+
+	var num int32
+	fn := func(ctx context.Context, c doogle.DoogleClient) error {
+		atomic.AddInt32(&num, 1)
+		_, err := c.Ping(ctx, &doogle.StringMessage{})
+		return err
+	}
+
+	// no node is closer than srv
+	assert.Equal(t, false, srv.forEachClosestNode(context.Background(), addr, fn))
+	assert.Equal(t, int32(0), num)
+
+	for _, to := range testServers[1:3] {
+		srv.updateRoutingTable(&nodeInfo{dAddr: to.node.DAddr, nAddr: localhost + to.port})
+	}
+	nis, err := srv.findNode(addr)
+	assert.Equal(t, nil, err)
+
+	assert.Equal(t, true, srv.forEachClosestNode(context.Background(), addr, fn))
+	assert.Equal(t, int32(len(nis)), num)
+}
+
 func TestNode_PostUrl(t *testing.T) {
 	resetRoutingTable()
 	defer resetRoutingTable()
@@ -816,7 +934,10 @@ func TestNode_PostUrl(t *testing.T) {
 			cr: &mockCrawler{
 				title:    "title1",
 				edgeURLs: []string{},
-				tokens:   []string{string([]byte{1}), string([]byte{2})},
+				tokens: []*crawler.Token{
+					{Term: string([]byte{1}), Fields: []doogle.Field{doogle.Field_TITLE}},
+					{Term: string([]byte{2}), Fields: []doogle.Field{doogle.Field_BODY}},
+				},
 			},
 		},
 		{
@@ -824,7 +945,13 @@ func TestNode_PostUrl(t *testing.T) {
 			cr: &mockCrawler{
 				title:    "title1",
 				edgeURLs: []string{"foo.com", "bar.com"},
-				tokens:   []string{"token1", "token2"},
+				tokens: []*crawler.Token{
+					{Term: "token1", Fields: []doogle.Field{doogle.Field_BODY}},
+					{Term: "token2", Fields: []doogle.Field{doogle.Field_HEADING, doogle.Field_BODY}},
+				},
+				anchors: []*crawler.Anchor{
+					{URL: "foo.com", Terms: []string{"token3"}},
+				},
 			},
 		},
 	} {
@@ -838,10 +965,10 @@ func TestNode_PostUrl(t *testing.T) {
 			assert.Equal(t, nil, err)
 
 			for _, token := range c.cr.tokens {
-				h := sha1.Sum([]byte(c.url))
+				h := sha1.Sum([]byte(normalizeURL(c.url)))
 				itemAddrStr := doogleAddressStr(h[:])
 
-				h = sha1.Sum([]byte(token))
+				h = sha1.Sum([]byte(token.Term))
 				tokenAddrStr := doogleAddressStr(h[:])
 				raw, ok := srv.dht.Load(tokenAddrStr)
 				assert.Equal(t, true, ok)
@@ -859,6 +986,7 @@ func TestNode_PostUrl(t *testing.T) {
 					}
 				}
 				assert.Equal(t, true, isIncluded)
+				assert.DeepEqual(t, token.Fields, dhtV.fields[ia])
 
 				raw, ok = srv.items.Load(ia)
 				assert.Equal(t, true, ok)
@@ -866,15 +994,35 @@ func TestNode_PostUrl(t *testing.T) {
 				it, ok := raw.(*item)
 				assert.Equal(t, true, ok)
 				assert.Equal(t, c.cr.title, it.title)
+				assert.Equal(t, hostOf(c.url), it.host)
 				assert.Equal(t, len(c.cr.edgeURLs), len(it.edges))
 
 				for i, eu := range c.cr.edgeURLs {
-					h = sha1.Sum([]byte(eu))
+					h = sha1.Sum([]byte(normalizeURL(eu)))
 					assert.Equal(t, doogleAddressStr(h[:]), it.edges[i])
 				}
 
 				dhtV.mux.Unlock()
 			}
+
+			for _, a := range c.cr.anchors {
+				h := sha1.Sum([]byte(normalizeURL(a.URL)))
+				itemAddrStr := doogleAddressStr(h[:])
+
+				raw, ok := srv.items.Load(itemAddrStr)
+				assert.Equal(t, true, ok)
+				assert.Equal(t, true, raw.(*item).anchorOnly)
+
+				for _, term := range a.Terms {
+					h = sha1.Sum([]byte(term))
+					raw, ok := srv.dht.Load(doogleAddressStr(h[:]))
+					assert.Equal(t, true, ok)
+
+					dhtV := raw.(*dhtValue)
+					assert.DeepEqual(t, []doogleAddressStr{itemAddrStr}, dhtV.itemAddresses)
+					assert.DeepEqual(t, []doogle.Field{doogle.Field_ANCHOR}, dhtV.fields[itemAddrStr])
+				}
+			}
 		})
 	}
 }
@@ -978,7 +1126,7 @@ func TestNode_findIndex_notFound(t *testing.T) {
 			msb := getMostSignificantBit(srv.DAddr.xor(dAddr))
 
 			srv.routingTable[msb].bucket = c.before
-			raw, err := srv.findIndex(context.Background(), doogleAddressStr(c.targetAddr))
+			raw, err := srv.findIndex(context.Background(), doogleAddressStr(c.targetAddr), nil)
 			assert.Equal(t, nil, err)
 
 			ret, ok := raw.Result.(*doogle.FindIndexReply_NodeInfos)
@@ -1040,11 +1188,15 @@ func TestNode_findIndex_Found(t *testing.T) {
 
 			srv.dht.Store(c.dAddrStr, dhtV)
 
-			raw, err := srv.findIndex(context.Background(), c.dAddrStr)
+			raw, err := srv.findIndex(context.Background(), c.dAddrStr, nil)
 			assert.Equal(t, nil, err)
 
+			// the empty posting list is referred to the other nodes
 			ret, ok := raw.Result.(*doogle.FindIndexReply_Items)
-			assert.Equal(t, true, ok)
+			assert.Equal(t, len(c.expItems) > 0, ok)
+			if !ok {
+				return
+			}
 			assert.Equal(t, len(c.expItems), len(ret.Items.Items))
 
 			for j, ai := range ret.Items.Items {
@@ -1130,3 +1282,60 @@ func TestNode_GetIndex(t *testing.T) {
 		})
 	}
 }
+
+func TestNode_GetIndex_filter(t *testing.T) {
+	resetRoutingTable()
+	defer resetRoutingTable()
+	resetDHT()
+	defer resetDHT()
+
+	srv := testServers[0].node
+
+	dhtV := &dhtValue{mux: sync.Mutex{}}
+	for _, it := range []struct {
+		it     *item
+		fields []doogle.Field
+	}{
+		{
+			it:     &item{url: "url1", dAddrStr: "address1", localRank: 0.3, host: "a.com", lang: "en"},
+			fields: []doogle.Field{doogle.Field_BODY},
+		},
+		{
+			it:     &item{url: "url2", dAddrStr: "address2", localRank: 0.2, host: "b.a.com", lang: "ja"},
+			fields: []doogle.Field{doogle.Field_BODY, doogle.Field_TITLE},
+		},
+		{
+			it:     &item{url: "url3", dAddrStr: "address3", localRank: 0.1, host: "b.com", lang: "en"},
+			fields: []doogle.Field{doogle.Field_HEADING},
+		},
+	} {
+		dhtV.itemAddresses = append(dhtV.itemAddresses, it.it.dAddrStr)
+		dhtV.addFields(it.it.dAddrStr, it.fields)
+		srv.items.Store(it.it.dAddrStr, it.it)
+	}
+
+	h := sha1.Sum([]byte("doogle"))
+	srv.dht.Store(doogleAddressStr(h[:]), dhtV)
+
+	for i, cc := range []struct {
+		query   string
+		expUrls []string
+	}{
+		{query: "doogle", expUrls: []string{"url2", "url1", "url3"}},
+		{query: "title:doogle", expUrls: []string{"url2"}},
+		{query: "doogle site:a.com", expUrls: []string{"url1", "url2"}},
+		{query: "doogle lang:en", expUrls: []string{"url1", "url3"}},
+		{query: "doogle inurl:3", expUrls: []string{"url3"}},
+		{query: "title:doogle lang:en", expUrls: []string{}},
+	} {
+		c := cc
+		t.Run(fmt.Sprintf("%d-th case", i), func(t *testing.T) {
+			res, err := srv.GetIndex(context.Background(), &doogle.StringMessage{Message: c.query})
+			assert.Equal(t, nil, err)
+			assert.Equal(t, len(c.expUrls), len(res.Items))
+			for j, ai := range res.Items {
+				assert.Equal(t, c.expUrls[j], ai.Url)
+			}
+		})
+	}
+}
@@ -1,125 +1,213 @@
 package node
 
 import (
-	"fmt"
-	"sync"
 	"time"
-
-	"github.com/pkg/errors"
-	"gonum.org/v1/gonum/graph/network"
-	"gonum.org/v1/gonum/graph/simple"
 )
 
 const (
-	worldNodeID int64 = -1
+	// default parameters of the ranker
 	damp              = 0.80
 	tol               = 1e-3
-)
+	maxRankIterations = 100
 
-var inProcessAddr sync.Map
+	// interval of runs of the rank scheduler
+	rankInterval = 10 * time.Second
 
-func (n *Node) StartPageRankComputer(numWorker int) {
-	for i := 0; i < numWorker; i++ {
-		go func() {
-			var workerFmt = fmt.Sprintf("[%d-th pagerankComputer]", i)
-			n.logger.Infof("%s started", workerFmt)
+	// items linked from a recomputed item are marked dirty with its priority multiplied by `priorityDecay`
+	// unless it goes below `minPropagatedPriority`
+	priorityDecay         = 0.8
+	minPropagatedPriority = 0.1
+)
 
-			for {
-				time.Sleep(1 * time.Second)
-				dAddr, _ := <-n.pageRankComputingQueue
-				if err := n.computeLocalRank(dAddr); err != nil {
-					n.logger.Errorf("%s failed to compute: %v", workerFmt, err)
-				}
-			}
-		}()
-	}
+// StartRankScheduler periodically recomputes the ranks of the dirty items and terms
+func (n *Node) StartRankScheduler() {
+	go func() {
+		n.logger.Infof("[rankScheduler] started: interval=%v", rankInterval)
+		for range time.Tick(rankInterval) {
+			n.runRankScheduler()
+		}
+	}()
 }
 
-func (n *Node) computeLocalRank(key doogleAddressStr) error {
-	if _, loaded := inProcessAddr.LoadOrStore(key, struct{}{}); loaded {
-		return nil
-	}
-
+// numTermItems returns the number of items on the term
+func (n *Node) numTermItems(key doogleAddressStr) int {
 	raw, ok := n.dht.Load(key)
 	if !ok {
-		return errors.Errorf("not found")
-	}
-
-	dhtV, ok := raw.(*dhtValue)
-	if !ok {
-		return errors.Errorf("type assertion failed")
+		return 0
 	}
 
+	dhtV := raw.(*dhtValue)
 	dhtV.mux.Lock()
+	defer dhtV.mux.Unlock()
+	return len(dhtV.itemAddresses)
+}
 
-	if len(dhtV.itemAddresses) < 2 {
-		return nil
+// runRankScheduler recomputes the ranks of the dirty items within the budget, and returns the number of them.
+// the items linked from ones whose rank has changed get dirty in turn
+func (n *Node) runRankScheduler() int {
+	tasks := n.rankScheduler.next(maxRankItemsPerRun, n.numTermItems)
+	if len(tasks) == 0 {
+		return 0
 	}
 
-	g := simple.NewDirectedGraph()
-	// add world node
-	g.AddNode(simple.Node(worldNodeID))
-
-	addrToID := make(map[doogleAddressStr]int64, len(dhtV.itemAddresses))
-	idToAddr := make(map[int64]doogleAddressStr, len(dhtV.itemAddresses))
-	for id, addr := range dhtV.itemAddresses {
-		id64 := int64(id + 1)
-		addrToID[addr] = id64
-		idToAddr[id64] = addr
-		g.AddNode(simple.Node(id))
+	// type: map{doogleAddressStr -> priority}
+	priorities := map[doogleAddressStr]float64{}
+	add := func(addr doogleAddressStr, p float64) {
+		if p > priorities[addr] {
+			priorities[addr] = p
+		}
 	}
 
-	dhtV.mux.Unlock()
-
-	for addr, fromID := range addrToID {
-		raw, ok := n.items.Load(addr)
-		if !ok {
-			n.logger.Errorf("failed to get the address of item %s", addr)
+	for _, t := range tasks {
+		if !t.isTerm {
+			add(t.addr, t.priority)
 			continue
 		}
 
-		it, ok := raw.(*item)
+		raw, ok := n.dht.Load(t.addr)
 		if !ok {
-			n.logger.Errorf("type assertion failed.")
 			continue
 		}
 
-		for _, edge := range it.edges {
-			if toID, ok := addrToID[edge]; ok && g.Node(toID) != nil {
-				g.SetEdge(simple.Edge{F: simple.Node(fromID), T: simple.Node(toID)})
-			} else {
-				// set edge to world node
-				g.SetEdge(simple.Edge{F: simple.Node(fromID), T: simple.Node(worldNodeID)})
-				g.SetEdge(simple.Edge{F: simple.Node(worldNodeID), T: simple.Node(fromID)})
-			}
+		dhtV := raw.(*dhtValue)
+		dhtV.mux.Lock()
+		for _, addr := range dhtV.itemAddresses {
+			add(addr, t.priority)
 		}
+		dhtV.mux.Unlock()
 	}
 
-	ranks := network.PageRank(g, damp, tol)
+	its := make([]*item, 0, len(priorities))
+	for addr := range priorities {
+		if raw, ok := n.items.Load(addr); ok && !raw.(*item).anchorOnly {
+			its = append(its, raw.(*item))
+		}
+	}
 
-	for addr, id := range addrToID {
-		raw, ok := n.items.Load(addr)
-		if !ok {
-			n.logger.Errorf("failed to get the address of item %s", addr)
+	now := time.Now()
+	n.computeLocalRank(its, now)
+
+	for _, it := range its {
+		p := priorities[it.dAddrStr] * priorityDecay
+		if p < minPropagatedPriority {
 			continue
 		}
 
-		it, ok := raw.(*item)
-		if !ok {
-			n.logger.Errorf("type assertion failed.")
-			continue
+		for _, e := range it.edges {
+			if _, ok := priorities[e]; !ok && n.isLocalPage(e) {
+				n.rankScheduler.markItem(e, p)
+			}
 		}
+	}
 
-		rank, _ := ranks[id]
+	n.rankScheduler.done(tasks, len(its), now)
+	return len(its)
+}
 
-		it.mux.Lock()
+// computeLocalRank updates the ranks of the items with the ranker over the graph of all the items on this node
+// and the world node, where the ranks of the other items are fixed
+func (n *Node) computeLocalRank(its []*item, now time.Time) {
+	if len(its) == 0 {
+		return
+	}
+
+	r := n.getRanker()
+	graphItems := its
+	if wr, ok := r.(wholeGraphRanker); ok && wr.wholeGraph() {
+		graphItems = n.withOtherItems(its)
+	}
+
+	g := n.rankGraph(graphItems)
+	ranks := r.Rank(g)
+	a := n.getRankAggregation()
 
-		// update PageRank
-		it.rankComputedCount++
-		it.localRank += (rank - it.localRank) / it.rankComputedCount
+	// only the given items are updated, which come first in the graph
+	for i, it := range its {
+		it.mux.Lock()
+		it.updateRank(ranks[i], 1/g.Total, now, a)
 		it.mux.Unlock()
 	}
+}
+
+// withOtherItems returns the items followed by all the other items on this node except for anchor-only ones
+func (n *Node) withOtherItems(its []*item) []*item {
+	included := make(map[doogleAddressStr]struct{}, len(its))
+	for _, it := range its {
+		included[it.dAddrStr] = struct{}{}
+	}
 
-	inProcessAddr.Delete(key)
-	return nil
+	ret := append([]*item{}, its...)
+	n.items.Range(func(_, raw interface{}) bool {
+		it := raw.(*item)
+		if _, ok := included[it.dAddrStr]; !ok && !it.anchorOnly {
+			ret = append(ret, it)
+		}
+		return true
+	})
+	return ret
+}
+
+// rankGraph builds the graph of the items and the world node as in JXP.
+// the world node links to each item with the weight of the scores flowing from the pages outside of them,
+// i.e. the other items on this node and the pages known at meetings
+func (n *Node) rankGraph(its []*item) *RankGraph {
+	idx := make(map[doogleAddressStr]int, len(its))
+	for i, it := range its {
+		idx[it.dAddrStr] = i
+	}
+
+	g := &RankGraph{
+		Hosts:     make([]string, len(its)),
+		Out:       make([][]int, len(its)),
+		OutDegree: make([]int, len(its)),
+		WorldIn:   make([]float64, len(its)),
+		Init:      make([]float64, len(its)),
+	}
+
+	for i, it := range its {
+		g.Hosts[i] = it.host
+		g.OutDegree[i] = len(it.edges)
+		for _, e := range it.edges {
+			if j, ok := idx[e]; ok {
+				g.Out[i] = append(g.Out[i], j)
+			}
+		}
+
+		g.Init[i] = n.currentRank(it)
+	}
+
+	flow := func(score float64, edges []doogleAddressStr) {
+		for _, e := range edges {
+			if i, ok := idx[e]; ok {
+				g.WorldIn[i] += score / float64(len(edges))
+			}
+		}
+	}
+
+	var numOthers int
+	n.items.Range(func(_, raw interface{}) bool {
+		it := raw.(*item)
+		if _, ok := idx[it.dAddrStr]; ok || it.anchorOnly {
+			return true
+		}
+
+		flow(n.currentRank(it), it.edges)
+		numOthers++
+		return true
+	})
+
+	n.world.mux.Lock()
+	for addr, wp := range n.world.pages {
+		if _, ok := idx[addr]; !ok {
+			flow(wp.Score, wp.Edges)
+		}
+	}
+	numOthers += len(n.world.pages)
+	n.world.mux.Unlock()
+
+	g.Total = float64(len(its) + numOthers)
+	if numOthers == 0 {
+		g.Total++
+	}
+	return g
 }
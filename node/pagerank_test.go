@@ -1,97 +1,116 @@
 package node
 
 import (
-	"fmt"
-	"sync"
+	"math"
 	"testing"
+	"time"
 
+	"github.com/mathetake/doogle/grpc"
 	"gotest.tools/assert"
 )
 
-func TestNode_computeLocalRank(t *testing.T) {
+func TestNode_runRankScheduler(t *testing.T) {
 	node, err := NewNode(1, "doogle", logger, nil, 10)
 	if err != nil {
 		t.Fatalf("NewNode failed: %v", err)
 	}
 
-	cases := []struct {
-		key     doogleAddressStr
-		dhtV    *dhtValue
-		items   map[doogleAddressStr]*item
-		highest doogleAddressStr
-	}{
-		{
-			key: doogleAddressStr([]byte{1, 0, 0}),
-			dhtV: &dhtValue{
-				itemAddresses: []doogleAddressStr{
-					"1", "2", "3",
-				},
-				mux: sync.Mutex{},
-			},
-			items: map[doogleAddressStr]*item{
-				"1": {
-					dAddrStr:  "1",
-					localRank: 0,
-					edges:     []doogleAddressStr{"3"},
-				},
-				"2": {
-					dAddrStr:  "2",
-					localRank: 0,
-					edges:     []doogleAddressStr{"3"},
-				},
-				"3": {
-					dAddrStr:  "3",
-					localRank: 0,
-					edges:     []doogleAddressStr{"1"},
-				},
-			},
-		},
-		{
-			key: doogleAddressStr([]byte{1, 0, 0}),
-			dhtV: &dhtValue{
-				itemAddresses: []doogleAddressStr{
-					"1", "2", "3",
-				},
-				mux: sync.Mutex{},
-			},
-			items: map[doogleAddressStr]*item{
-				"1": {
-					dAddrStr:          "1",
-					localRank:         0.4,
-					rankComputedCount: 1,
-					edges:             []doogleAddressStr{"3"},
-				},
-				"2": {
-					dAddrStr:          "2",
-					localRank:         0.1,
-					rankComputedCount: 2,
-					edges:             []doogleAddressStr{"3"},
-				},
-				"3": {
-					dAddrStr:          "3",
-					localRank:         0.001,
-					rankComputedCount: 3,
-					edges:             []doogleAddressStr{"1"},
-				},
-			},
-		},
+	items := map[doogleAddressStr]*item{
+		"1": {dAddrStr: "1", edges: []doogleAddressStr{"3"}},
+		"2": {dAddrStr: "2", edges: []doogleAddressStr{"3"}},
+		"3": {dAddrStr: "3", edges: []doogleAddressStr{"1"}},
+		"4": {dAddrStr: "4", anchorOnly: true},
+	}
+	for addr, it := range items {
+		node.items.Store(addr, it)
+	}
+
+	// nothing is dirty
+	assert.Equal(t, 0, node.runRankScheduler())
+
+	for _, addr := range []doogleAddressStr{"1", "2", "3", "4"} {
+		node.rankScheduler.markItem(addr, storePriority)
+	}
+	assert.Equal(t, 3, node.runRankScheduler())
+	assert.Assert(t, items["3"].localRank > items["1"].localRank)
+	assert.Assert(t, items["1"].localRank > items["2"].localRank)
+	assert.Equal(t, 0.0, items["4"].localRank)
+	for _, addr := range []doogleAddressStr{"1", "2", "3"} {
+		assert.Equal(t, 1.0, items[addr].rankComputedCount)
+		assert.Assert(t, items[addr].rankComputedAt > 0)
+	}
+	assert.Equal(t, int32(0), node.rankScheduler.stats().NumDirtyItems)
+
+	// the change propagates along the links while the priority is high enough
+	node.rankScheduler.markItem("2", storePriority)
+	for _, exp := range []doogleAddressStr{"2", "3", "1", "3", "1", "3", "1", "3", "1", "3", "1"} {
+		before := items[exp].rankComputedCount
+		assert.Equal(t, 1, node.runRankScheduler())
+		assert.Equal(t, before+1, items[exp].rankComputedCount)
+	}
+	assert.Equal(t, 0, node.runRankScheduler())
+}
+
+func TestNode_rankGraph(t *testing.T) {
+	node, err := NewNode(1, "doogle", logger, nil, 10)
+	if err != nil {
+		t.Fatalf("NewNode failed: %v", err)
+	}
+
+	its := []*item{
+		{dAddrStr: "1", edges: []doogleAddressStr{"2"}},
+		{dAddrStr: "2", edges: []doogleAddressStr{"1", "3"}},
 	}
 
-	for i, cc := range cases {
-		c := cc
-		t.Run(fmt.Sprintf("%d-th case", i), func(t *testing.T) {
-			node.dht.Store(c.key, c.dhtV)
-
-			for _, addr := range c.dhtV.itemAddresses {
-				it, ok := c.items[addr]
-				if !ok {
-					t.Fatal("failed to get *item")
-				}
-				node.items.Store(addr, it)
-			}
-
-			err := node.computeLocalRank(c.key)
-			assert.Equal(t, true, err == nil)
-		})
+	before := node.ranker.Rank(node.rankGraph(its))
+	assert.Assert(t, before[1] > before[0])
+
+	// the world node learns the page linking to "1"
+	node.world.merge([]*doogle.PageScore{
+		{DoogleAddress: []byte("4"), Score: 0.5, Edges: [][]byte{[]byte("1")}, Owned: true},
+	}, "other", time.Now(), node.isLocalPage)
+
+	after := node.ranker.Rank(node.rankGraph(its))
+	assert.Assert(t, after[0] > before[0])
+	assert.Assert(t, after[0] > after[1])
+}
+
+func TestNode_computeLocalRank_wholeGraph(t *testing.T) {
+	node, err := NewNode(1, "doogle", logger, nil, 10)
+	if err != nil {
+		t.Fatalf("NewNode failed: %v", err)
 	}
+	node.SetRanker(&HITSRanker{Tolerance: 1e-9, MaxIterations: maxRankIterations})
+
+	items := map[doogleAddressStr]*item{
+		"1": {dAddrStr: "1", edges: []doogleAddressStr{"3"}},
+		"2": {dAddrStr: "2", edges: []doogleAddressStr{"3"}},
+		"3": {dAddrStr: "3", edges: []doogleAddressStr{"1"}},
+		"4": {dAddrStr: "4", edges: []doogleAddressStr{"1", "3"}},
+	}
+	all := make([]*item, 0, len(items))
+	for addr, it := range items {
+		node.items.Store(addr, it)
+		all = append(all, it)
+	}
+
+	now := time.Now()
+	node.computeLocalRank(all, now)
+
+	full := map[doogleAddressStr]float64{}
+	var sum float64
+	for addr, it := range items {
+		full[addr] = it.localRank
+		sum += it.localRank
+	}
+
+	// the scores are on the scale of PageRank
+	assert.Assert(t, math.Abs(sum-4.0/5) < 1e-6)
+	assert.Assert(t, full["3"] > full["1"])
+
+	// recomputing an item alone keeps the score comparable with the others
+	node.computeLocalRank([]*item{items["3"]}, now)
+	assert.Assert(t, math.Abs(items["3"].localRank-full["3"]) < 1e-6)
+	assert.Equal(t, 2.0, items["3"].rankComputedCount)
+	assert.Equal(t, 1.0, items["1"].rankComputedCount)
 }
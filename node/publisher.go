@@ -0,0 +1,26 @@
+package node
+
+import (
+	"fmt"
+)
+
+// enqueue a task publishing values into DHT
+func (n *Node) enqueuePublish(task func()) {
+	select {
+	case n.publishQueue <- task:
+	default: // if the queue is full, ignore it
+	}
+}
+
+func (n *Node) StartPublisher(numWorker int) {
+	for i := 0; i < numWorker; i++ {
+		go func(i int) {
+			var workerFmt = fmt.Sprintf("[%d-th publisher]", i)
+			n.logger.Infof("%s started", workerFmt)
+
+			for task := range n.publishQueue {
+				task()
+			}
+		}(i)
+	}
+}
@@ -0,0 +1,197 @@
+package node
+
+import (
+	"crypto/sha1"
+	"math/bits"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/mathetake/doogle/grpc"
+)
+
+// query operators
+const (
+	operatorTitle = "title:"
+	operatorSite  = "site:"
+	operatorInURL = "inurl:"
+	operatorLang  = "lang:"
+	operatorLink  = "link:"
+
+	// deadline of the query, e.g. timeout:500ms
+	operatorTimeout = "timeout:"
+)
+
+// max Hamming distance between SimHashes of near-duplicate items
+const simHashThreshold = 3
+
+// boost on score for each field an index occurred in
+var fieldBoosts = map[doogle.Field]float64{
+	doogle.Field_BODY:    1.0,
+	doogle.Field_TITLE:   3.0,
+	doogle.Field_HEADING: 2.0,
+	doogle.Field_ANCHOR:  1.5,
+}
+
+// parseQuery splits the given query into the index to search, the filter for items
+// and the timeout of the query. zero timeout means the default one.
+func parseQuery(q string) (string, *doogle.Filter, time.Duration) {
+	var terms []string
+	var timeout time.Duration
+	f := &doogle.Filter{}
+	for _, w := range strings.Fields(q) {
+		op, v := splitOperator(w)
+		switch op {
+		case operatorTitle:
+			f.Fields = mergeFields(f.Fields, []doogle.Field{doogle.Field_TITLE})
+			terms = append(terms, v)
+		case operatorSite:
+			f.Site = strings.ToLower(v)
+		case operatorInURL:
+			f.Inurl = strings.ToLower(v)
+		case operatorLang:
+			f.Lang = strings.ToLower(v)
+		case operatorLink:
+			f.Link = normalizeURL(v)
+		case operatorTimeout:
+			// invalid timeouts are ignored
+			if d, err := time.ParseDuration(v); err == nil && d > 0 {
+				timeout = d
+			}
+		default:
+			terms = append(terms, w)
+		}
+	}
+	return strings.Join(terms, " "), f, timeout
+}
+
+// splitOperator returns the operator and its value if `w` is an operator with non-empty value
+func splitOperator(w string) (string, string) {
+	for _, op := range []string{operatorTitle, operatorSite, operatorInURL, operatorLang, operatorLink, operatorTimeout} {
+		if strings.HasPrefix(w, op) && len(w) > len(op) {
+			return op, w[len(op):]
+		}
+	}
+	return "", w
+}
+
+// hostOf returns the lower-cased host of the given url without port
+func hostOf(rawURL string) string {
+	if !strings.Contains(rawURL, "://") {
+		rawURL = "http://" + rawURL
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(u.Hostname())
+}
+
+// mergeFields returns the union of the given fields
+func mergeFields(fs, others []doogle.Field) []doogle.Field {
+	for _, o := range others {
+		var included bool
+		for _, f := range fs {
+			if f == o {
+				included = true
+				break
+			}
+		}
+		if !included {
+			fs = append(fs, o)
+		}
+	}
+	return fs
+}
+
+// fieldBoost returns the largest boost among the given fields
+func fieldBoost(fs []doogle.Field) float64 {
+	if len(fs) == 0 {
+		// items stored without fields are treated as body
+		return fieldBoosts[doogle.Field_BODY]
+	}
+
+	var ret float64
+	for _, f := range fs {
+		if b := fieldBoosts[f]; b > ret {
+			ret = b
+		}
+	}
+	return ret
+}
+
+// matchFilter reports whether the item whose index occurred in `fs` satisfies the filter
+func matchFilter(filter *doogle.Filter, it *item, fs []doogle.Field) bool {
+	if filter == nil {
+		return true
+	}
+
+	if filter.Site != "" && it.host != filter.Site && !strings.HasSuffix(it.host, "."+filter.Site) {
+		return false
+	}
+
+	if filter.Inurl != "" && !strings.Contains(strings.ToLower(it.url), filter.Inurl) {
+		return false
+	}
+
+	if filter.Lang != "" && it.lang != filter.Lang {
+		return false
+	}
+
+	if filter.Link != "" && !linksTo(it, filter.Link) {
+		return false
+	}
+
+	if len(filter.Fields) == 0 {
+		return true
+	}
+
+	for _, want := range filter.Fields {
+		for _, f := range fs {
+			if f == want {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// linksTo reports whether the item has a hyperlink to the url
+func linksTo(it *item, url string) bool {
+	h := sha1.Sum([]byte(url))
+	for _, e := range it.edges {
+		if e == doogleAddressStr(h[:]) {
+			return true
+		}
+	}
+	return false
+}
+
+// collapseDuplicates removes items whose SimHash is within simHashThreshold of a preceding item,
+// and returns the rest with the number of removed items.
+func collapseDuplicates(its []*doogle.Item) ([]*doogle.Item, int) {
+	ret := make([]*doogle.Item, 0, len(its))
+	var kept []uint64
+	for _, it := range its {
+		var dup bool
+		if it.SimHash != 0 { // zero means unknown
+			for _, h := range kept {
+				if bits.OnesCount64(h^it.SimHash) <= simHashThreshold {
+					dup = true
+					break
+				}
+			}
+		}
+
+		if dup {
+			continue
+		}
+
+		if it.SimHash != 0 {
+			kept = append(kept, it.SimHash)
+		}
+		ret = append(ret, it)
+	}
+	return ret, len(its) - len(ret)
+}
@@ -0,0 +1,170 @@
+package node
+
+import (
+	"crypto/sha1"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mathetake/doogle/grpc"
+	"gotest.tools/assert"
+)
+
+func TestParseQuery(t *testing.T) {
+	for i, cc := range []struct {
+		query      string
+		expTerm    string
+		expFilter  *doogle.Filter
+		expTimeout time.Duration
+	}{
+		{query: "doogle", expTerm: "doogle", expFilter: &doogle.Filter{}},
+		{query: " doogle ", expTerm: "doogle", expFilter: &doogle.Filter{}},
+		{
+			query:     "title:doogle",
+			expTerm:   "doogle",
+			expFilter: &doogle.Filter{Fields: []doogle.Field{doogle.Field_TITLE}},
+		},
+		{query: "title:", expTerm: "title:", expFilter: &doogle.Filter{}},
+		{
+			query:     string([]byte{0, 0, 1}),
+			expTerm:   string([]byte{0, 0, 1}),
+			expFilter: &doogle.Filter{},
+		},
+		{
+			query:     "doogle site:Example.com inurl:Wiki lang:EN",
+			expTerm:   "doogle",
+			expFilter: &doogle.Filter{Site: "example.com", Inurl: "wiki", Lang: "en"},
+		},
+		{
+			query:      "doogle timeout:500ms",
+			expTerm:    "doogle",
+			expFilter:  &doogle.Filter{},
+			expTimeout: 500 * time.Millisecond,
+		},
+		{query: "doogle timeout:never", expTerm: "doogle", expFilter: &doogle.Filter{}},
+		{
+			query:     "link:https://golang.org/Doc",
+			expTerm:   "",
+			expFilter: &doogle.Filter{Link: "https://golang.org/Doc"},
+		},
+		{query: "doogle timeout:-1s", expTerm: "doogle", expFilter: &doogle.Filter{}},
+	} {
+		c := cc
+		t.Run(fmt.Sprintf("%d-th case", i), func(t *testing.T) {
+			term, filter, timeout := parseQuery(c.query)
+			assert.Equal(t, c.expTerm, term)
+			assert.Equal(t, c.expTimeout, timeout)
+			assert.DeepEqual(t, c.expFilter.Fields, filter.Fields)
+			assert.Equal(t, c.expFilter.Site, filter.Site)
+			assert.Equal(t, c.expFilter.Inurl, filter.Inurl)
+			assert.Equal(t, c.expFilter.Lang, filter.Lang)
+			assert.Equal(t, c.expFilter.Link, filter.Link)
+		})
+	}
+}
+
+func TestHostOf(t *testing.T) {
+	for i, cc := range []struct {
+		url, exp string
+	}{
+		{"https://www.Example.com:8080/a", "www.example.com"},
+		{"example.com/a", "example.com"},
+		{"", ""},
+	} {
+		c := cc
+		t.Run(fmt.Sprintf("%d-th case", i), func(t *testing.T) {
+			assert.Equal(t, c.exp, hostOf(c.url))
+		})
+	}
+}
+
+func TestMatchFilter(t *testing.T) {
+	h := sha1.Sum([]byte("https://golang.org"))
+	it := &item{
+		url: "https://en.example.com/Wiki/Go", host: "en.example.com", lang: "en",
+		edges: []doogleAddressStr{doogleAddressStr(h[:])},
+	}
+
+	for i, cc := range []struct {
+		filter *doogle.Filter
+		fields []doogle.Field
+		exp    bool
+	}{
+		{filter: &doogle.Filter{Site: "example.com"}, exp: true},
+		{filter: &doogle.Filter{Site: "en.example.com"}, exp: true},
+		{filter: &doogle.Filter{Site: "ample.com"}, exp: false},
+		{filter: &doogle.Filter{Inurl: "wiki"}, exp: true},
+		{filter: &doogle.Filter{Inurl: "rust"}, exp: false},
+		{filter: &doogle.Filter{Lang: "en"}, exp: true},
+		{filter: &doogle.Filter{Lang: "ja"}, exp: false},
+		{filter: &doogle.Filter{Link: "https://golang.org"}, exp: true},
+		{filter: &doogle.Filter{Link: "https://golang.org/doc"}, exp: false},
+		{filter: nil, fields: nil, exp: true},
+		{filter: &doogle.Filter{}, fields: []doogle.Field{doogle.Field_BODY}, exp: true},
+		{
+			filter: &doogle.Filter{Fields: []doogle.Field{doogle.Field_TITLE}},
+			fields: []doogle.Field{doogle.Field_BODY, doogle.Field_TITLE},
+			exp:    true,
+		},
+		{
+			filter: &doogle.Filter{Fields: []doogle.Field{doogle.Field_TITLE}},
+			fields: []doogle.Field{doogle.Field_BODY},
+			exp:    false,
+		},
+		{
+			filter: &doogle.Filter{Fields: []doogle.Field{doogle.Field_TITLE}},
+			fields: nil,
+			exp:    false,
+		},
+	} {
+		c := cc
+		t.Run(fmt.Sprintf("%d-th case", i), func(t *testing.T) {
+			assert.Equal(t, c.exp, matchFilter(c.filter, it, c.fields))
+		})
+	}
+}
+
+func TestFieldBoost(t *testing.T) {
+	assert.Equal(t, fieldBoosts[doogle.Field_BODY], fieldBoost(nil))
+	assert.Equal(t, fieldBoosts[doogle.Field_TITLE], fieldBoost([]doogle.Field{doogle.Field_BODY, doogle.Field_TITLE}))
+	assert.Equal(t, fieldBoosts[doogle.Field_HEADING], fieldBoost([]doogle.Field{doogle.Field_HEADING}))
+}
+
+func TestCollapseDuplicates(t *testing.T) {
+	for i, cc := range []struct {
+		items     []*doogle.Item
+		expUrls   []string
+		expHidden int
+	}{
+		{
+			items: []*doogle.Item{
+				{Url: "a", SimHash: 0xff00},
+				{Url: "b", SimHash: 0xff01},
+				{Url: "c", SimHash: 0x00ff},
+				{Url: "d", SimHash: 0xff07},
+			},
+			expUrls:   []string{"a", "c"},
+			expHidden: 2,
+		},
+		{
+			items: []*doogle.Item{
+				{Url: "a"},
+				{Url: "b"},
+				{Url: "c", SimHash: 0xf0f0},
+				{Url: "d", SimHash: 0xf0f0f},
+			},
+			expUrls:   []string{"a", "b", "c", "d"},
+			expHidden: 0,
+		},
+	} {
+		c := cc
+		t.Run(fmt.Sprintf("%d-th case", i), func(t *testing.T) {
+			actual, hidden := collapseDuplicates(c.items)
+			assert.Equal(t, c.expHidden, hidden)
+			assert.Equal(t, len(c.expUrls), len(actual))
+			for j, it := range actual {
+				assert.Equal(t, c.expUrls[j], it.Url)
+			}
+		})
+	}
+}
@@ -0,0 +1,303 @@
+package node
+
+import (
+	"math"
+	"reflect"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// names of the ranking algorithms
+const (
+	RankerPageRank             = "pagerank"
+	RankerHITSAuthority        = "hits-authority"
+	RankerHITSHub              = "hits-hub"
+	RankerPersonalizedPageRank = "personalized-pagerank"
+)
+
+// RankGraph is the graph of items whose ranks are computed, which is a part of the local graph on the node.
+// the pages outside of it are represented by the world node
+type RankGraph struct {
+	Hosts []string
+
+	// Out[i] is the indices of the items linked from the i-th item
+	Out [][]int
+
+	// OutDegree[i] is the number of all the links from the i-th item including ones to the outside
+	OutDegree []int
+
+	// WorldIn[i] is the score flowing into the i-th item from the pages outside of the graph
+	WorldIn []float64
+
+	// estimated number of all the pages
+	Total float64
+
+	// current ranks of the items
+	Init []float64
+}
+
+func (g *RankGraph) size() int {
+	return len(g.Out)
+}
+
+// Ranker computes the ranks of the items on the graph
+type Ranker interface {
+	Rank(g *RankGraph) []float64
+}
+
+// wholeGraphRanker is implemented by the rankers whose scores depend on all the items of the graph.
+// they run over all the items on the node even when only some of them are recomputed
+type wholeGraphRanker interface {
+	wholeGraph() bool
+}
+
+type RankerConfig struct {
+	Algorithm     string
+	Damping       float64
+	Tolerance     float64
+	MaxIterations int
+
+	// domains from which personalized PageRank flows. subdomains are included
+	TrustedDomains []string
+}
+
+func DefaultRankerConfig() RankerConfig {
+	return RankerConfig{
+		Algorithm:     RankerPageRank,
+		Damping:       damp,
+		Tolerance:     tol,
+		MaxIterations: maxRankIterations,
+	}
+}
+
+func NewRanker(cfg RankerConfig) (Ranker, error) {
+	if cfg.Damping <= 0 || cfg.Damping >= 1 {
+		return nil, errors.Errorf("damping must be in (0, 1): %v", cfg.Damping)
+	}
+
+	if cfg.Tolerance <= 0 || cfg.MaxIterations <= 0 {
+		return nil, errors.Errorf("invalid tolerance or max iterations: %v, %d", cfg.Tolerance, cfg.MaxIterations)
+	}
+
+	switch cfg.Algorithm {
+	case RankerPageRank:
+		return &PageRanker{Damping: cfg.Damping, Tolerance: cfg.Tolerance, MaxIterations: cfg.MaxIterations}, nil
+	case RankerPersonalizedPageRank:
+		if len(cfg.TrustedDomains) == 0 {
+			return nil, errors.New("no trusted domain")
+		}
+		return &PageRanker{
+			Damping: cfg.Damping, Tolerance: cfg.Tolerance, MaxIterations: cfg.MaxIterations,
+			TrustedDomains: cfg.TrustedDomains,
+		}, nil
+	case RankerHITSAuthority, RankerHITSHub:
+		return &HITSRanker{
+			Tolerance: cfg.Tolerance, MaxIterations: cfg.MaxIterations,
+			Hub: cfg.Algorithm == RankerHITSHub,
+		}, nil
+	}
+	return nil, errors.Errorf("unknown ranking algorithm: %s", cfg.Algorithm)
+}
+
+// SetRanker replaces the algorithm computing the ranks of items.
+// the ranks computed by the previous one are discarded as they are on a different scale
+func (n *Node) SetRanker(r Ranker) {
+	n.rankerMux.Lock()
+	changed := !reflect.DeepEqual(n.ranker, r)
+	n.ranker = r
+	n.rankerMux.Unlock()
+
+	if changed {
+		n.resetRanks()
+	}
+}
+
+// resetRanks forgets the ranks and their history of all the items, and marks them dirty
+func (n *Node) resetRanks() {
+	n.items.Range(func(_, raw interface{}) bool {
+		it := raw.(*item)
+		it.mux.Lock()
+		it.localRank, it.rankComputedCount, it.rankComputedAt = 0, 0, 0
+		it.rankPrior, it.rankHistory = 0, nil
+		it.mux.Unlock()
+
+		if !it.anchorOnly {
+			n.rankScheduler.markItem(it.dAddrStr, storePriority)
+		}
+		return true
+	})
+}
+
+func (n *Node) getRanker() Ranker {
+	n.rankerMux.Lock()
+	defer n.rankerMux.Unlock()
+	return n.ranker
+}
+
+// PageRanker computes PageRank with the world node as in JXP.
+// if TrustedDomains is not empty, random jumps go only to the items on them (personalized PageRank)
+type PageRanker struct {
+	Damping        float64
+	Tolerance      float64
+	MaxIterations  int
+	TrustedDomains []string
+}
+
+var _ Ranker = &PageRanker{}
+
+func (r *PageRanker) isTrusted(host string) bool {
+	for _, d := range r.TrustedDomains {
+		if host == d || strings.HasSuffix(host, "."+d) {
+			return true
+		}
+	}
+	return false
+}
+
+// teleport returns the probabilities of random jumps to the items
+func (r *PageRanker) teleport(g *RankGraph) []float64 {
+	ret := make([]float64, g.size())
+	for i := range ret {
+		ret[i] = 1 / g.Total
+	}
+
+	if len(r.TrustedDomains) == 0 {
+		return ret
+	}
+
+	var trusted []int
+	for i, h := range g.Hosts {
+		if r.isTrusted(h) {
+			trusted = append(trusted, i)
+		}
+	}
+
+	if len(trusted) == 0 {
+		// no item on the trusted domains in the graph. the ranks flow only from the outside
+		return make([]float64, g.size())
+	}
+
+	// the jumps to the graph go only to the trusted items
+	for i := range ret {
+		ret[i] = 0
+	}
+	for _, i := range trusted {
+		ret[i] = float64(g.size()) / g.Total / float64(len(trusted))
+	}
+	return ret
+}
+
+func (r *PageRanker) Rank(g *RankGraph) []float64 {
+	v := r.teleport(g)
+
+	ranks := make([]float64, g.size())
+	for i := range ranks {
+		ranks[i] = g.Init[i]
+		if ranks[i] <= 0 {
+			ranks[i] = 1 / g.Total
+		}
+	}
+
+	for iter := 0; iter < r.MaxIterations; iter++ {
+		next := make([]float64, g.size())
+		for i := range next {
+			next[i] = (1-r.Damping)*v[i] + r.Damping*g.WorldIn[i]
+		}
+
+		for i, out := range g.Out {
+			for _, j := range out {
+				// links to the pages outside of the graph flow into the world node
+				next[j] += r.Damping * ranks[i] / float64(g.OutDegree[i])
+			}
+		}
+
+		diff := l1Distance(next, ranks)
+		ranks = next
+		if diff < r.Tolerance {
+			break
+		}
+	}
+	return ranks
+}
+
+// HITSRanker computes the authority scores of HITS, or hub scores if Hub is true.
+// the scores flowing from the outside of the graph are added to the authorities.
+// the scores sum up to the share of the graph in all the pages as PageRank does
+type HITSRanker struct {
+	Tolerance     float64
+	MaxIterations int
+	Hub           bool
+}
+
+var _ Ranker = &HITSRanker{}
+var _ wholeGraphRanker = &HITSRanker{}
+
+// the scores are normalized over the graph, so the ones computed over a part of it are not comparable
+func (r *HITSRanker) wholeGraph() bool { return true }
+
+func (r *HITSRanker) Rank(g *RankGraph) []float64 {
+	auths, hubs := make([]float64, g.size()), make([]float64, g.size())
+	for i := range hubs {
+		auths[i], hubs[i] = 1/float64(g.size()), 1/float64(g.size())
+	}
+
+	for iter := 0; iter < r.MaxIterations; iter++ {
+		nextAuths := make([]float64, g.size())
+		copy(nextAuths, g.WorldIn)
+		for i, out := range g.Out {
+			for _, j := range out {
+				nextAuths[j] += hubs[i]
+			}
+		}
+		normalizeL1(nextAuths)
+
+		nextHubs := make([]float64, g.size())
+		for i, out := range g.Out {
+			for _, j := range out {
+				nextHubs[i] += nextAuths[j]
+			}
+		}
+		normalizeL1(nextHubs)
+
+		diff := l1Distance(nextAuths, auths) + l1Distance(nextHubs, hubs)
+		auths, hubs = nextAuths, nextHubs
+		if diff < r.Tolerance {
+			break
+		}
+	}
+
+	ret := auths
+	if r.Hub {
+		ret = hubs
+	}
+
+	for i := range ret {
+		ret[i] *= float64(g.size()) / g.Total
+	}
+	return ret
+}
+
+func l1Distance(xs, ys []float64) float64 {
+	var ret float64
+	for i := range xs {
+		ret += math.Abs(xs[i] - ys[i])
+	}
+	return ret
+}
+
+// normalizeL1 scales the values to sum up to one unless all of them are zero
+func normalizeL1(xs []float64) {
+	var sum float64
+	for _, x := range xs {
+		sum += x
+	}
+
+	if sum == 0 {
+		return
+	}
+
+	for i := range xs {
+		xs[i] /= sum
+	}
+}
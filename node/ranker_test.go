@@ -0,0 +1,117 @@
+package node
+
+import (
+	"fmt"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestNewRanker(t *testing.T) {
+	for i, cc := range []struct {
+		modify func(cfg *RankerConfig)
+		exp    Ranker
+	}{
+		{
+			modify: func(cfg *RankerConfig) {},
+			exp:    &PageRanker{Damping: damp, Tolerance: tol, MaxIterations: maxRankIterations},
+		},
+		{
+			modify: func(cfg *RankerConfig) {
+				cfg.Algorithm = RankerPersonalizedPageRank
+				cfg.Damping = 0.5
+				cfg.TrustedDomains = []string{"a.com"}
+			},
+			exp: &PageRanker{Damping: 0.5, Tolerance: tol, MaxIterations: maxRankIterations, TrustedDomains: []string{"a.com"}},
+		},
+		{
+			modify: func(cfg *RankerConfig) { cfg.Algorithm = RankerHITSHub },
+			exp:    &HITSRanker{Tolerance: tol, MaxIterations: maxRankIterations, Hub: true},
+		},
+		{
+			modify: func(cfg *RankerConfig) { cfg.Algorithm = RankerHITSAuthority },
+			exp:    &HITSRanker{Tolerance: tol, MaxIterations: maxRankIterations},
+		},
+		{modify: func(cfg *RankerConfig) { cfg.Algorithm = RankerPersonalizedPageRank }},
+		{modify: func(cfg *RankerConfig) { cfg.Algorithm = "unknown" }},
+		{modify: func(cfg *RankerConfig) { cfg.Damping = 1 }},
+		{modify: func(cfg *RankerConfig) { cfg.MaxIterations = 0 }},
+	} {
+		c := cc
+		t.Run(fmt.Sprintf("%d-th case", i), func(t *testing.T) {
+			cfg := DefaultRankerConfig()
+			c.modify(&cfg)
+
+			actual, err := NewRanker(cfg)
+			if c.exp == nil {
+				assert.Assert(t, err != nil)
+				return
+			}
+			assert.Equal(t, nil, err)
+			assert.DeepEqual(t, c.exp, actual)
+		})
+	}
+}
+
+func TestRanker_Rank(t *testing.T) {
+	// 0 -> 1, 2 -> 1, 1 -> 3, 3 -> 0
+	g := &RankGraph{
+		Hosts:     []string{"a.com", "b.com", "www.c.com", "d.com"},
+		Out:       [][]int{{1}, {3}, {1}, {0}},
+		OutDegree: []int{1, 1, 1, 1},
+		WorldIn:   make([]float64, 4),
+		Total:     5,
+		Init:      make([]float64, 4),
+	}
+
+	argmax := func(xs []float64) int {
+		var ret int
+		for i, x := range xs {
+			if x > xs[ret] {
+				ret = i
+			}
+		}
+		return ret
+	}
+
+	pr := (&PageRanker{Damping: damp, Tolerance: 1e-6, MaxIterations: maxRankIterations}).Rank(g)
+	assert.Equal(t, 1, argmax(pr))
+	assert.Assert(t, pr[0] > pr[2])
+
+	// random jumps go only to c.com
+	ppr := (&PageRanker{
+		Damping: damp, Tolerance: 1e-6, MaxIterations: maxRankIterations, TrustedDomains: []string{"c.com"},
+	}).Rank(g)
+	assert.Assert(t, ppr[2] > pr[2])
+	assert.Assert(t, ppr[0] < pr[0])
+
+	// 0 and 2 are the hubs linking to the authority 1
+	auths := (&HITSRanker{Tolerance: 1e-6, MaxIterations: maxRankIterations}).Rank(g)
+	hubs := (&HITSRanker{Tolerance: 1e-6, MaxIterations: maxRankIterations, Hub: true}).Rank(g)
+	assert.Equal(t, 1, argmax(auths))
+	assert.Assert(t, hubs[0] > hubs[1])
+	assert.Assert(t, hubs[2] > hubs[3])
+}
+
+func TestNode_SetRanker(t *testing.T) {
+	node, err := NewNode(1, "doogle", logger, nil, 10)
+	if err != nil {
+		t.Fatalf("NewNode failed: %v", err)
+	}
+
+	it := &item{dAddrStr: "1", localRank: 0.5, rankComputedCount: 2, rankComputedAt: 10, rankPrior: 0.1,
+		rankHistory: []rankRecord{{computedAt: 10, rank: 0.5, localRank: 0.5}}}
+	node.items.Store(it.dAddrStr, it)
+	node.items.Store(doogleAddressStr("2"), &item{dAddrStr: "2", anchorOnly: true})
+
+	// the same algorithm keeps the ranks
+	node.SetRanker(&PageRanker{Damping: damp, Tolerance: tol, MaxIterations: maxRankIterations})
+	assert.Equal(t, 0.5, it.localRank)
+	assert.Equal(t, int32(0), node.rankScheduler.stats().NumDirtyItems)
+
+	node.SetRanker(&HITSRanker{Tolerance: tol, MaxIterations: maxRankIterations})
+	assert.Equal(t, 0.0, it.localRank)
+	assert.Equal(t, 0.0, it.rankComputedCount)
+	assert.Equal(t, 0, len(it.rankHistory))
+	assert.Equal(t, int32(1), node.rankScheduler.stats().NumDirtyItems)
+}
@@ -0,0 +1,301 @@
+package node
+
+import (
+	"context"
+	"crypto/sha1"
+	"sort"
+	"sync"
+
+	"github.com/mathetake/doogle/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	maxNumRelatedPages = 10
+
+	// max numbers of the backlinks and the outgoing links of the page whose closest nodes are asked for co-links
+	maxRelatedNeighbours = 10
+)
+
+func (n *Node) FindCoLinks(ctx context.Context, in *doogle.FindCoLinksRequest) (*doogle.CoLinks, error) {
+	if !n.isValidSender(in.Certificate) {
+		return nil, status.Error(codes.InvalidArgument, "invalid certificate")
+	}
+
+	es := make([]doogleAddressStr, len(in.Edges))
+	for i, e := range in.Edges {
+		es[i] = doogleAddressStr(e)
+	}
+	return &doogle.CoLinks{CoLinks: n.findLocalCoLinks(doogleAddressStr(in.DoogleAddress), es)}, nil
+}
+
+// findLocalCoLinks scans the outgoing links of the items and documents on this node for the pages
+// co-cited with the target (linked from the same page) or bibliographically coupled to it (linking to the same page).
+// the backlinks of the target's edges stored on this node are also pages coupled to it
+func (n *Node) findLocalCoLinks(target doogleAddressStr, targetEdges []doogleAddressStr) []*doogle.CoLink {
+	isTargetEdge := make(map[doogleAddressStr]struct{}, len(targetEdges))
+	for _, e := range targetEdges {
+		isTargetEdge[e] = struct{}{}
+	}
+
+	cls := map[doogleAddressStr]*doogle.CoLink{}
+	get := func(addr doogleAddressStr) *doogle.CoLink {
+		cl, ok := cls[addr]
+		if !ok {
+			cl = &doogle.CoLink{DoogleAddress: []byte(addr)}
+			cls[addr] = cl
+		}
+		return cl
+	}
+
+	// urls and titles of the pages known from documents and backlinks, used unless they are items on this node
+	type page struct{ url, title string }
+	pages := map[doogleAddressStr]page{}
+
+	scan := func(addr doogleAddressStr, edges []doogleAddressStr) {
+		var citing bool
+		for _, e := range edges {
+			if e == target {
+				citing = true
+				break
+			}
+		}
+
+		for _, e := range edges {
+			if citing && e != target {
+				cl := get(e)
+				cl.CitedBy = append(cl.CitedBy, []byte(addr))
+			}
+
+			if _, ok := isTargetEdge[e]; ok {
+				cl := get(addr)
+				cl.CoupledBy = append(cl.CoupledBy, []byte(e))
+			}
+		}
+	}
+
+	n.items.Range(func(_, raw interface{}) bool {
+		it := raw.(*item)
+		if !it.anchorOnly && it.dAddrStr != target {
+			scan(it.dAddrStr, it.edges)
+		}
+		return true
+	})
+
+	n.documents.Range(func(key, raw interface{}) bool {
+		addr := key.(doogleAddressStr)
+		doc := n.findLocalDocument(addr)
+		if doc == nil || doc.Deleted || addr == target {
+			return true
+		}
+
+		es := make([]doogleAddressStr, len(doc.EdgeURLs))
+		for i, e := range doc.EdgeURLs {
+			h := sha1.Sum([]byte(e))
+			es[i] = doogleAddressStr(h[:])
+			pages[es[i]] = page{url: e}
+		}
+		pages[addr] = page{url: doc.Url}
+		scan(addr, es)
+		return true
+	})
+
+	for _, e := range targetEdges {
+		for _, bl := range n.findLocalBacklinks(e) {
+			h := sha1.Sum([]byte(bl.Url))
+			addr := doogleAddressStr(h[:])
+			if bl.Deleted || addr == target {
+				continue
+			}
+
+			pages[addr] = page{url: bl.Url, title: bl.Title}
+			cl := get(addr)
+			cl.CoupledBy = append(cl.CoupledBy, []byte(e))
+		}
+	}
+
+	ret := make([]*doogle.CoLink, 0, len(cls))
+	for addr, cl := range cls {
+		if p, ok := pages[addr]; ok {
+			cl.Url, cl.Title = p.url, p.title
+		}
+		if raw, ok := n.items.Load(addr); ok && !raw.(*item).anchorOnly {
+			it := raw.(*item)
+			cl.Url, cl.Title = it.url, it.title
+		}
+		ret = append(ret, cl)
+	}
+	return ret
+}
+
+// outgoing links of the page on the url known to the network
+func (n *Node) findEdges(ctx context.Context, url string) []doogleAddressStr {
+	if doc := n.findDocument(ctx, url); doc != nil && !doc.Deleted {
+		es := make([]doogleAddressStr, len(doc.EdgeURLs))
+		for i, e := range doc.EdgeURLs {
+			h := sha1.Sum([]byte(e))
+			es[i] = doogleAddressStr(h[:])
+		}
+		return es
+	}
+
+	h := sha1.Sum([]byte(url))
+	if raw, ok := n.items.Load(doogleAddressStr(h[:])); ok {
+		return raw.(*item).edges
+	}
+	return nil
+}
+
+// relatedHolders returns the nodes closest to the target, to the pages linking to it and to the ones linked from it,
+// which hold their items, documents and backlinks. the numbers of the pages are limited by `maxRelatedNeighbours`
+func (n *Node) relatedHolders(target doogleAddressStr, backlinks []string, edges []doogleAddressStr) []*doogle.NodeInfo {
+	addrs := []doogleAddressStr{target}
+	for i, bl := range backlinks {
+		if i >= maxRelatedNeighbours {
+			break
+		}
+		h := sha1.Sum([]byte(bl))
+		addrs = append(addrs, doogleAddressStr(h[:]))
+	}
+
+	if len(edges) > maxRelatedNeighbours {
+		edges = edges[:maxRelatedNeighbours]
+	}
+	addrs = append(addrs, edges...)
+
+	included := map[string]struct{}{}
+	var ret []*doogle.NodeInfo
+	for _, addr := range addrs {
+		var dAddr doogleAddress
+		copy(dAddr[:], addr)
+
+		nis, err := n.findNode(dAddr)
+		if err != nil {
+			n.logger.Errorf("failed to find node for %x : %v", addr, err)
+			continue
+		}
+
+		for _, ni := range nis {
+			if _, ok := included[ni.NetworkAddress]; !ok {
+				included[ni.NetworkAddress] = struct{}{}
+				ret = append(ret, ni)
+			}
+		}
+	}
+	return ret
+}
+
+// relatedPages merges the co-links found on its own and the nodes holding the pages around the target into the ranked pages.
+// the score is the sum of the numbers of distinct pages co-citing and coupling
+func (n *Node) relatedPages(ctx context.Context, url string) []*doogle.RelatedPage {
+	h := sha1.Sum([]byte(url))
+	target := doogleAddressStr(h[:])
+	edges := n.findEdges(ctx, url)
+
+	type related struct {
+		url, title         string
+		citedBy, coupledBy map[doogleAddressStr]struct{}
+	}
+
+	rs := map[doogleAddressStr]*related{}
+	var mux sync.Mutex
+	merge := func(cls []*doogle.CoLink) {
+		mux.Lock()
+		defer mux.Unlock()
+		for _, cl := range cls {
+			addr := doogleAddressStr(cl.DoogleAddress)
+			if addr == target {
+				continue
+			}
+
+			r, ok := rs[addr]
+			if !ok {
+				r = &related{citedBy: map[doogleAddressStr]struct{}{}, coupledBy: map[doogleAddressStr]struct{}{}}
+				rs[addr] = r
+			}
+
+			if r.url == "" {
+				r.url, r.title = cl.Url, cl.Title
+			}
+			for _, a := range cl.CitedBy {
+				r.citedBy[doogleAddressStr(a)] = struct{}{}
+			}
+			for _, a := range cl.CoupledBy {
+				r.coupledBy[doogleAddressStr(a)] = struct{}{}
+			}
+		}
+	}
+
+	merge(n.findLocalCoLinks(target, edges))
+
+	req := &doogle.FindCoLinksRequest{Certificate: n.certificate, DoogleAddress: h[:]}
+	for _, e := range edges {
+		req.Edges = append(req.Edges, []byte(e))
+	}
+
+	var backlinks []string
+	for _, bl := range n.findBacklinks(ctx, url) {
+		backlinks = append(backlinks, bl.Url)
+	}
+
+	var wg sync.WaitGroup
+	for _, ni := range n.relatedHolders(target, backlinks, edges) {
+		wg.Add(1)
+		go func(ni *doogle.NodeInfo) {
+			defer wg.Done()
+
+			conn, err := n.getConnByNetworkAddress(ctx, ni.NetworkAddress)
+			if err != nil {
+				return
+			}
+
+			c := doogle.NewDoogleClient(conn)
+			res, err := c.FindCoLinks(ctx, req)
+			if err != nil {
+				n.logger.Errorf("failed to call FindCoLinks: %v", err)
+				return
+			}
+			merge(res.CoLinks)
+		}(ni)
+	}
+	wg.Wait()
+
+	ret := make([]*doogle.RelatedPage, 0, len(rs))
+	for _, r := range rs {
+		if r.url == "" {
+			// not indexed by any node
+			continue
+		}
+
+		ret = append(ret, &doogle.RelatedPage{
+			Url:        r.url,
+			Title:      r.title,
+			CoCitation: int32(len(r.citedBy)),
+			Coupling:   int32(len(r.coupledBy)),
+			Score:      float64(len(r.citedBy) + len(r.coupledBy)),
+		})
+	}
+
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].Score != ret[j].Score {
+			return ret[i].Score > ret[j].Score
+		}
+		return ret[i].Url < ret[j].Url
+	})
+
+	if len(ret) > maxNumRelatedPages {
+		ret = ret[:maxNumRelatedPages]
+	}
+	return ret
+}
+
+func (n *Node) Related(ctx context.Context, in *doogle.StringMessage) (*doogle.RelatedPages, error) {
+	if in.Message == "" {
+		return nil, status.Error(codes.InvalidArgument, "empty url")
+	}
+
+	ctx, cancel := withQueryTimeout(ctx, 0)
+	defer cancel()
+	return &doogle.RelatedPages{Pages: n.relatedPages(ctx, normalizeURL(in.Message))}, nil
+}
@@ -0,0 +1,128 @@
+package node
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha1"
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/mathetake/doogle/grpc"
+	"gotest.tools/assert"
+)
+
+func TestNode_Related(t *testing.T) {
+	resetRoutingTable()
+	defer resetRoutingTable()
+	resetDHT()
+	defer resetDHT()
+
+	srv := testServers[0].node
+	defer func() { srv.crawler = &mockCrawler{} }()
+	for _, to := range testServers[1:4] {
+		srv.updateRoutingTable(&nodeInfo{dAddr: to.node.DAddr, nAddr: localhost + to.port})
+	}
+
+	for i, c := range []struct {
+		url   string
+		edges []string
+	}{
+		{url: "t.com", edges: []string{"z.com"}},
+		{url: "a.com", edges: []string{"t.com", "x.com", "y.com"}},
+		{url: "b.com", edges: []string{"t.com", "x.com"}},
+		{url: "x.com", edges: []string{"z.com"}},
+		{url: "y.com"},
+		{url: "c.com", edges: []string{"z.com", "w.com"}},
+	} {
+		// items, documents and backlinks are placed on the closest nodes
+		srv.crawler = &mockCrawler{edgeURLs: c.edges}
+		_, err := srv.PostUrl(context.Background(), &doogle.StringMessage{Message: c.url})
+		assert.Equal(t, nil, err, fmt.Sprintf("%d-th case", i))
+	}
+
+	res, err := srv.Related(context.Background(), &doogle.StringMessage{Message: "t.com"})
+	assert.Equal(t, nil, err)
+	assert.DeepEqual(t, []*doogle.RelatedPage{
+		{Url: "http://x.com/", CoCitation: 2, Coupling: 1, Score: 3},
+		{Url: "http://c.com/", Coupling: 1, Score: 1},
+		{Url: "http://y.com/", CoCitation: 1, Score: 1},
+	}, res.Pages)
+
+	_, err = srv.Related(context.Background(), &doogle.StringMessage{})
+	assert.Assert(t, err != nil)
+}
+
+func TestNode_relatedHolders(t *testing.T) {
+	resetRoutingTable()
+	defer resetRoutingTable()
+
+	srv := testServers[0].node
+	for i := 0; i < 100; i++ {
+		var dAddr doogleAddress
+		_, err := rand.Read(dAddr[:])
+		assert.Equal(t, nil, err)
+		srv.updateRoutingTable(&nodeInfo{dAddr: dAddr, nAddr: fmt.Sprintf("%s%d", localhost, i)})
+	}
+
+	var backlinks []string
+	var edges []doogleAddressStr
+	for i := 0; i < 2*maxRelatedNeighbours; i++ {
+		backlinks = append(backlinks, fmt.Sprintf("http://b%d.com/", i))
+		h := sha1.Sum([]byte(fmt.Sprintf("http://e%d.com/", i)))
+		edges = append(edges, doogleAddressStr(h[:]))
+	}
+	h := sha1.Sum([]byte("http://t.com/"))
+	target := doogleAddressStr(h[:])
+
+	for i, c := range []struct {
+		backlinks []string
+		edges     []doogleAddressStr
+		max       int
+	}{
+		{max: alpha},
+		{backlinks: backlinks[:1], max: 2 * alpha},
+		{backlinks: backlinks, edges: edges, max: (1 + 2*maxRelatedNeighbours) * alpha},
+	} {
+		actual := srv.relatedHolders(target, c.backlinks, c.edges)
+		assert.Assert(t, len(actual) > 0, fmt.Sprintf("%d-th case", i))
+		assert.Assert(t, len(actual) <= c.max, fmt.Sprintf("%d-th case", i))
+
+		included := map[string]struct{}{}
+		for _, ni := range actual {
+			_, ok := included[ni.NetworkAddress]
+			assert.Assert(t, !ok, fmt.Sprintf("%d-th case", i))
+			included[ni.NetworkAddress] = struct{}{}
+		}
+	}
+}
+
+func TestNode_findLocalCoLinks_documents(t *testing.T) {
+	resetDHT()
+	defer resetDHT()
+
+	addr := func(url string) doogleAddressStr {
+		h := sha1.Sum([]byte(url))
+		return doogleAddressStr(h[:])
+	}
+
+	srv := testServers[0].node
+	srv.storeDocument(&doogle.Document{Url: "http://a.com/", EdgeURLs: []string{"http://t.com/", "http://x.com/"}})
+	srv.storeDocument(&doogle.Document{Url: "http://d.com/", EdgeURLs: []string{"http://x.com/"}, Deleted: true})
+	srv.storeBacklink("http://z.com/", &doogle.Backlink{Url: "http://c.com/", Title: "c"})
+	srv.storeBacklink("http://z.com/", &doogle.Backlink{Url: "http://t.com/"})
+	srv.storeBacklink("http://z.com/", &doogle.Backlink{Url: "http://e.com/", Deleted: true})
+
+	actual := srv.findLocalCoLinks(addr("http://t.com/"), []doogleAddressStr{addr("http://z.com/")})
+	sort.Slice(actual, func(i, j int) bool { return actual[i].Url < actual[j].Url })
+	assert.DeepEqual(t, []*doogle.CoLink{
+		{
+			DoogleAddress: []byte(addr("http://c.com/")), Url: "http://c.com/", Title: "c",
+			CoupledBy: [][]byte{[]byte(addr("http://z.com/"))},
+		},
+		{
+			DoogleAddress: []byte(addr("http://x.com/")), Url: "http://x.com/",
+			CitedBy: [][]byte{[]byte(addr("http://a.com/"))},
+		},
+	}, actual)
+}
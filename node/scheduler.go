@@ -0,0 +1,169 @@
+package node
+
+import (
+	"context"
+	"crypto/sha1"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/mathetake/doogle/grpc"
+)
+
+const (
+	// priority of the items and terms changed by StoreItem
+	storePriority = 1.0
+
+	// priority added to a dirty term each time it is searched
+	queryPriority = 1.0
+
+	// max number of items recomputed in a single run
+	maxRankItemsPerRun = 1000
+)
+
+type rankTask struct {
+	addr      doogleAddressStr
+	isTerm    bool
+	priority  float64
+	dirtiedAt time.Time
+}
+
+// rankScheduler tracks the items and terms whose ranks are to be recomputed
+type rankScheduler struct {
+	// type: map{doogleAddressStr -> *rankTask}
+	items map[doogleAddressStr]*rankTask
+	terms map[doogleAddressStr]*rankTask
+
+	// unix time in nanoseconds of the last computation of each term
+	termComputedAt map[doogleAddressStr]int64
+
+	// the last run
+	lastRunAt      int64
+	lastRunNumItem int
+
+	mux sync.Mutex
+}
+
+func newRankScheduler() *rankScheduler {
+	return &rankScheduler{
+		items:          map[doogleAddressStr]*rankTask{},
+		terms:          map[doogleAddressStr]*rankTask{},
+		termComputedAt: map[doogleAddressStr]int64{},
+	}
+}
+
+func (s *rankScheduler) mark(tasks map[doogleAddressStr]*rankTask, addr doogleAddressStr, isTerm bool, priority float64) {
+	if t, ok := tasks[addr]; ok {
+		if priority > t.priority {
+			t.priority = priority
+		}
+		return
+	}
+	tasks[addr] = &rankTask{addr: addr, isTerm: isTerm, priority: priority, dirtiedAt: time.Now()}
+}
+
+func (s *rankScheduler) markItem(addr doogleAddressStr, priority float64) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.mark(s.items, addr, false, priority)
+}
+
+func (s *rankScheduler) markTerm(addr doogleAddressStr, priority float64) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.mark(s.terms, addr, true, priority)
+}
+
+// boostTerm raises the priority of the term if it is dirty so that searched terms are recomputed first
+func (s *rankScheduler) boostTerm(addr doogleAddressStr) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	if t, ok := s.terms[addr]; ok {
+		t.priority += queryPriority
+	}
+}
+
+// next pops the dirty tasks in the order of priority, and then of the time when they got dirty.
+// `numItems` returns the number of items of a term, and the tasks are popped until the items exceed `budget`
+func (s *rankScheduler) next(budget int, numItems func(doogleAddressStr) int) []*rankTask {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	ts := make([]*rankTask, 0, len(s.items)+len(s.terms))
+	for _, t := range s.items {
+		ts = append(ts, t)
+	}
+	for _, t := range s.terms {
+		ts = append(ts, t)
+	}
+
+	sort.Slice(ts, func(i, j int) bool {
+		if ts[i].priority != ts[j].priority {
+			return ts[i].priority > ts[j].priority
+		}
+		return ts[i].dirtiedAt.Before(ts[j].dirtiedAt)
+	})
+
+	var ret []*rankTask
+	var num int
+	for _, t := range ts {
+		var l = 1
+		if t.isTerm {
+			l = numItems(t.addr)
+		}
+
+		// at least one task is popped even if it exceeds the budget
+		if len(ret) > 0 && num+l > budget {
+			break
+		}
+
+		num += l
+		ret = append(ret, t)
+		if t.isTerm {
+			delete(s.terms, t.addr)
+		} else {
+			delete(s.items, t.addr)
+		}
+	}
+	return ret
+}
+
+// done records the run computing the given tasks
+func (s *rankScheduler) done(tasks []*rankTask, numItems int, at time.Time) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	for _, t := range tasks {
+		if t.isTerm {
+			s.termComputedAt[t.addr] = at.UnixNano()
+		}
+	}
+	s.lastRunAt = at.UnixNano()
+	s.lastRunNumItem = numItems
+}
+
+func (s *rankScheduler) stats() *doogle.RankStats {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	return &doogle.RankStats{
+		NumDirtyItems:   int32(len(s.items)),
+		NumDirtyTerms:   int32(len(s.terms)),
+		LastRunAt:       s.lastRunAt,
+		LastRunNumItems: int32(s.lastRunNumItem),
+	}
+}
+
+func (s *rankScheduler) computedAt(term doogleAddressStr) int64 {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	return s.termComputedAt[term]
+}
+
+func (n *Node) GetRankStats(ctx context.Context, in *doogle.StringMessage) (*doogle.RankStats, error) {
+	ret := n.rankScheduler.stats()
+	if in.Message != "" {
+		addr := sha1.Sum([]byte(in.Message))
+		ret.TermComputedAt = n.rankScheduler.computedAt(doogleAddressStr(addr[:]))
+	}
+	return ret, nil
+}
@@ -0,0 +1,82 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/mathetake/doogle/grpc"
+	"gotest.tools/assert"
+)
+
+func TestRankScheduler_next(t *testing.T) {
+	s := newRankScheduler()
+	numItems := func(addr doogleAddressStr) int { return map[doogleAddressStr]int{"t1": 3, "t2": 5}[addr] }
+
+	s.markItem("i1", 1)
+	s.markTerm("t1", 1)
+	s.markItem("i2", 2)
+	s.markTerm("t2", 1)
+
+	// higher priority is kept
+	s.markItem("i2", 0.5)
+
+	// only dirty terms are boosted
+	s.boostTerm("t2")
+	s.boostTerm("t2")
+	s.boostTerm("t3")
+
+	for i, cc := range []struct {
+		budget int
+		exp    []doogleAddressStr
+	}{
+		// a task exceeding the budget is popped if it is the first
+		{budget: 1, exp: []doogleAddressStr{"t2"}},
+		{budget: 2, exp: []doogleAddressStr{"i2", "i1"}},
+		{budget: 2, exp: []doogleAddressStr{"t1"}},
+		{budget: 2, exp: nil},
+	} {
+		c := cc
+		t.Run(fmt.Sprintf("%d-th case", i), func(t *testing.T) {
+			var actual []doogleAddressStr
+			for _, task := range s.next(c.budget, numItems) {
+				actual = append(actual, task.addr)
+			}
+			assert.DeepEqual(t, c.exp, actual)
+		})
+	}
+}
+
+func TestNode_GetRankStats(t *testing.T) {
+	resetRoutingTable()
+	defer resetRoutingTable()
+	resetDHT()
+	defer resetDHT()
+
+	srv := testServers[0].node
+	for _, url := range []string{"url1", "url2"} {
+		_, err := srv.StoreItem(context.Background(), &doogle.StoreItemRequest{
+			Certificate: srv.certificate, Url: url, Index: "doogle",
+		})
+		assert.Equal(t, nil, err)
+	}
+
+	stats, err := srv.GetRankStats(context.Background(), &doogle.StringMessage{Message: "doogle"})
+	assert.Equal(t, nil, err)
+	assert.DeepEqual(t, &doogle.RankStats{NumDirtyItems: 2, NumDirtyTerms: 1}, stats)
+
+	assert.Equal(t, 2, srv.runRankScheduler())
+
+	stats, err = srv.GetRankStats(context.Background(), &doogle.StringMessage{Message: "doogle"})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, int32(0), stats.NumDirtyItems)
+	assert.Equal(t, int32(0), stats.NumDirtyTerms)
+	assert.Equal(t, int32(2), stats.LastRunNumItems)
+	assert.Equal(t, stats.LastRunAt, stats.TermComputedAt)
+
+	res, err := srv.GetIndex(context.Background(), &doogle.StringMessage{Message: "doogle"})
+	assert.Equal(t, nil, err)
+	for _, it := range res.Items {
+		assert.Equal(t, stats.LastRunAt, it.RankComputedAt)
+	}
+}
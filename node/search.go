@@ -0,0 +1,444 @@
+package node
+
+import (
+	"context"
+	"crypto/sha1"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/mathetake/doogle/grpc"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultQueryTimeout = 5 * time.Second
+	maxQueryTimeout     = 30 * time.Second
+
+	// the part of the query's remaining time reserved for suggesting spellings after searching, up to `maxSuggestionReserve`
+	suggestionReserveRatio = 5
+	maxSuggestionReserve   = 1 * time.Second
+
+	// max number of rounds following referrals toward the index
+	maxLookupRounds = 8
+)
+
+// errInvalidCertificate is recorded for the referred node whose certificate is not valid
+var errInvalidCertificate = errors.New("invalid certificate")
+
+// searchResult accumulates the items reported by nodes for a query
+type searchResult struct {
+	items  []*doogle.Item
+	scores map[string]*itemScore
+	stats  *doogle.SearchStats
+	mux    sync.Mutex
+}
+
+func newSearchResult() *searchResult {
+	return &searchResult{
+		items:  make([]*doogle.Item, 0, maxNumGetItem),
+		scores: map[string]*itemScore{},
+		stats:  &doogle.SearchStats{},
+	}
+}
+
+// record the outcome of asking a remote node under the query's context
+func (sr *searchResult) record(ctx context.Context, err error) {
+	sr.mux.Lock()
+	defer sr.mux.Unlock()
+
+	switch {
+	case err == nil:
+		sr.stats.NumAnswered++
+	case ctx.Err() == context.DeadlineExceeded || status.Code(err) == codes.DeadlineExceeded:
+		sr.stats.NumTimedOut++
+	default:
+		sr.stats.NumFailed++
+	}
+}
+
+// add the items reported by a single node on `node`, and returns the ones seen for the first time
+// ordered by the scores so far
+func (sr *searchResult) add(node string, its []*doogle.Item) []*doogle.Item {
+	its = uniqueItems(its)
+	scores := normalizeScores(its)
+
+	sr.mux.Lock()
+	defer sr.mux.Unlock()
+
+	var ret []*doogle.Item
+	for i, it := range its {
+		if _, ok := sr.scores[it.Url]; !ok {
+			sr.scores[it.Url] = &itemScore{}
+			sr.items = append(sr.items, it)
+			ret = append(ret, it)
+		}
+		sr.scores[it.Url].add(node, it, scores[i])
+	}
+	sr.sort(ret)
+	return ret
+}
+
+// ranked returns all the items sorted by score, and the number of near-duplicates removed
+func (sr *searchResult) ranked() ([]*doogle.Item, int) {
+	sr.mux.Lock()
+	defer sr.mux.Unlock()
+
+	sr.sort(sr.items)
+
+	// keep the highest-ranked one among near-duplicates
+	return collapseDuplicates(sr.items)
+}
+
+// sort by aggregated score weighted by field boosts. the caller must hold the lock
+func (sr *searchResult) sort(its []*doogle.Item) {
+	sort.SliceStable(its, func(i, j int) bool {
+		si, sj := sr.scores[its[i].Url], sr.scores[its[j].Url]
+		if si.agg*si.boost != sj.agg*sj.boost {
+			return si.agg*si.boost > sj.agg*sj.boost
+		}
+		return si.boost > sj.boost
+	})
+}
+
+// withQueryTimeout returns the context of a query with the given timeout.
+// zero timeout means the default one, and the deadline of the caller is kept if it is earlier.
+func withQueryTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		timeout = defaultQueryTimeout
+	} else if timeout > maxQueryTimeout {
+		timeout = maxQueryTimeout
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// withSuggestionReserve returns the context of searching, which ends earlier than the query
+// by the time reserved for suggesting spellings
+func withSuggestionReserve(ctx context.Context) (context.Context, context.CancelFunc) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return context.WithCancel(ctx)
+	}
+
+	reserve := time.Until(deadline) / suggestionReserveRatio
+	if reserve > maxSuggestionReserve {
+		reserve = maxSuggestionReserve
+	}
+	return context.WithDeadline(ctx, deadline.Add(-reserve))
+}
+
+// search collects the items of `term` from the local DHT and the closest nodes into `sr`,
+// calling `onBatch` with newly found items each time a node answers.
+// onBatch may be called concurrently. Nodes not answering before the deadline of `ctx`
+// are recorded in the stats of `sr` and the results collected so far are kept.
+func (n *Node) search(ctx context.Context, term string, filter *doogle.Filter, sr *searchResult, onBatch func([]*doogle.Item)) error {
+	if term == "" && filter.Link != "" {
+		// pages linking to the url are found in the reverse-edge index
+		onBatch(sr.add(n.certificate.NetworkAddress, n.backlinkItems(ctx, filter)))
+		return nil
+	}
+
+	targetAddr := sha1.Sum([]byte(term))
+	var targetAddrStr = doogleAddressStr(targetAddr[:])
+
+	// searched terms are recomputed first
+	n.rankScheduler.boostTerm(targetAddrStr)
+
+	res, err := n.findIndex(ctx, targetAddrStr, filter)
+	if err != nil {
+		return status.Errorf(codes.Internal, "findIndex failed: %v", err)
+	}
+
+	var shortlist []*doogle.NodeInfo
+	maxRounds := maxLookupRounds
+	if its, ok := res.Result.(*doogle.FindIndexReply_Items); ok {
+		onBatch(sr.add(n.certificate.NetworkAddress, its.Items.Items))
+
+		// get nearest nodes holding the replicas
+		shortlist, err = n.findNode(targetAddr)
+		if err != nil {
+			return status.Errorf(codes.Internal, "findNode failed: %v", err)
+		}
+
+		// the value is already found, so no need to follow referrals
+		maxRounds = 1
+	} else {
+		shortlist = res.Result.(*doogle.FindIndexReply_NodeInfos).NodeInfos.Infos
+	}
+
+	n.lookupIndex(ctx, targetAddr, filter, shortlist, maxRounds, sr, onBatch)
+	return nil
+}
+
+// lookupIndex asks the nodes in `shortlist` for the items of `targetAddr` and follows the referrals
+// toward the address until items are found or the shortlist converges, as in Kademlia's FIND_VALUE.
+func (n *Node) lookupIndex(ctx context.Context, targetAddr doogleAddress, filter *doogle.Filter,
+	shortlist []*doogle.NodeInfo, maxRounds int, sr *searchResult, onBatch func([]*doogle.Item)) {
+
+	queried := map[doogleAddress]struct{}{n.DAddr: {}}
+	var closest *doogleAddress // the closest distance among queried nodes
+
+	// nodes answered with referrals and with items, and the items replied by the latter
+	var missed, holders []*doogle.NodeInfo
+	replies := map[string][]*doogle.Item{}
+	for round := 0; round < maxRounds && ctx.Err() == nil; round++ {
+		cands := closestUnqueried(targetAddr, shortlist, queried)
+		if len(cands) == 0 {
+			return
+		}
+
+		first := distanceTo(targetAddr, cands[0])
+		if closest != nil && closest.lessThanEqual(first) {
+			// no closer node is found in the last round
+			return
+		}
+		closest = &first
+
+		var found bool
+		var referrals []*doogle.NodeInfo
+		var mux sync.Mutex
+		var wg sync.WaitGroup
+		for _, ni := range cands {
+			queried[toDoogleAddress(ni.DoogleAddress)] = struct{}{}
+
+			wg.Add(1)
+			go func(ni *doogle.NodeInfo, referred bool) {
+				defer wg.Done()
+
+				conn, err := n.getConnByNetworkAddress(ctx, ni.NetworkAddress)
+				if err != nil {
+					sr.record(ctx, err)
+					return
+				}
+
+				c := doogle.NewDoogleClient(conn)
+
+				if referred {
+					// verify the referred node and update routing table with it
+					ct, err := c.PingWithCertificate(ctx, n.certificate)
+					if err == nil && !n.isValidSender(ct) {
+						err = errInvalidCertificate
+					}
+					if err != nil {
+						n.logger.Errorf("failed to PingWithCertificate: %v", err)
+						sr.record(ctx, err)
+						return
+					}
+				}
+
+				res, err := c.FindIndex(ctx, &doogle.FindIndexRequest{
+					Certificate:   n.certificate,
+					DoogleAddress: targetAddr[:],
+					Filter:        filter,
+				})
+
+				sr.record(ctx, err)
+				if err != nil {
+					n.logger.Errorf("failed to call FindIndex: %v", err)
+					return
+				}
+
+				if its, ok := res.Result.(*doogle.FindIndexReply_Items); ok {
+					onBatch(sr.add(ni.NetworkAddress, its.Items.Items))
+					mux.Lock()
+					found = true
+					holders = append(holders, ni)
+					replies[ni.NetworkAddress] = its.Items.Items
+					mux.Unlock()
+					return
+				}
+
+				if nis, ok := res.Result.(*doogle.FindIndexReply_NodeInfos); ok {
+					mux.Lock()
+					referrals = append(referrals, nis.NodeInfos.Infos...)
+					missed = append(missed, ni)
+					mux.Unlock()
+				}
+			}(ni, round > 0)
+		}
+		wg.Wait()
+
+		if found {
+			// only the nodes closer to the key than this node accept the copy
+			var path []*doogle.NodeInfo
+			for _, ni := range missed {
+				if !n.DAddr.xor(targetAddr).lessThanEqual(distanceTo(targetAddr, ni)) {
+					path = append(path, ni)
+				}
+			}
+
+			// cache the posting list of the closest holder on the closest node on the path which did not have it
+			if ni, holder, ttl := cacheTarget(targetAddr, path, holders); ni != nil && isEmptyFilter(filter) {
+				items := replies[holder.NetworkAddress]
+				n.enqueuePublish(func() { n.publishIndexCache(ni, targetAddr, items, ttl) })
+			}
+			return
+		}
+		shortlist = append(shortlist, referrals...)
+	}
+}
+
+// closestUnqueried returns at most alpha nodes in `nis` not queried yet, sorted by the distance to `targetAddr`
+func closestUnqueried(targetAddr doogleAddress, nis []*doogle.NodeInfo, queried map[doogleAddress]struct{}) []*doogle.NodeInfo {
+	seen := map[doogleAddress]struct{}{}
+	var ret []*doogle.NodeInfo
+	for _, ni := range nis {
+		if len(ni.DoogleAddress) != addressLength {
+			continue
+		}
+
+		da := toDoogleAddress(ni.DoogleAddress)
+		if _, ok := queried[da]; ok {
+			continue
+		}
+		if _, ok := seen[da]; ok {
+			continue
+		}
+		seen[da] = struct{}{}
+		ret = append(ret, ni)
+	}
+
+	sort.Slice(ret, func(i, j int) bool {
+		return distanceTo(targetAddr, ret[i]).lessThanEqual(distanceTo(targetAddr, ret[j]))
+	})
+
+	if len(ret) > alpha {
+		ret = ret[:alpha]
+	}
+	return ret
+}
+
+func toDoogleAddress(b []byte) doogleAddress {
+	var da doogleAddress
+	copy(da[:], b)
+	return da
+}
+
+func distanceTo(targetAddr doogleAddress, ni *doogle.NodeInfo) doogleAddress {
+	return toDoogleAddress(ni.DoogleAddress).xor(targetAddr)
+}
+
+// backlinkItems returns the pages linking to the url of the filter, satisfying its site and inurl
+func (n *Node) backlinkItems(ctx context.Context, filter *doogle.Filter) []*doogle.Item {
+	f := &doogle.Filter{Site: filter.Site, Inurl: filter.Inurl}
+
+	var ret []*doogle.Item
+	for _, bl := range n.findBacklinks(ctx, filter.Link) {
+		host := hostOf(bl.Url)
+		if matchFilter(f, &item{url: bl.Url, host: host}, nil) {
+			ret = append(ret, &doogle.Item{Url: bl.Url, Title: bl.Title, Host: host})
+		}
+	}
+	return ret
+}
+
+// summarize makes the final reply of the query from the ranked items
+// spellings are suggested within the query's context, whose time is reserved while searching
+func (n *Node) summarize(ctx context.Context, term string, sr *searchResult) *doogle.GetIndexReply {
+	ret, numHidden := sr.ranked()
+	rep := &doogle.GetIndexReply{Items: ret, NumSimilarHidden: int32(numHidden), Stats: sr.stats}
+
+	if len(ret) < minNumResultsForSuggestion {
+		rep.Suggestions = n.suggestSpellings(ctx, term)
+	}
+	return rep
+}
+
+// searched terms with results become popular completions
+func (n *Node) publishQuery(term string, rep *doogle.GetIndexReply) {
+	if len(rep.Items) == 0 {
+		return
+	}
+
+	n.enqueuePublish(func() {
+		n.publishCompletion(&doogle.Completion{Term: term, Score: queryCompletionScore})
+	})
+}
+
+// cacheReply stores the reply into the query cache if no node failed to answer
+func (n *Node) cacheReply(key, term string, rep *doogle.GetIndexReply, gen uint64) {
+	if term == "" || rep.Stats.NumFailed > 0 || rep.Stats.NumTimedOut > 0 {
+		// results without the term are not invalidated by index changes
+		return
+	}
+
+	termAddr := sha1.Sum([]byte(term))
+	n.queryCache.put(key, doogleAddressStr(termAddr[:]), rep, gen)
+}
+
+func (n *Node) GetIndex(ctx context.Context, in *doogle.StringMessage) (*doogle.GetIndexReply, error) {
+
+	// TODO: deal with complex queries, like AND, OR, etc.
+
+	term, filter, timeout := parseQuery(in.Message)
+	key := queryCacheKey(term, filter)
+	rep, gen, ok := n.queryCache.get(key)
+	if ok {
+		n.publishQuery(term, rep)
+		return rep, nil
+	}
+
+	ctx, cancel := withQueryTimeout(ctx, timeout)
+	defer cancel()
+
+	searchCtx, cancelSearch := withSuggestionReserve(ctx)
+	defer cancelSearch()
+
+	sr := newSearchResult()
+	if err := n.search(searchCtx, term, filter, sr, func([]*doogle.Item) {}); err != nil {
+		return nil, err
+	}
+
+	rep = n.summarize(ctx, term, sr)
+	n.cacheReply(key, term, rep, gen)
+	n.publishQuery(term, rep)
+	return rep, nil
+}
+
+func (n *Node) SearchStream(in *doogle.StringMessage, stream doogle.Doogle_SearchStreamServer) error {
+	term, filter, timeout := parseQuery(in.Message)
+	key := queryCacheKey(term, filter)
+	rep, gen, ok := n.queryCache.get(key)
+	if ok {
+		n.publishQuery(term, rep)
+		return stream.Send(&doogle.SearchStreamReply{Items: rep.Items, Summary: rep})
+	}
+
+	ctx, cancel := withQueryTimeout(stream.Context(), timeout)
+	defer cancel()
+
+	searchCtx, cancelSearch := withSuggestionReserve(ctx)
+	defer cancelSearch()
+
+	sr := newSearchResult()
+
+	// stream.Send is not safe to call from multiple goroutines
+	var mux sync.Mutex
+	var sendErr error
+	onBatch := func(its []*doogle.Item) {
+		if len(its) == 0 {
+			return
+		}
+
+		mux.Lock()
+		defer mux.Unlock()
+		if sendErr != nil {
+			return
+		}
+		sendErr = stream.Send(&doogle.SearchStreamReply{Items: its})
+	}
+
+	if err := n.search(searchCtx, term, filter, sr, onBatch); err != nil {
+		return err
+	}
+
+	if sendErr != nil {
+		return status.Errorf(codes.Unavailable, "failed to send items: %v", sendErr)
+	}
+	rep = n.summarize(ctx, term, sr)
+	n.cacheReply(key, term, rep, gen)
+	n.publishQuery(term, rep)
+	return stream.Send(&doogle.SearchStreamReply{Summary: rep})
+}
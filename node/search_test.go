@@ -0,0 +1,350 @@
+package node
+
+import (
+	"context"
+	"crypto/sha1"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mathetake/doogle/grpc"
+	"google.golang.org/grpc"
+	"gotest.tools/assert"
+)
+
+func TestSearchResult_add(t *testing.T) {
+	sr := newSearchResult()
+
+	for i, cc := range []struct {
+		items   []*doogle.Item
+		expNew  []string
+		expRank []string
+	}{
+		{
+			items: []*doogle.Item{
+				{Url: "url1", LocalRank: 0.1},
+				{Url: "url2", LocalRank: 0.3},
+			},
+			expNew:  []string{"url2", "url1"},
+			expRank: []string{"url2", "url1"},
+		},
+		{
+			items: []*doogle.Item{
+				{Url: "url1", LocalRank: 0.9},
+				{Url: "url3", LocalRank: 0.2},
+			},
+			expNew:  []string{"url3"},
+			expRank: []string{"url2", "url1", "url3"},
+		},
+		{
+			items: []*doogle.Item{
+				{Url: "url4", LocalRank: 0.1, Fields: []doogle.Field{doogle.Field_TITLE}},
+			},
+			expNew:  []string{"url4"},
+			expRank: []string{"url4", "url2", "url1", "url3"},
+		},
+	} {
+		c := cc
+		t.Run(fmt.Sprintf("%d-th case", i), func(t *testing.T) {
+			actual := sr.add("node", c.items)
+			assert.Equal(t, len(c.expNew), len(actual))
+			for j, it := range actual {
+				assert.Equal(t, c.expNew[j], it.Url)
+			}
+
+			ranked, numHidden := sr.ranked()
+			assert.Equal(t, 0, numHidden)
+			assert.Equal(t, len(c.expRank), len(ranked))
+			for j, it := range ranked {
+				assert.Equal(t, c.expRank[j], it.Url)
+			}
+		})
+	}
+}
+
+func TestNode_SearchStream(t *testing.T) {
+	resetRoutingTable()
+	defer resetRoutingTable()
+	resetDHT()
+	defer resetDHT()
+
+	from, to := testServers[0], testServers[1]
+	from.node.updateRoutingTable(&nodeInfo{dAddr: to.node.DAddr, nAddr: localhost + to.port})
+
+	h := sha1.Sum([]byte("doogle"))
+	for _, cc := range []struct {
+		node  *Node
+		items []*item
+	}{
+		{
+			node: from.node,
+			items: []*item{
+				{url: "url1", dAddrStr: "address1", localRank: 0.1},
+				{url: "url2", dAddrStr: "address2", localRank: 0.2},
+			},
+		},
+		{
+			node: to.node,
+			items: []*item{
+				{url: "url2", dAddrStr: "address2", localRank: 0.2},
+				{url: "url3", dAddrStr: "address3", localRank: 0.3},
+			},
+		},
+	} {
+		dhtV := &dhtValue{mux: sync.Mutex{}}
+		for _, it := range cc.items {
+			dhtV.itemAddresses = append(dhtV.itemAddresses, it.dAddrStr)
+			cc.node.items.Store(it.dAddrStr, it)
+		}
+		cc.node.dht.Store(doogleAddressStr(h[:]), dhtV)
+	}
+
+	conn, err := grpc.Dial(localhost+from.port, grpc.WithInsecure())
+	assert.Equal(t, nil, err)
+	defer conn.Close()
+
+	stream, err := doogle.NewDoogleClient(conn).SearchStream(context.Background(), &doogle.StringMessage{Message: "doogle"})
+	assert.Equal(t, nil, err)
+
+	var reps []*doogle.SearchStreamReply
+	for {
+		rep, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		assert.Equal(t, nil, err)
+		reps = append(reps, rep)
+	}
+
+	// local items, items of the remote node and the summary
+	assert.Equal(t, 3, len(reps))
+
+	for i, exp := range [][]string{{"url2", "url1"}, {"url3"}} {
+		assert.Equal(t, (*doogle.GetIndexReply)(nil), reps[i].Summary)
+		assert.Equal(t, len(exp), len(reps[i].Items))
+		for j, it := range reps[i].Items {
+			assert.Equal(t, exp[j], it.Url)
+		}
+	}
+
+	summary := reps[2].Summary
+	assert.Assert(t, summary != nil)
+	assert.Equal(t, 0, len(reps[2].Items))
+	for j, exp := range []string{"url3", "url2", "url1"} {
+		assert.Equal(t, exp, summary.Items[j].Url)
+	}
+}
+
+func TestNode_GetIndex_deadline(t *testing.T) {
+	resetRoutingTable()
+	defer resetRoutingTable()
+	resetDHT()
+	defer resetDHT()
+
+	// the node accepting connections but never answering
+	lis, err := net.Listen("tcp", localhost+":0")
+	assert.Equal(t, nil, err)
+	defer lis.Close()
+
+	from, to := testServers[0], testServers[1]
+	from.node.updateRoutingTable(&nodeInfo{dAddr: to.node.DAddr, nAddr: localhost + to.port})
+
+	// put the silent node into the same bucket
+	silent := to.node.DAddr
+	silent[addressLength-1] ^= 1
+	from.node.updateRoutingTable(&nodeInfo{dAddr: silent, nAddr: lis.Addr().String()})
+
+	h := sha1.Sum([]byte("doogle"))
+	it := &item{url: "url1", dAddrStr: "address1", localRank: 0.1}
+	from.node.items.Store(it.dAddrStr, it)
+	from.node.dht.Store(doogleAddressStr(h[:]), &dhtValue{itemAddresses: []doogleAddressStr{it.dAddrStr}})
+
+	begin := time.Now()
+	res, err := from.node.GetIndex(context.Background(), &doogle.StringMessage{Message: "doogle timeout:300ms"})
+	assert.Equal(t, nil, err)
+	assert.Assert(t, time.Since(begin) < dialTimeout)
+
+	// partial results
+	assert.Equal(t, 1, len(res.Items))
+	assert.Equal(t, "url1", res.Items[0].Url)
+	assert.Equal(t, int32(1), res.Stats.NumAnswered)
+	assert.Equal(t, int32(1), res.Stats.NumTimedOut)
+	assert.Equal(t, int32(0), res.Stats.NumFailed)
+}
+
+func TestNode_summarize(t *testing.T) {
+	resetRoutingTable()
+	defer resetRoutingTable()
+
+	from, to := testServers[0], testServers[1]
+	from.node.updateRoutingTable(&nodeInfo{dAddr: to.node.DAddr, nAddr: localhost + to.port})
+
+	from.node.spellings, to.node.spellings = sync.Map{}, sync.Map{}
+	defer func() { from.node.spellings, to.node.spellings = sync.Map{}, sync.Map{} }()
+
+	// only held by the remote node
+	to.node.publishSpelling(&doogle.Spelling{Term: "doogle", DocFreq: 1})
+
+	res := from.node.summarize(context.Background(), "dooglw", newSearchResult())
+	assert.Equal(t, 0, len(res.Items))
+	assert.DeepEqual(t, []string{"doogle"}, res.Suggestions)
+
+	// the remote node is not asked after the query is canceled
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	res = from.node.summarize(ctx, "dooglw", newSearchResult())
+	assert.Equal(t, 0, len(res.Suggestions))
+}
+
+func TestWithSuggestionReserve(t *testing.T) {
+	ctx, cancel := withSuggestionReserve(context.Background())
+	defer cancel()
+	_, ok := ctx.Deadline()
+	assert.Equal(t, false, ok)
+
+	for i, cc := range []struct {
+		timeout, expReserve time.Duration
+	}{
+		{timeout: 500 * time.Millisecond, expReserve: 100 * time.Millisecond},
+		{timeout: 30 * time.Second, expReserve: maxSuggestionReserve},
+	} {
+		c := cc
+		t.Run(fmt.Sprintf("%d-th case", i), func(t *testing.T) {
+			qCtx, cancel := context.WithTimeout(context.Background(), c.timeout)
+			defer cancel()
+
+			ctx, cancel := withSuggestionReserve(qCtx)
+			defer cancel()
+
+			qDeadline, _ := qCtx.Deadline()
+			deadline, ok := ctx.Deadline()
+			assert.Equal(t, true, ok)
+
+			reserve := qDeadline.Sub(deadline)
+			assert.Assert(t, reserve <= c.expReserve && reserve > c.expReserve-10*time.Millisecond, reserve)
+		})
+	}
+}
+
+func TestClosestUnqueried(t *testing.T) {
+	var target doogleAddress
+	ni := func(b byte) *doogle.NodeInfo {
+		da := doogleAddress{}
+		da[0] = b
+		return &doogle.NodeInfo{DoogleAddress: da[:], NetworkAddress: fmt.Sprintf("node%d", b)}
+	}
+
+	for i, cc := range []struct {
+		nis     []*doogle.NodeInfo
+		queried []byte
+		exp     []string
+	}{
+		{
+			nis: []*doogle.NodeInfo{ni(4), ni(1), ni(3), ni(2)},
+			exp: []string{"node1", "node2", "node3"},
+		},
+		{
+			nis:     []*doogle.NodeInfo{ni(4), ni(1), ni(3), ni(1)},
+			queried: []byte{3},
+			exp:     []string{"node1", "node4"},
+		},
+		{
+			nis:     []*doogle.NodeInfo{ni(1), {DoogleAddress: []byte{1}}},
+			queried: []byte{1},
+			exp:     []string{},
+		},
+	} {
+		c := cc
+		t.Run(fmt.Sprintf("%d-th case", i), func(t *testing.T) {
+			queried := map[doogleAddress]struct{}{}
+			for _, b := range c.queried {
+				queried[toDoogleAddress(ni(b).DoogleAddress)] = struct{}{}
+			}
+
+			actual := closestUnqueried(target, c.nis, queried)
+			assert.Equal(t, len(c.exp), len(actual))
+			for j, a := range actual {
+				assert.Equal(t, c.exp[j], a.NetworkAddress)
+			}
+		})
+	}
+}
+
+func TestNode_lookupIndex(t *testing.T) {
+	resetRoutingTable()
+	defer resetRoutingTable()
+	resetDHT()
+	defer resetDHT()
+
+	// testServers[0] -> via -> testServers[2] which holds the index
+	from, holder := testServers[0], testServers[2]
+	target := holder.node.DAddr
+	target[addressLength-1] ^= 1
+
+	// the copy is only cached on the node closer to the key than the searching node
+	via := testServers[1]
+	for _, srv := range testServers[3:] {
+		if via.node.DAddr.xor(target).lessThanEqual(from.node.DAddr.xor(target)) {
+			break
+		}
+		via = srv
+	}
+	via.node.updateRoutingTable(&nodeInfo{dAddr: holder.node.DAddr, nAddr: localhost + holder.port})
+
+	it := &item{url: "url1", dAddrStr: "address1", localRank: 0.1}
+	holder.node.items.Store(it.dAddrStr, it)
+	holder.node.dht.Store(doogleAddressStr(target[:]), &dhtValue{itemAddresses: []doogleAddressStr{it.dAddrStr}})
+
+	shortlist := []*doogle.NodeInfo{{DoogleAddress: via.node.DAddr[:], NetworkAddress: localhost + via.port}}
+
+	publishQueue := from.node.publishQueue
+	from.node.publishQueue = make(chan func(), 1)
+	defer func() { from.node.publishQueue = publishQueue }()
+
+	sr := newSearchResult()
+	from.node.lookupIndex(context.Background(), target, nil, shortlist, maxLookupRounds, sr, func([]*doogle.Item) {})
+
+	ret, _ := sr.ranked()
+	assert.Equal(t, 1, len(ret))
+	assert.Equal(t, "url1", ret[0].Url)
+	assert.Equal(t, int32(2), sr.stats.NumAnswered)
+
+	// the posting list is cached on the node on the path
+	(<-from.node.publishQueue)()
+	res, err := via.node.findIndex(context.Background(), doogleAddressStr(target[:]), nil)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, res.Cached)
+	assert.Equal(t, "url1", res.Result.(*doogle.FindIndexReply_Items).Items.Items[0].Url)
+	via.node.indexCaches = sync.Map{}
+
+	// the referred node is added to the routing table
+	ns, err := from.node.findNode(holder.node.DAddr)
+	assert.Equal(t, nil, err)
+	var included bool
+	for _, ni := range ns {
+		included = included || ni.NetworkAddress == localhost+holder.port
+	}
+	assert.Equal(t, true, included)
+
+	// no referral is followed in a single round
+	sr = newSearchResult()
+	from.node.lookupIndex(context.Background(), target, nil, shortlist, 1, sr, func([]*doogle.Item) {})
+	ret, _ = sr.ranked()
+	assert.Equal(t, 0, len(ret))
+	assert.Equal(t, int32(1), sr.stats.NumAnswered)
+
+	// the referred node with the certificate of lower difficulty fails
+	difficulty := from.node.difficulty
+	from.node.difficulty = int(holder.node.certificate.Difficulty) + 1
+	defer func() { from.node.difficulty = difficulty }()
+
+	sr = newSearchResult()
+	from.node.lookupIndex(context.Background(), target, nil, shortlist, maxLookupRounds, sr, func([]*doogle.Item) {})
+	ret, _ = sr.ranked()
+	assert.Equal(t, 0, len(ret))
+	assert.Equal(t, int32(1), sr.stats.NumAnswered)
+	assert.Equal(t, int32(1), sr.stats.NumFailed)
+}
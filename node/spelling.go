@@ -0,0 +1,251 @@
+package node
+
+import (
+	"context"
+	"crypto/sha1"
+	"sort"
+	"sync"
+
+	"github.com/mathetake/doogle/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// prefix of deletion-neighbourhood keys in order not to collide with indices
+	spellingKeyPrefix = "spelling:"
+
+	// suggest spellings if the number of results is less than this
+	minNumResultsForSuggestion = 3
+	maxNumSuggestions          = 5
+
+	// terms shorter than this are corrected within edit distance 1, otherwise 2
+	minLenForDistance2 = 5
+)
+
+type spellingValue struct {
+	// type: map{term -> document frequency}
+	docFreqs map[string]int64
+	mux      sync.Mutex
+}
+
+// spellingKeys returns the deletion-neighbourhood of `term` within distance 1 including itself
+func spellingKeys(term string) []string {
+	rs := []rune(term)
+	ret := []string{term}
+	seen := map[string]struct{}{term: {}}
+	for i := range rs {
+		del := string(rs[:i]) + string(rs[i+1:])
+		if _, ok := seen[del]; ok || del == "" {
+			continue
+		}
+		seen[del] = struct{}{}
+		ret = append(ret, del)
+	}
+	return ret
+}
+
+func spellingAddress(key string) doogleAddress {
+	return sha1.Sum([]byte(spellingKeyPrefix + key))
+}
+
+// editDistance returns the optimal string alignment distance between a and b
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	d := make([][]int, len(ra)+1)
+	for i := range d {
+		d[i] = make([]int, len(rb)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+
+			d[i][j] = d[i-1][j-1] + cost
+			if v := d[i-1][j] + 1; v < d[i][j] {
+				d[i][j] = v
+			}
+			if v := d[i][j-1] + 1; v < d[i][j] {
+				d[i][j] = v
+			}
+
+			// transposition
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				if v := d[i-2][j-2] + 1; v < d[i][j] {
+					d[i][j] = v
+				}
+			}
+		}
+	}
+	return d[len(ra)][len(rb)]
+}
+
+func maxSpellingDistance(term string) int {
+	if len([]rune(term)) < minLenForDistance2 {
+		return 1
+	}
+	return 2
+}
+
+func (n *Node) StoreSpelling(ctx context.Context, in *doogle.StoreSpellingRequest) (*doogle.Empty, error) {
+	if !n.isValidSender(in.Certificate) {
+		return nil, status.Error(codes.InvalidArgument, "invalid certificate")
+	}
+
+	if in.Spelling == nil || in.Spelling.Term == "" {
+		return nil, status.Error(codes.InvalidArgument, "empty spelling")
+	}
+
+	n.storeSpelling(doogleAddressStr(in.DoogleAddress), in.Spelling)
+	return &doogle.Empty{}, nil
+}
+
+func (n *Node) storeSpelling(addr doogleAddressStr, sp *doogle.Spelling) {
+	actual, _ := n.spellings.LoadOrStore(addr, &spellingValue{
+		docFreqs: map[string]int64{},
+		mux:      sync.Mutex{},
+	})
+
+	sv := actual.(*spellingValue)
+	sv.mux.Lock()
+	defer sv.mux.Unlock()
+
+	if df, ok := sv.docFreqs[sp.Term]; !ok || sp.DocFreq > df {
+		sv.docFreqs[sp.Term] = sp.DocFreq
+	}
+}
+
+func (n *Node) FindSpelling(ctx context.Context, in *doogle.FindSpellingRequest) (*doogle.Spellings, error) {
+	if !n.isValidSender(in.Certificate) {
+		return nil, status.Error(codes.InvalidArgument, "invalid certificate")
+	}
+	return &doogle.Spellings{Spellings: n.findSpelling(doogleAddressStr(in.DoogleAddress))}, nil
+}
+
+func (n *Node) findSpelling(addr doogleAddressStr) []*doogle.Spelling {
+	raw, ok := n.spellings.Load(addr)
+	if !ok {
+		return nil
+	}
+
+	sv := raw.(*spellingValue)
+	sv.mux.Lock()
+	defer sv.mux.Unlock()
+
+	ret := make([]*doogle.Spelling, 0, len(sv.docFreqs))
+	for term, df := range sv.docFreqs {
+		ret = append(ret, &doogle.Spelling{Term: term, DocFreq: df})
+	}
+	return ret
+}
+
+// store the spelling on the nodes closest to each key of its deletion-neighbourhood
+func (n *Node) publishSpelling(sp *doogle.Spelling) {
+	for _, key := range spellingKeys(sp.Term) {
+		addr := spellingAddress(key)
+		req := &doogle.StoreSpellingRequest{
+			Certificate:   n.certificate,
+			DoogleAddress: addr[:],
+			Spelling:      sp,
+		}
+
+		if !n.forEachClosestNode(context.Background(), addr, func(ctx context.Context, c doogle.DoogleClient) error {
+			_, err := c.StoreSpelling(ctx, req)
+			return err
+		}) {
+			// if no node is closer, store spelling into its own table
+			n.storeSpelling(doogleAddressStr(addr[:]), sp)
+		}
+	}
+}
+
+// suggestSpellings returns the indexed terms close to `term`, ranked by edit distance and document frequency
+func (n *Node) suggestSpellings(ctx context.Context, term string) []string {
+	if term == "" {
+		return nil
+	}
+
+	docFreqs := map[string]int64{}
+	var mux sync.Mutex
+	merge := func(sps []*doogle.Spelling) {
+		mux.Lock()
+		defer mux.Unlock()
+		for _, sp := range sps {
+			if df, ok := docFreqs[sp.Term]; !ok || sp.DocFreq > df {
+				docFreqs[sp.Term] = sp.DocFreq
+			}
+		}
+	}
+
+	var wg sync.WaitGroup
+	for _, key := range spellingKeys(term) {
+		wg.Add(1)
+		go func(key string) {
+			defer wg.Done()
+
+			addr := spellingAddress(key)
+			merge(n.findSpelling(doogleAddressStr(addr[:])))
+
+			rep, err := n.findNode(addr)
+			if err != nil {
+				return
+			}
+
+			for _, ni := range rep {
+				conn, err := n.getConnByNetworkAddress(ctx, ni.NetworkAddress)
+				if err != nil {
+					continue
+				}
+
+				c := doogle.NewDoogleClient(conn)
+				res, err := c.FindSpelling(ctx, &doogle.FindSpellingRequest{
+					Certificate:   n.certificate,
+					DoogleAddress: addr[:],
+				})
+				if err != nil {
+					n.logger.Errorf("failed to call FindSpelling: %v", err)
+					continue
+				}
+				merge(res.Spellings)
+			}
+		}(key)
+	}
+	wg.Wait()
+
+	type candidate struct {
+		term    string
+		dist    int
+		docFreq int64
+	}
+
+	maxDist := maxSpellingDistance(term)
+	var cs []candidate
+	for t, df := range docFreqs {
+		if d := editDistance(term, t); d > 0 && d <= maxDist {
+			cs = append(cs, candidate{term: t, dist: d, docFreq: df})
+		}
+	}
+
+	sort.Slice(cs, func(i, j int) bool {
+		if cs[i].dist != cs[j].dist {
+			return cs[i].dist < cs[j].dist
+		}
+		if cs[i].docFreq != cs[j].docFreq {
+			return cs[i].docFreq > cs[j].docFreq
+		}
+		return cs[i].term < cs[j].term
+	})
+
+	ret := make([]string, 0, maxNumSuggestions)
+	for i := 0; i < len(cs) && i < maxNumSuggestions; i++ {
+		ret = append(ret, cs[i].term)
+	}
+	return ret
+}
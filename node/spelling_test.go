@@ -0,0 +1,108 @@
+package node
+
+import (
+	"context"
+	"crypto/sha1"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/mathetake/doogle/grpc"
+	"gotest.tools/assert"
+)
+
+func TestSpellingKeys(t *testing.T) {
+	for i, cc := range []struct {
+		term string
+		exp  []string
+	}{
+		{term: "go", exp: []string{"go", "o", "g"}},
+		{term: "foo", exp: []string{"foo", "oo", "fo"}},
+		{term: "a", exp: []string{"a"}},
+	} {
+		c := cc
+		t.Run(fmt.Sprintf("%d-th case", i), func(t *testing.T) {
+			assert.DeepEqual(t, c.exp, spellingKeys(c.term))
+		})
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	for i, cc := range []struct {
+		a, b string
+		exp  int
+	}{
+		{"doogle", "doogle", 0},
+		{"doogle", "dogle", 1},
+		{"doogle", "dooglee", 1},
+		{"doogle", "doigle", 1},
+		{"doogle", "odogle", 1},
+		{"doogle", "google", 1},
+		{"kademlia", "kadmelai", 2},
+		{"", "abc", 3},
+	} {
+		c := cc
+		t.Run(fmt.Sprintf("%d-th case", i), func(t *testing.T) {
+			assert.Equal(t, c.exp, editDistance(c.a, c.b))
+			assert.Equal(t, c.exp, editDistance(c.b, c.a))
+		})
+	}
+}
+
+func TestNode_suggestSpellings(t *testing.T) {
+	resetRoutingTable()
+	defer resetRoutingTable()
+
+	srv := testServers[0].node
+	srv.spellings = sync.Map{}
+	defer func() { srv.spellings = sync.Map{} }()
+
+	for _, sp := range []*doogle.Spelling{
+		{Term: "doogle", DocFreq: 4},
+		{Term: "google", DocFreq: 16},
+		{Term: "dongle", DocFreq: 1},
+		{Term: "kademlia", DocFreq: 8},
+		{Term: "go", DocFreq: 2},
+	} {
+		srv.publishSpelling(sp)
+	}
+
+	for i, cc := range []struct {
+		term string
+		exp  []string
+	}{
+		{term: "dogle", exp: []string{"doogle", "dongle"}},
+		{term: "doogle", exp: []string{"google", "dongle"}},
+		{term: "kadmelia", exp: []string{"kademlia"}},
+		{term: "og", exp: []string{"go"}},
+		{term: "xyz", exp: []string{}},
+	} {
+		c := cc
+		t.Run(fmt.Sprintf("%d-th case", i), func(t *testing.T) {
+			assert.DeepEqual(t, c.exp, srv.suggestSpellings(context.Background(), c.term))
+		})
+	}
+}
+
+func TestNode_GetIndex_suggestions(t *testing.T) {
+	resetRoutingTable()
+	defer resetRoutingTable()
+	resetDHT()
+	defer resetDHT()
+
+	srv := testServers[0].node
+	srv.spellings = sync.Map{}
+	defer func() { srv.spellings = sync.Map{} }()
+
+	srv.publishSpelling(&doogle.Spelling{Term: "doogle", DocFreq: 1})
+
+	dhtV := &dhtValue{itemAddresses: []doogleAddressStr{"address1"}, mux: sync.Mutex{}}
+	srv.items.Store(doogleAddressStr("address1"), &item{url: "url1", dAddrStr: "address1"})
+	h := sha1.Sum([]byte("doogle"))
+	srv.dht.Store(doogleAddressStr(h[:]), dhtV)
+
+	res, err := srv.GetIndex(context.Background(), &doogle.StringMessage{Message: "dooglw"})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 0, len(res.Items))
+	assert.DeepEqual(t, []string{"doogle"}, res.Suggestions)
+}
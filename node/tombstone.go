@@ -0,0 +1,375 @@
+package node
+
+import (
+	"context"
+	"crypto/sha1"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mathetake/doogle/grpc"
+	"golang.org/x/crypto/ed25519"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type documentValue struct {
+	doc *doogle.Document
+	mux sync.Mutex
+}
+
+const (
+	// tombstones are forgotten after `tombstoneTTL` since the deletion. items older than them can be stored again,
+	// but such requests are unlikely to arrive that late
+	tombstoneTTL = 7 * 24 * time.Hour
+
+	// interval of collecting the expired tombstones
+	tombstoneGCInterval = time.Hour
+
+	// max difference of clocks between nodes allowed for the timestamps of tombstones
+	maxClockSkew = time.Minute
+)
+
+type tombstoneValue struct {
+	// the latest timestamps each item was deleted at, in unix nanoseconds
+	// type: map{doogleAddressStr -> int64}
+	deletedAt map[doogleAddressStr]int64
+
+	// set when the value is removed from the tombstones by the collector
+	removed bool
+	mux     sync.Mutex
+}
+
+// record the timestamp the item on `itemAddr` deleted from the index at
+func (n *Node) markDeleted(idxAddr, itemAddr doogleAddressStr, ts int64) {
+	for {
+		actual, _ := n.tombstones.LoadOrStore(idxAddr, &tombstoneValue{
+			deletedAt: map[doogleAddressStr]int64{},
+			mux:       sync.Mutex{},
+		})
+
+		tv := actual.(*tombstoneValue)
+		tv.mux.Lock()
+		if tv.removed {
+			// collected after loaded. retry with the new value
+			tv.mux.Unlock()
+			continue
+		}
+
+		if ts > tv.deletedAt[itemAddr] {
+			tv.deletedAt[itemAddr] = ts
+		}
+		tv.mux.Unlock()
+		return
+	}
+}
+
+// deletedAt returns the timestamp the item on `itemAddr` deleted from the index at, and false if it's never deleted
+func (n *Node) deletedAt(idxAddr, itemAddr doogleAddressStr) (int64, bool) {
+	raw, ok := n.tombstones.Load(idxAddr)
+	if !ok {
+		return 0, false
+	}
+
+	tv := raw.(*tombstoneValue)
+	tv.mux.Lock()
+	defer tv.mux.Unlock()
+
+	ts, ok := tv.deletedAt[itemAddr]
+	return ts, ok
+}
+
+// StartTombstoneCollector periodically forgets the expired tombstones
+func (n *Node) StartTombstoneCollector() {
+	go func() {
+		n.logger.Infof("[tombstoneCollector] started: interval=%v", tombstoneGCInterval)
+		for now := range time.Tick(tombstoneGCInterval) {
+			n.collectTombstones(now)
+		}
+	}()
+}
+
+// collectTombstones removes the tombstones deleted before `tombstoneTTL` ago, and returns the number of them
+func (n *Node) collectTombstones(now time.Time) int {
+	expiredAt := now.Add(-tombstoneTTL).UnixNano()
+
+	var num int
+	n.tombstones.Range(func(key, raw interface{}) bool {
+		tv := raw.(*tombstoneValue)
+		tv.mux.Lock()
+		defer tv.mux.Unlock()
+
+		for addr, ts := range tv.deletedAt {
+			if ts < expiredAt {
+				delete(tv.deletedAt, addr)
+				num++
+			}
+		}
+
+		if len(tv.deletedAt) == 0 {
+			tv.removed = true
+			n.tombstones.CompareAndDelete(key, tv)
+		}
+		return true
+	})
+	return num
+}
+
+func tombstoneBytes(tb *doogle.Tombstone) []byte {
+	return []byte(fmt.Sprintf("tombstone\x00%s\x00%s\x00%d\x00%t", tb.Url, tb.Index, tb.Timestamp, tb.Gone))
+}
+
+func documentBytes(doc *doogle.Document) []byte {
+	return []byte(fmt.Sprintf("document\x00%s\x00%d\x00%s\x00%d\x00%t\x00%d\x00%s",
+		doc.Url, len(doc.Terms), strings.Join(doc.Terms, "\x00"), doc.Timestamp, doc.Deleted,
+		len(doc.EdgeURLs), strings.Join(doc.EdgeURLs, "\x00")))
+}
+
+func verifySignature(publicKey, msg, sig []byte) bool {
+	if len(publicKey) != ed25519.PublicKeySize {
+		return false
+	}
+	return ed25519.Verify(publicKey, msg, sig)
+}
+
+func (n *Node) DeleteItem(ctx context.Context, in *doogle.DeleteItemRequest) (*doogle.Empty, error) {
+	if !n.isValidSender(in.Certificate) {
+		return nil, status.Error(codes.InvalidArgument, "invalid certificate")
+	}
+
+	if in.Tombstone == nil || !verifySignature(in.Certificate.PublicKey, tombstoneBytes(in.Tombstone), in.Signature) {
+		return nil, status.Error(codes.InvalidArgument, "invalid signature")
+	}
+
+	// tombstones from the future would refuse the items for long
+	if in.Tombstone.Timestamp > time.Now().Add(maxClockSkew).UnixNano() {
+		return nil, status.Error(codes.InvalidArgument, "invalid timestamp")
+	}
+
+	// any certified node may have seen the page changed or gone, and newer crawls win over the tombstone
+	n.deleteItem(in.Tombstone)
+	return &doogle.Empty{}, nil
+}
+
+// deleteItem removes the url from the posting list of the index unless it is stored after the tombstone
+func (n *Node) deleteItem(tb *doogle.Tombstone) {
+	h := sha1.Sum([]byte(normalizeURL(tb.Url)))
+	itemAddr := doogleAddressStr(h[:])
+
+	h = sha1.Sum([]byte(tb.Index))
+	idxAddr := doogleAddressStr(h[:])
+
+	raw, ok := n.dht.Load(idxAddr)
+	if !ok {
+		// keep the tombstone even if the index is not stored yet, so that the older item is refused
+		n.markDeleted(idxAddr, itemAddr, tb.Timestamp)
+
+		// the index may be stored before the tombstone is recorded
+		if raw, ok = n.dht.Load(idxAddr); !ok {
+			return
+		}
+	}
+
+	dhtV := raw.(*dhtValue)
+	dhtV.mux.Lock()
+	defer dhtV.mux.Unlock()
+
+	if tb.Timestamp < dhtV.storedAt[itemAddr] {
+		// the item is stored again after the deletion
+		return
+	}
+
+	// the tombstone takes over the timestamp from here
+	n.markDeleted(idxAddr, itemAddr, tb.Timestamp)
+	delete(dhtV.storedAt, itemAddr)
+
+	as := make([]doogleAddressStr, 0, len(dhtV.itemAddresses))
+	for _, addr := range dhtV.itemAddresses {
+		if addr != itemAddr {
+			as = append(as, addr)
+		}
+	}
+	dhtV.itemAddresses = as
+	delete(dhtV.fields, itemAddr)
+
+	if tb.Gone {
+		// the page is not linked from the indices of this node anymore
+		n.items.Delete(itemAddr)
+	}
+
+	n.queryCache.invalidate(idxAddr)
+	n.logger.Infof("[DeleteItem] item deleted: url=%s, token=%s", tb.Url, tb.Index)
+}
+
+// make signed DeleteItem requests to the nodes closest to the index of `tb`
+func (n *Node) deleteItemOnClosestNodes(ctx context.Context, tb *doogle.Tombstone) {
+	req := &doogle.DeleteItemRequest{
+		Certificate: n.certificate,
+		Tombstone:   tb,
+		Signature:   ed25519.Sign(n.secretKey, tombstoneBytes(tb)),
+	}
+
+	addr := sha1.Sum([]byte(tb.Index))
+	if !n.forEachClosestNode(ctx, addr, func(ctx context.Context, c doogle.DoogleClient) error {
+		_, err := c.DeleteItem(ctx, req)
+		return err
+	}) {
+		// if no node is closer, delete item from its own table
+		n.deleteItem(tb)
+	}
+}
+
+func (n *Node) StoreDocument(ctx context.Context, in *doogle.StoreDocumentRequest) (*doogle.Empty, error) {
+	if !n.isValidSender(in.Certificate) {
+		return nil, status.Error(codes.InvalidArgument, "invalid certificate")
+	}
+
+	if in.Document == nil || !verifySignature(in.Certificate.PublicKey, documentBytes(in.Document), in.Signature) {
+		return nil, status.Error(codes.InvalidArgument, "invalid signature")
+	}
+
+	// documents from the future would never be replaced by newer crawls
+	if in.Document.Timestamp > time.Now().Add(maxClockSkew).UnixNano() {
+		return nil, status.Error(codes.InvalidArgument, "invalid timestamp")
+	}
+
+	n.storeDocument(in.Document)
+	return &doogle.Empty{}, nil
+}
+
+// storeDocument keeps the newest document of the url
+func (n *Node) storeDocument(doc *doogle.Document) {
+	h := sha1.Sum([]byte(doc.Url))
+	actual, _ := n.documents.LoadOrStore(doogleAddressStr(h[:]), &documentValue{doc: doc})
+
+	dv := actual.(*documentValue)
+	dv.mux.Lock()
+	defer dv.mux.Unlock()
+
+	if doc.Timestamp > dv.doc.Timestamp {
+		dv.doc = doc
+	}
+}
+
+func (n *Node) FindDocument(ctx context.Context, in *doogle.FindDocumentRequest) (*doogle.Document, error) {
+	if !n.isValidSender(in.Certificate) {
+		return nil, status.Error(codes.InvalidArgument, "invalid certificate")
+	}
+
+	if doc := n.findLocalDocument(doogleAddressStr(in.DoogleAddress)); doc != nil {
+		return doc, nil
+	}
+	return &doogle.Document{}, nil
+}
+
+func (n *Node) findLocalDocument(addr doogleAddressStr) *doogle.Document {
+	raw, ok := n.documents.Load(addr)
+	if !ok {
+		return nil
+	}
+
+	dv := raw.(*documentValue)
+	dv.mux.Lock()
+	defer dv.mux.Unlock()
+	return dv.doc
+}
+
+// findDocument returns the newest document of the url among its own and the closest nodes. nil if not found
+func (n *Node) findDocument(ctx context.Context, url string) *doogle.Document {
+	addr := sha1.Sum([]byte(url))
+	ret := n.findLocalDocument(doogleAddressStr(addr[:]))
+
+	var mux sync.Mutex
+	n.forEachClosestNode(ctx, addr, func(ctx context.Context, c doogle.DoogleClient) error {
+		doc, err := c.FindDocument(ctx, &doogle.FindDocumentRequest{
+			Certificate:   n.certificate,
+			DoogleAddress: addr[:],
+		})
+		if err != nil {
+			return err
+		}
+
+		mux.Lock()
+		defer mux.Unlock()
+		if doc.Url == url && (ret == nil || doc.Timestamp > ret.Timestamp) {
+			ret = doc
+		}
+		return nil
+	})
+	return ret
+}
+
+// store the signed document on the nodes closest to its url
+func (n *Node) publishDocument(ctx context.Context, doc *doogle.Document) {
+	req := &doogle.StoreDocumentRequest{
+		Certificate: n.certificate,
+		Document:    doc,
+		Signature:   ed25519.Sign(n.secretKey, documentBytes(doc)),
+	}
+
+	addr := sha1.Sum([]byte(doc.Url))
+	if !n.forEachClosestNode(ctx, addr, func(ctx context.Context, c doogle.DoogleClient) error {
+		_, err := c.StoreDocument(ctx, req)
+		return err
+	}) {
+		// if no node is closer, store document into its own table
+		n.storeDocument(doc)
+	}
+}
+
+// replaceDocument deletes the indices and backlinks of the previous document not contained in the new one,
+// and then stores the new document
+func (n *Node) replaceDocument(ctx context.Context, prev, doc *doogle.Document) {
+	if prev != nil {
+		for _, t := range difference(prev.Terms, doc.Terms) {
+			n.deleteItemOnClosestNodes(ctx, &doogle.Tombstone{Url: doc.Url, Index: t, Timestamp: doc.Timestamp})
+		}
+
+		n.publishBacklinks(ctx, doc.Url, "", difference(prev.EdgeURLs, doc.EdgeURLs), nil, doc.Timestamp, true)
+	}
+	n.publishDocument(ctx, doc)
+}
+
+// unlistPage deletes all the indices of the gone page, including the anchor texts of the pages linking to it,
+// and publishes its deleted document
+func (n *Node) unlistPage(ctx context.Context, url string) {
+	now := time.Now().UnixNano()
+	prev := n.findDocument(ctx, url)
+
+	var terms []string
+	if prev != nil {
+		terms = append(terms, prev.Terms...)
+	}
+	for _, bl := range n.findBacklinks(ctx, url) {
+		terms = append(terms, difference(bl.AnchorTerms, terms)...)
+	}
+
+	for _, t := range terms {
+		n.deleteItemOnClosestNodes(ctx, &doogle.Tombstone{Url: url, Index: t, Timestamp: now, Gone: true})
+	}
+
+	if prev != nil {
+		n.publishBacklinks(ctx, url, "", prev.EdgeURLs, nil, now, true)
+	}
+
+	h := sha1.Sum([]byte(url))
+	n.items.Delete(doogleAddressStr(h[:]))
+	n.publishDocument(ctx, &doogle.Document{Url: url, Timestamp: now, Deleted: true})
+}
+
+// difference returns the elements of `xs` not contained in `ys`
+func difference(xs, ys []string) []string {
+	m := make(map[string]struct{}, len(ys))
+	for _, y := range ys {
+		m[y] = struct{}{}
+	}
+
+	var ret []string
+	for _, x := range xs {
+		if _, ok := m[x]; !ok {
+			ret = append(ret, x)
+		}
+	}
+	return ret
+}
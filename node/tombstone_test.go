@@ -0,0 +1,419 @@
+package node
+
+import (
+	"context"
+	"crypto/sha1"
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/mathetake/doogle/crawler"
+	"github.com/mathetake/doogle/grpc"
+	"golang.org/x/crypto/ed25519"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gotest.tools/assert"
+)
+
+// urlsOf returns the urls in the posting list of the term, which is empty if the term is not stored
+func urlsOf(n *Node, term string) []string {
+	h := sha1.Sum([]byte(term))
+	res, err := n.findIndex(context.Background(), doogleAddressStr(h[:]), nil)
+	if err != nil {
+		return nil
+	}
+
+	ret := []string{}
+	its, ok := res.Result.(*doogle.FindIndexReply_Items)
+	if !ok {
+		return ret
+	}
+
+	for _, it := range its.Items.Items {
+		ret = append(ret, it.Url)
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+func TestNode_DeleteItem(t *testing.T) {
+	resetRoutingTable()
+	defer resetRoutingTable()
+	resetDHT()
+	defer resetDHT()
+
+	srv := testServers[0].node
+	ct := srv.certificate
+	tb := &doogle.Tombstone{Url: "http://url1/", Index: "doogle", Timestamp: 10}
+
+	_, err := srv.StoreItem(context.Background(), &doogle.StoreItemRequest{Certificate: ct, Url: "http://url1/", Index: "doogle", Timestamp: 5})
+	assert.Equal(t, nil, err)
+
+	other, err := NewNode(1, "other", logger, nil, 0)
+	assert.Equal(t, nil, err)
+
+	future := &doogle.Tombstone{Url: "http://url1/", Index: "doogle", Timestamp: time.Now().Add(time.Hour).UnixNano()}
+	for i, cc := range []struct {
+		ct  *doogle.NodeCertificate
+		tb  *doogle.Tombstone
+		sig []byte
+		exp codes.Code
+	}{
+		{ct: ct, tb: tb, sig: ed25519.Sign(other.secretKey, tombstoneBytes(tb)), exp: codes.InvalidArgument},
+		{
+			ct: ct, tb: &doogle.Tombstone{Url: "http://url2/", Index: "doogle", Timestamp: 10},
+			sig: ed25519.Sign(srv.secretKey, tombstoneBytes(tb)), exp: codes.InvalidArgument,
+		},
+		{ct: ct, tb: future, sig: ed25519.Sign(srv.secretKey, tombstoneBytes(future)), exp: codes.InvalidArgument},
+		// the item stored by srv is deleted by another node which found the page gone
+		{ct: other.certificate, tb: tb, sig: ed25519.Sign(other.secretKey, tombstoneBytes(tb)), exp: codes.OK},
+		{ct: ct, tb: tb, sig: ed25519.Sign(srv.secretKey, tombstoneBytes(tb)), exp: codes.OK},
+	} {
+		c := cc
+		t.Run(fmt.Sprintf("%d-th case", i), func(t *testing.T) {
+			_, err := srv.DeleteItem(context.Background(), &doogle.DeleteItemRequest{
+				Certificate: c.ct,
+				Tombstone:   c.tb,
+				Signature:   c.sig,
+			})
+			assert.Equal(t, c.exp, status.Code(err))
+		})
+	}
+
+	assert.DeepEqual(t, []string{}, urlsOf(srv, "doogle"))
+}
+
+func TestNode_StoreDocument(t *testing.T) {
+	resetDHT()
+	defer resetDHT()
+
+	srv := testServers[0].node
+	other, err := NewNode(1, "other", logger, nil, 0)
+	assert.Equal(t, nil, err)
+
+	doc := &doogle.Document{Url: "http://url1/", Terms: []string{"doogle"}, Timestamp: 10}
+	deleted := &doogle.Document{Url: "http://url1/", Timestamp: 20, Deleted: true}
+	future := &doogle.Document{Url: "http://url1/", Timestamp: time.Now().Add(time.Hour).UnixNano()}
+	for i, cc := range []struct {
+		ct     *doogle.NodeCertificate
+		doc    *doogle.Document
+		sig    []byte
+		exp    codes.Code
+		expDoc *doogle.Document
+	}{
+		{ct: srv.certificate, doc: doc, sig: ed25519.Sign(other.secretKey, documentBytes(doc)), exp: codes.InvalidArgument},
+		{ct: srv.certificate, doc: doc, sig: ed25519.Sign(srv.secretKey, documentBytes(doc)), exp: codes.OK, expDoc: doc},
+		{ct: srv.certificate, doc: future, sig: ed25519.Sign(srv.secretKey, documentBytes(future)), exp: codes.InvalidArgument, expDoc: doc},
+		// the document stored by srv is deleted by another node which found the page gone
+		{ct: other.certificate, doc: deleted, sig: ed25519.Sign(other.secretKey, documentBytes(deleted)), exp: codes.OK, expDoc: deleted},
+	} {
+		c := cc
+		t.Run(fmt.Sprintf("%d-th case", i), func(t *testing.T) {
+			_, err := srv.StoreDocument(context.Background(), &doogle.StoreDocumentRequest{
+				Certificate: c.ct,
+				Document:    c.doc,
+				Signature:   c.sig,
+			})
+			assert.Equal(t, c.exp, status.Code(err))
+
+			h := sha1.Sum([]byte("http://url1/"))
+			assert.DeepEqual(t, c.expDoc, srv.findLocalDocument(doogleAddressStr(h[:])))
+		})
+	}
+}
+
+func TestNode_deleteItem(t *testing.T) {
+	resetDHT()
+	defer resetDHT()
+
+	srv := testServers[0].node
+	ct := srv.certificate
+
+	for i, cc := range []struct {
+		store   *doogle.StoreItemRequest
+		delete  *doogle.Tombstone
+		expUrls []string
+	}{
+		{
+			store:   &doogle.StoreItemRequest{Certificate: ct, Url: "http://url1/", Index: "doogle", Timestamp: 10},
+			expUrls: []string{"http://url1/"},
+		},
+		{
+			// older than the stored one
+			delete:  &doogle.Tombstone{Url: "http://url1/", Index: "doogle", Timestamp: 5},
+			expUrls: []string{"http://url1/"},
+		},
+		{
+			delete:  &doogle.Tombstone{Url: "http://url1/", Index: "doogle", Timestamp: 20},
+			expUrls: []string{},
+		},
+		{
+			// older than the tombstone
+			store:   &doogle.StoreItemRequest{Certificate: ct, Url: "http://url1/", Index: "doogle", Timestamp: 15},
+			expUrls: []string{},
+		},
+		{
+			// anchor texts of other pages never revive the item
+			store:   &doogle.StoreItemRequest{Certificate: ct, Url: "http://url1/", Index: "doogle", Timestamp: 30, AnchorOnly: true},
+			expUrls: []string{},
+		},
+		{
+			store:   &doogle.StoreItemRequest{Certificate: ct, Url: "http://url1/", Index: "doogle", Timestamp: 30},
+			expUrls: []string{"http://url1/"},
+		},
+		{
+			// tombstone arrived before the item
+			delete:  &doogle.Tombstone{Url: "http://url2/", Index: "doogle", Timestamp: 20},
+			expUrls: []string{"http://url1/"},
+		},
+		{
+			store:   &doogle.StoreItemRequest{Certificate: ct, Url: "http://url2/", Index: "doogle", Timestamp: 10},
+			expUrls: []string{"http://url1/"},
+		},
+	} {
+		c := cc
+		t.Run(fmt.Sprintf("%d-th case", i), func(t *testing.T) {
+			if c.store != nil {
+				_, err := srv.StoreItem(context.Background(), c.store)
+				assert.Equal(t, nil, err)
+			}
+
+			if c.delete != nil {
+				srv.deleteItem(c.delete)
+			}
+			assert.DeepEqual(t, c.expUrls, urlsOf(srv, "doogle"))
+		})
+	}
+}
+
+func TestNode_collectTombstones(t *testing.T) {
+	resetDHT()
+	defer resetDHT()
+
+	srv := testServers[0].node
+	now := time.Now()
+
+	for i, ts := range []time.Time{now.Add(-2 * tombstoneTTL), now.Add(-tombstoneTTL / 2)} {
+		tb := &doogle.Tombstone{Url: fmt.Sprintf("http://url%d/", i), Index: "doogle", Timestamp: ts.UnixNano()}
+		srv.deleteItem(tb)
+	}
+	assert.Equal(t, 1, srv.collectTombstones(now))
+
+	h := sha1.Sum([]byte("doogle"))
+	for i, exp := range []bool{false, true} {
+		h2 := sha1.Sum([]byte(fmt.Sprintf("http://url%d/", i)))
+		_, ok := srv.deletedAt(doogleAddressStr(h[:]), doogleAddressStr(h2[:]))
+		assert.Equal(t, exp, ok)
+	}
+
+	// the empty tombstones are forgotten
+	raw, _ := srv.tombstones.Load(doogleAddressStr(h[:]))
+	assert.Equal(t, 1, srv.collectTombstones(now.Add(tombstoneTTL)))
+	_, ok := srv.tombstones.Load(doogleAddressStr(h[:]))
+	assert.Equal(t, false, ok)
+
+	// the tombstone recorded by those which loaded the collected value is not lost
+	assert.Equal(t, true, raw.(*tombstoneValue).removed)
+	srv.markDeleted(doogleAddressStr(h[:]), "address1", now.UnixNano())
+	_, ok = srv.deletedAt(doogleAddressStr(h[:]), "address1")
+	assert.Equal(t, true, ok)
+}
+
+func TestNode_findIndex_deleted(t *testing.T) {
+	resetRoutingTable()
+	defer resetRoutingTable()
+	resetDHT()
+	defer resetDHT()
+
+	srv := testServers[0].node
+	srv.updateRoutingTable(&nodeInfo{dAddr: testServers[1].node.DAddr, nAddr: localhost + testServers[1].port})
+
+	h := sha1.Sum([]byte("doogle"))
+	idxAddr := doogleAddressStr(h[:])
+
+	for i, cc := range []struct {
+		store    *doogle.StoreItemRequest
+		delete   *doogle.Tombstone
+		expItems bool
+	}{
+		// the tombstone of the index not stored
+		{delete: &doogle.Tombstone{Url: "http://url1/", Index: "doogle", Timestamp: 10}, expItems: false},
+		{
+			store:    &doogle.StoreItemRequest{Certificate: srv.certificate, Url: "http://url1/", Index: "doogle", Timestamp: 20},
+			expItems: true,
+		},
+		// all the items are deleted
+		{delete: &doogle.Tombstone{Url: "http://url1/", Index: "doogle", Timestamp: 30}, expItems: false},
+	} {
+		c := cc
+		t.Run(fmt.Sprintf("%d-th case", i), func(t *testing.T) {
+			if c.store != nil {
+				_, err := srv.StoreItem(context.Background(), c.store)
+				assert.Equal(t, nil, err)
+			}
+
+			if c.delete != nil {
+				srv.deleteItem(c.delete)
+			}
+
+			res, err := srv.findIndex(context.Background(), idxAddr, nil)
+			assert.Equal(t, nil, err)
+
+			// referrals are returned instead of the empty posting list
+			_, ok := res.Result.(*doogle.FindIndexReply_Items)
+			assert.Equal(t, c.expItems, ok)
+		})
+	}
+}
+
+func TestNode_StoreItem_replaceFields(t *testing.T) {
+	resetDHT()
+	defer resetDHT()
+
+	srv := testServers[0].node
+	ct := srv.certificate
+	h := sha1.Sum([]byte("http://url1/"))
+	itemAddr := doogleAddressStr(h[:])
+
+	for i, cc := range []struct {
+		store     *doogle.StoreItemRequest
+		expFields []doogle.Field
+	}{
+		{
+			store: &doogle.StoreItemRequest{
+				Certificate: ct, Url: "http://url1/", Index: "doogle", Timestamp: 10,
+				Fields: []doogle.Field{doogle.Field_TITLE, doogle.Field_BODY},
+			},
+			expFields: []doogle.Field{doogle.Field_TITLE, doogle.Field_BODY},
+		},
+		{
+			store: &doogle.StoreItemRequest{
+				Certificate: ct, Url: "http://url1/", Index: "doogle", Timestamp: 10, AnchorOnly: true,
+				Fields: []doogle.Field{doogle.Field_ANCHOR},
+			},
+			expFields: []doogle.Field{doogle.Field_TITLE, doogle.Field_BODY, doogle.Field_ANCHOR},
+		},
+		{
+			// the term was removed from the title
+			store: &doogle.StoreItemRequest{
+				Certificate: ct, Url: "http://url1/", Index: "doogle", Timestamp: 20,
+				Fields: []doogle.Field{doogle.Field_BODY},
+			},
+			expFields: []doogle.Field{doogle.Field_ANCHOR, doogle.Field_BODY},
+		},
+	} {
+		c := cc
+		t.Run(fmt.Sprintf("%d-th case", i), func(t *testing.T) {
+			_, err := srv.StoreItem(context.Background(), c.store)
+			assert.Equal(t, nil, err)
+
+			h := sha1.Sum([]byte("doogle"))
+			raw, _ := srv.dht.Load(doogleAddressStr(h[:]))
+			assert.DeepEqual(t, c.expFields, raw.(*dhtValue).fields[itemAddr])
+		})
+	}
+}
+
+func TestNode_StoreItem_future(t *testing.T) {
+	resetDHT()
+	defer resetDHT()
+
+	srv := testServers[0].node
+	ct := srv.certificate
+
+	_, err := srv.StoreItem(context.Background(), &doogle.StoreItemRequest{
+		Certificate: ct, Url: "http://url1/", Index: "doogle", Timestamp: time.Now().Add(time.Hour).UnixNano(),
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.DeepEqual(t, []string{}, urlsOf(srv, "doogle"))
+
+	// within the clock skew
+	now := time.Now().Add(maxClockSkew / 2).UnixNano()
+	_, err = srv.StoreItem(context.Background(), &doogle.StoreItemRequest{Certificate: ct, Url: "http://url1/", Index: "doogle", Timestamp: now})
+	assert.Equal(t, nil, err)
+	assert.DeepEqual(t, []string{"http://url1/"}, urlsOf(srv, "doogle"))
+
+	srv.deleteItem(&doogle.Tombstone{Url: "http://url1/", Index: "doogle", Timestamp: now + 1})
+	assert.DeepEqual(t, []string{}, urlsOf(srv, "doogle"))
+}
+
+func TestNode_PostUrl_replace(t *testing.T) {
+	resetRoutingTable()
+	defer resetRoutingTable()
+	resetDHT()
+	defer resetDHT()
+
+	srv := testServers[0].node
+	defer func() { srv.crawler = &mockCrawler{} }()
+
+	for i, cc := range []struct {
+		cr       *mockCrawler
+		expTerms map[string][]string
+	}{
+		{
+			cr: &mockCrawler{title: "title1", tokens: []*crawler.Token{{Term: "doogle"}, {Term: "kademlia"}}},
+			expTerms: map[string][]string{
+				"doogle": {"http://url1/"}, "kademlia": {"http://url1/"}, "pagerank": {},
+			},
+		},
+		{
+			cr: &mockCrawler{title: "title1", tokens: []*crawler.Token{{Term: "kademlia"}, {Term: "pagerank"}}},
+			expTerms: map[string][]string{
+				"doogle": {}, "kademlia": {"http://url1/"}, "pagerank": {"http://url1/"},
+			},
+		},
+		{
+			cr: &mockCrawler{err: crawler.ErrPageGone},
+			expTerms: map[string][]string{
+				"doogle": {}, "kademlia": {}, "pagerank": {},
+			},
+		},
+	} {
+		c := cc
+		t.Run(fmt.Sprintf("%d-th case", i), func(t *testing.T) {
+			srv.crawler = c.cr
+			_, err := srv.PostUrl(context.Background(), &doogle.StringMessage{Message: "http://url1/"})
+			assert.Equal(t, nil, err)
+
+			for term, exp := range c.expTerms {
+				assert.DeepEqual(t, exp, urlsOf(srv, term))
+			}
+		})
+	}
+
+	doc := srv.findDocument(context.Background(), "http://url1/")
+	assert.Equal(t, true, doc.Deleted)
+	assert.Equal(t, 0, len(doc.Terms))
+}
+
+func TestNode_PostUrl_gone(t *testing.T) {
+	resetRoutingTable()
+	defer resetRoutingTable()
+	resetDHT()
+	defer resetDHT()
+
+	srv := testServers[0].node
+	defer func() { srv.crawler = &mockCrawler{} }()
+
+	srv.crawler = &mockCrawler{
+		title:    "a",
+		tokens:   []*crawler.Token{{Term: "doogle"}},
+		edgeURLs: []string{"b.com"},
+		anchors:  []*crawler.Anchor{{URL: "b.com", Terms: []string{"kademlia"}}},
+	}
+	_, err := srv.PostUrl(context.Background(), &doogle.StringMessage{Message: "a.com"})
+	assert.Equal(t, nil, err)
+	assert.DeepEqual(t, []string{"http://b.com/"}, urlsOf(srv, "kademlia"))
+
+	// the page only known from the anchor text is gone
+	srv.crawler = &mockCrawler{err: crawler.ErrPageGone}
+	_, err = srv.PostUrl(context.Background(), &doogle.StringMessage{Message: "b.com"})
+	assert.Equal(t, nil, err)
+	assert.DeepEqual(t, []string{}, urlsOf(srv, "kademlia"))
+	assert.DeepEqual(t, []string{"http://a.com/"}, urlsOf(srv, "doogle"))
+
+	h := sha1.Sum([]byte("http://b.com/"))
+	_, ok := srv.items.Load(doogleAddressStr(h[:]))
+	assert.Equal(t, false, ok)
+}